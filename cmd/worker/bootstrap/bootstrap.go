@@ -3,28 +3,53 @@ package bootstrap
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"net/http"
 	"time"
 
 	"mms_api/config"
-	"mms_api/internal/adapter/out/mercadobitcoin"
+	httpAdapter "mms_api/internal/adapter/in/http"
 	"mms_api/internal/adapter/out/persistence/postgres"
 	"mms_api/internal/application/port/out"
 	"mms_api/internal/application/service"
+	app "mms_api/internal/bootstrap"
+	"mms_api/internal/domain/model"
 	dbconfig "mms_api/pkg/db/postgres"
+	"mms_api/pkg/httpclient"
 	"mms_api/pkg/logger"
 	"mms_api/pkg/monitoring"
 
 	"github.com/go-co-op/gocron"
 )
 
+// DefaultBackfillLimit é a quantidade padrão de lacunas recalculadas por execução
+const DefaultBackfillLimit = 10
+
 type Worker struct {
-	mmsService    service.MMSService
-	mmsRepo       out.MMSRepository
-	alertMonitor  monitoring.AlertMonitor
-	logger        logger.Logger
-	db            *sql.DB
-	retryInterval time.Duration // Intervalo de retry configurável
+	mmsService     service.MMSService
+	pairService    service.PairService
+	mmsRepo        out.MMSRepository
+	alertMonitor   monitoring.AlertMonitor
+	logger         logger.Logger
+	db             *sql.DB
+	retryInterval  time.Duration // Intervalo de retry configurável
+	backfillLimit  int           // Máximo de lacunas recalculadas por execução
+	backfillBudget int           // Lacunas que ainda podem ser recalculadas na execução atual
+
+	reconciler            service.ReconciliationService // Reconciliação entre provedores (nil desativa)
+	reconciliationCandles int                           // Candles mais recentes reconciliados por par e resolução
+
+	breakers []*httpclient.CircuitBreaker // Circuit breakers dos provedores, expostos pelo servidor de status
+}
+
+// BackfillReport resume o preenchimento automático das lacunas de um par em uma resolução
+type BackfillReport struct {
+	Pair       string
+	Resolution model.Resolution
+	Filled     []model.TimeRange // Lacunas recalculadas e completas
+	Unfilled   []model.TimeRange // Lacunas que continuam sem dados após o recálculo (ex.: a exchange não tem candle)
+	Deferred   []model.TimeRange // Lacunas acima do limite da execução, deixadas para a próxima
 }
 
 func NewWorker(cfg *config.Config) (*Worker, error) {
@@ -40,39 +65,68 @@ func NewWorker(cfg *config.Config) (*Worker, error) {
 
 	// Inicializar repositório
 	mmsRepo := postgres.NewMMSRepository(db, l)
-
-	// Inicializar HTTP client para a API de candles
-	httpClient := &http.Client{
-		Timeout: 30 * time.Second,
-	}
-
-	// Inicializar API de candles
-	candleAPI := mercadobitcoin.NewCandleAPI(cfg.MercadoBitcoinBaseURL, httpClient, l)
-
-	// Inicializar serviço
-	mmsService := service.NewMMSService(mmsRepo, candleAPI, l)
+	indicatorRepo := postgres.NewIndicatorRepository(db, l)
+	candleRepo := postgres.NewCandleRepository(db, l)
+	signalRepo := postgres.NewSignalRepository(db, l)
+	pairRepo := postgres.NewPairRepository(db, l)
+	divergenceRepo := postgres.NewDivergenceRepository(db, l)
 
 	// Inicializar monitor de alertas
 	alertMonitor := monitoring.NewAlertMonitor(cfg.AlertConfig, l)
 
-	return &Worker{
+	// Inicializar o provedor de candles configurado
+	candleAPI, breakers, err := app.NewCandleAPI(cfg, l, alertMonitor)
+	if err != nil {
+		l.Error("Erro ao criar o provedor de candles", err)
+		return nil, err
+	}
+
+	// Inicializar serviços
+	pairService := service.NewPairService(pairRepo, l)
+	mmsService := service.NewMMSService(mmsRepo, candleAPI, l,
+		service.WithPeriods(cfg.MMSPeriods...),
+		service.WithResolutions(cfg.Resolutions...),
+		service.WithIndicatorSet(service.NewIndicatorSet(cfg.Indicators)),
+		service.WithIndicatorRepository(indicatorRepo),
+		service.WithCandleRepository(candleRepo),
+		service.WithSignalRepository(signalRepo),
+		service.WithPairService(pairService),
+		service.WithValidationPolicy(cfg.CandleValidationPolicy),
+		service.WithAlertMonitor(alertMonitor),
+	)
+
+	worker := &Worker{
 		mmsService:    mmsService,
+		pairService:   pairService,
 		mmsRepo:       mmsRepo,
 		alertMonitor:  alertMonitor,
 		logger:        l,
 		db:            db,
 		retryInterval: 1 * time.Hour, // Valor padrão
-	}, nil
+		backfillLimit: cfg.BackfillMaxRanges,
+		breakers:      breakers,
+	}
+
+	// Com mais de um provedor, os candles recentes do principal são reconciliados com os do seguinte
+	if providers := candleAPI.Providers(); len(providers) > 1 && cfg.ReconciliationCandles > 0 {
+		reconciler := service.NewReconciliationService(providers[0], providers[1], divergenceRepo,
+			cfg.ReconciliationTolerance, l, alertMonitor)
+		worker.SetReconciliation(reconciler, cfg.ReconciliationCandles)
+	}
+
+	return worker, nil
 }
 
 // NewWorkerWithDeps cria um novo worker com dependências injetadas (usado para testes)
-func NewWorkerWithDeps(mmsService service.MMSService, mmsRepo out.MMSRepository, alertMonitor monitoring.AlertMonitor, l logger.Logger) *Worker {
+func NewWorkerWithDeps(mmsService service.MMSService, pairService service.PairService, mmsRepo out.MMSRepository, alertMonitor monitoring.AlertMonitor, l logger.Logger) *Worker {
 	return &Worker{
 		mmsService:    mmsService,
+		pairService:   pairService,
 		mmsRepo:       mmsRepo,
 		alertMonitor:  alertMonitor,
 		logger:        l,
 		retryInterval: 100 * time.Millisecond, // Valor menor para testes
+		backfillLimit: DefaultBackfillLimit,
 	}
 }
 
@@ -81,6 +135,42 @@ func (w *Worker) SetRetryInterval(interval time.Duration) {
 	w.retryInterval = interval
 }
 
+// SetBackfillLimit configura o máximo de lacunas recalculadas por execução (0 desativa o preenchimento)
+func (w *Worker) SetBackfillLimit(limit int) {
+	w.backfillLimit = limit
+}
+
+// SetReconciliation configura a reconciliação dos últimos candles de cada par e resolução entre dois
+// provedores após a atualização (reconciler nil ou candles 0 desativa a reconciliação)
+func (w *Worker) SetReconciliation(reconciler service.ReconciliationService, candles int) {
+	w.reconciler = reconciler
+	w.reconciliationCandles = candles
+}
+
+// ServeStatus atende o servidor de status do worker na porta informada até o cancelamento de ctx. O
+// /health informa o estado e os contadores dos circuit breakers dos provedores de candles (status
+// "degraded" enquanto algum circuito não está fechado) e o /metrics os expõe para o Prometheus.
+func (w *Worker) ServeStatus(ctx context.Context, port string) error {
+	srv := &http.Server{
+		Addr:              fmt.Sprintf("0.0.0.0:%s", port),
+		Handler:           httpAdapter.NewStatusRouter(w.breakers...).SetupRoutes(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	w.logger.Info("Servidor de status iniciado", "addr", srv.Addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
 // Close fecha as conexões do worker
 func (w *Worker) Close() error {
 	return w.db.Close()
@@ -95,76 +185,193 @@ func (w *Worker) Run() error {
 	// Configurações de retry
 	maxRetries := 5
 
-	// Pares a serem processados
-	pairs := []string{"BRLBTC", "BRLETH"}
+	// Pares a serem processados: os habilitados no registro de pares
+	pairs, err := w.pairService.EnabledPairs(ctx)
+	if err != nil {
+		w.logger.Error("Erro ao obter pares habilitados", err)
+		w.alertMonitor.SendAlert("falha_atualizacao", "Falha ao obter os pares habilitados do registro")
+		return err
+	}
+	if len(pairs) == 0 {
+		w.logger.Info("Nenhum par habilitado no registro")
+		return nil
+	}
+
+	// O limite de lacunas recalculadas vale para a execução inteira, somando todos os pares
+	w.backfillBudget = w.backfillLimit
 
 	for _, pair := range pairs {
-		// Obter última data processada
-		lastTimestamp, err := w.mmsRepo.GetLastTimestamp(ctx, pair)
-		if err != nil {
-			w.logger.Error("Erro ao obter último timestamp", err, "pair", pair)
-			continue
+		for _, resolution := range w.mmsService.Resolutions() {
+			w.processPair(ctx, pair, resolution, maxRetries)
 		}
+	}
+
+	return nil
+}
+
+// processPair atualiza as médias de um par em uma resolução, do candle seguinte ao último
+// processado até o último candle completo, verifica a completude dos dados e preenche as lacunas
+func (w *Worker) processPair(ctx context.Context, pair string, resolution model.Resolution, maxRetries int) {
+	// Obter último candle processado
+	lastTimestamp, err := w.mmsRepo.GetLastTimestamp(ctx, pair, resolution)
+	if err != nil {
+		w.logger.Error("Erro ao obter último timestamp", err, "pair", pair, "resolution", resolution)
+		return
+	}
+
+	// Se não houver dados, começar do início (último ano)
+	var from time.Time
+	if lastTimestamp.IsZero() {
+		from = resolution.Truncate(time.Now().AddDate(-1, 0, 0))
+	} else {
+		// Caso contrário, começar do candle seguinte ao último processado
+		from = resolution.Next(resolution.Truncate(lastTimestamp))
+	}
+
+	// Calcular até o último candle completo disponível (ontem, na resolução diária)
+	to := resolution.Truncate(time.Now()).Add(-resolution.Duration())
 
-		// Se não houver dados, começar do início (último ano)
-		var from time.Time
-		if lastTimestamp.IsZero() {
-			from = time.Now().AddDate(-1, 0, 0)
+	// Se 'from' for posterior a 'to', não há candles novos; as lacunas ainda são verificadas
+	if from.After(to) {
+		w.logger.Info("Dados já atualizados", "pair", pair, "resolution", resolution)
+	} else {
+		w.update(ctx, pair, resolution, from, to, maxRetries)
+	}
+
+	w.reconcile(ctx, pair, resolution, to)
+
+	// Verificar completude dos dados
+	isComplete, missingDates, err := w.mmsService.CheckDataCompleteness(ctx, pair, resolution)
+	if err != nil {
+		w.logger.Error("Erro ao verificar completude dos dados", err, "pair", pair, "resolution", resolution)
+		return
+	}
+
+	if !isComplete {
+		w.logger.Info("Dados incompletos detectados", "pair", pair, "resolution", resolution, "missingDates", len(missingDates))
+		report := w.backfill(ctx, pair, resolution, missingDates)
+		w.reportBackfill(report)
+	}
+}
+
+// update calcula as médias do intervalo, com retry em caso de falha
+func (w *Worker) update(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time, maxRetries int) {
+	success := false
+	for attempt := 0; attempt < maxRetries && !success; attempt++ {
+		if attempt > 0 {
+			w.logger.Info("Tentando novamente", "attempt", attempt+1, "pair", pair, "resolution", resolution)
+			time.Sleep(w.retryInterval)
+		}
+
+		err := w.mmsService.CalculateAndSaveMMSForRange(ctx, pair, resolution, from, to)
+		if err == nil {
+			success = true
+			w.logger.Info("Atualização concluída com sucesso", "pair", pair, "resolution", resolution)
 		} else {
-			// Caso contrário, começar do dia seguinte ao último processado
-			from = lastTimestamp.AddDate(0, 0, 1)
+			w.logger.Error("Erro na atualização", err, "pair", pair, "resolution", resolution, "attempt", attempt+1)
 		}
+	}
+
+	if !success {
+		w.logger.Error("Falha após todas as tentativas", "pair", pair, "resolution", resolution)
+		w.alertMonitor.SendAlert("falha_atualizacao", fmt.Sprintf("Falha na atualização de %s (%s)", pair, resolution))
+	}
+}
 
-		// Normalizar 'from' para o início do dia (UTC)
-		from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+// reconcile compara entre os provedores os últimos candles completos do par, até to. As divergências são
+// gravadas e alertadas pelo serviço; uma falha na reconciliação não interrompe o processamento do par.
+func (w *Worker) reconcile(ctx context.Context, pair string, resolution model.Resolution, to time.Time) {
+	if w.reconciler == nil || w.reconciliationCandles <= 0 {
+		return
+	}
 
-		// Calcular até ontem (último dia completo disponível)
-		today := time.Now().UTC()
-		to := today.AddDate(0, 0, -1)
-		to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	from := to
+	for i := 1; i < w.reconciliationCandles; i++ {
+		from = resolution.Truncate(from.Add(-time.Nanosecond))
+	}
 
-		// Se 'from' for posterior a 'to', não há nada a processar
-		if from.After(to) {
-			w.logger.Info("Dados já atualizados", "pair", pair)
+	if _, err := w.reconciler.Reconcile(ctx, pair, resolution, from, to); err != nil {
+		w.logger.Error("Erro na reconciliação entre provedores", err, "pair", pair, "resolution", resolution)
+	}
+}
+
+// backfill agrupa os candles ausentes em intervalos contíguos e recalcula cada um pelo serviço,
+// respeitando o limite de lacunas da execução. Depois do recálculo a completude é verificada de novo
+// para separar as lacunas preenchidas das que continuam sem dados.
+func (w *Worker) backfill(ctx context.Context, pair string, resolution model.Resolution, missingDates []time.Time) BackfillReport {
+	report := BackfillReport{Pair: pair, Resolution: resolution}
+
+	var attempted []model.TimeRange
+	for _, gap := range resolution.ContiguousRanges(missingDates) {
+		if w.backfillBudget <= 0 {
+			report.Deferred = append(report.Deferred, gap)
 			continue
 		}
+		w.backfillBudget--
+		attempted = append(attempted, gap)
 
-		// Processar com retry em caso de falha
-		success := false
-		for attempt := 0; attempt < maxRetries && !success; attempt++ {
-			if attempt > 0 {
-				w.logger.Info("Tentando novamente", "attempt", attempt+1, "pair", pair)
-				time.Sleep(w.retryInterval)
-			}
+		// Uma única tentativa por lacuna; as que falharem voltam a ser detectadas na próxima execução
+		if err := w.mmsService.CalculateAndSaveMMSForRange(ctx, pair, resolution, gap.From, gap.To); err != nil {
+			w.logger.Error("Erro ao preencher lacuna", err, "pair", pair, "resolution", resolution, "from", gap.From, "to", gap.To)
+		}
+	}
+
+	if len(attempted) == 0 {
+		return report
+	}
 
-			err := w.mmsService.CalculateAndSaveMMSForRange(ctx, pair, from, to)
-			if err == nil {
-				success = true
-				w.logger.Info("Atualização concluída com sucesso", "pair", pair)
-			} else {
-				w.logger.Error("Erro na atualização", err, "pair", pair, "attempt", attempt+1)
+	_, remaining, err := w.mmsService.CheckDataCompleteness(ctx, pair, resolution)
+	if err != nil {
+		w.logger.Error("Erro ao verificar completude após o preenchimento", err, "pair", pair, "resolution", resolution)
+		report.Unfilled = attempted
+		return report
+	}
+
+	remainingRanges := resolution.ContiguousRanges(remaining)
+	for _, gap := range attempted {
+		filled := true
+		for _, r := range remainingRanges {
+			if gap.Contains(r.From) || gap.Contains(r.To) || r.Contains(gap.From) {
+				filled = false
+				report.Unfilled = append(report.Unfilled, clampRange(r, gap))
 			}
 		}
-
-		if !success {
-			w.logger.Error("Falha após todas as tentativas", "pair", pair)
-			w.alertMonitor.SendAlert("falha_atualizacao", "Falha na atualização diária de "+pair)
+		if filled {
+			report.Filled = append(report.Filled, gap)
 		}
+	}
 
-		// Verificar completude dos dados
-		isComplete, missingDates, err := w.mmsService.CheckDataCompleteness(ctx, pair)
-		if err != nil {
-			w.logger.Error("Erro ao verificar completude dos dados", err, "pair", pair)
-			continue
-		}
+	return report
+}
 
-		if !isComplete {
-			w.logger.Info("Dados incompletos detectados", "pair", pair, "missingDates", missingDates)
-			w.alertMonitor.SendAlert("dados_incompletos", "Dados incompletos para "+pair)
-		}
+// clampRange restringe o intervalo r aos limites de bounds
+func clampRange(r, bounds model.TimeRange) model.TimeRange {
+	if r.From.Before(bounds.From) {
+		r.From = bounds.From
+	}
+	if r.To.After(bounds.To) {
+		r.To = bounds.To
 	}
+	return r
+}
 
-	return nil
+// reportBackfill registra o resultado do preenchimento e alerta quando restam lacunas
+func (w *Worker) reportBackfill(report BackfillReport) {
+	w.logger.Info("Preenchimento de lacunas concluído", "pair", report.Pair, "resolution", report.Resolution,
+		"filled", len(report.Filled), "unfilled", len(report.Unfilled), "deferred", len(report.Deferred))
+
+	if len(report.Unfilled) == 0 && len(report.Deferred) == 0 {
+		return
+	}
+
+	message := fmt.Sprintf("Dados incompletos para %s (%s)", report.Pair, report.Resolution)
+	if len(report.Unfilled) > 0 {
+		message += fmt.Sprintf(". Lacunas não preenchidas: %s", report.Resolution.FormatTimeRanges(report.Unfilled))
+	}
+	if len(report.Deferred) > 0 {
+		message += fmt.Sprintf(". %d lacuna(s) adiada(s) para a próxima execução: %s", len(report.Deferred), report.Resolution.FormatTimeRanges(report.Deferred))
+	}
+	w.alertMonitor.SendAlert("dados_incompletos", message)
 }
 
 // RunScheduled executa o worker em um intervalo programado
@@ -27,6 +27,13 @@ func main() {
 	}
 	defer worker.Close()
 
+	// Servidor de status com o estado dos circuit breakers dos provedores
+	go func() {
+		if err := worker.ServeStatus(ctx, cfg.WorkerStatusPort); err != nil {
+			log.Printf("Erro no servidor de status do worker: %v", err)
+		}
+	}()
+
 	// Configurar intervalo de execução (por exemplo, uma vez por dia às 00:00)
 	interval := 24 * time.Hour
 
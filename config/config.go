@@ -1,28 +1,145 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
+	"mms_api/internal/domain/indicator"
+	"mms_api/internal/domain/model"
+	"mms_api/internal/domain/quality"
+	"mms_api/internal/domain/reconciliation"
 	"mms_api/pkg/db/postgres"
+	"mms_api/pkg/httpclient"
 	"mms_api/pkg/monitoring"
+
+	"github.com/shopspring/decimal"
+)
+
+// Provedores de candles suportados
+const (
+	ProviderMercadoBitcoin = "mercadobitcoin"
+	ProviderBinance        = "binance"
+	ProviderFile           = "file" // Arquivos CSV ou JSON Lines locais, sem acesso à rede
 )
 
+// SupportedCandleProviders lista os provedores de candles suportados
+var SupportedCandleProviders = []string{ProviderMercadoBitcoin, ProviderBinance, ProviderFile}
+
 type Config struct {
 	// Database configuration
 	Database postgres.Config
 
+	// Provedores de candles em ordem de preferência: o primeiro é o principal e os demais são usados
+	// quando os anteriores falham ou respondem com candles faltando
+	CandleProviders []string
+
 	// MercadoBitcoin configuration
 	MercadoBitcoinBaseURL string
 
+	// Símbolos do Mercado Bitcoin que diferem do formato padrão BASE-COTAÇÃO (ex.: BRLMATIC -> POL-BRL)
+	MercadoBitcoinSymbols map[string]string
+
+	// Máximo de candles por requisição ao Mercado Bitcoin; intervalos maiores são divididos em janelas
+	MercadoBitcoinMaxCandles int
+
+	// Máximo de janelas de um intervalo buscadas simultaneamente no Mercado Bitcoin
+	MercadoBitcoinConcurrency int
+
+	// Limite de requisições por segundo ao Mercado Bitcoin e rajada máxima (0 desativa o limite)
+	MercadoBitcoinRateLimit float64
+	MercadoBitcoinRateBurst int
+
+	// Binance (ou API compatível com o formato de klines da Binance)
+	BinanceBaseURL    string
+	BinanceSymbols    map[string]string // Símbolos que diferem do formato padrão BASECOTAÇÃO (ex.: BRLMATIC -> POLBRL)
+	BinanceMaxCandles int
+	BinanceRateLimit  float64
+	BinanceRateBurst  int
+
+	// Arquivos de candles locais, um por par e resolução (ex.: BRLBTC_1d.csv)
+	CandleFileDir        string
+	CandleFileFormat     string            // csv ou jsonl
+	CandleFileColumns    map[string]string // Nomes das colunas que diferem do campo (ex.: timestamp -> time)
+	CandleFileTimezone   string            // Fuso dos timestamps sem fuso explícito
+	CandleFileTimeLayout string            // unix, unix_ms ou layout do pacote time; vazio detecta o formato
+
+	// Novas tentativas das requisições aos provedores de candles (erros de rede, 429 e 5xx)
+	HTTPRetry httpclient.RetryPolicy
+
+	// Circuit breaker dos provedores de candles
+	CircuitBreaker httpclient.CircuitBreakerConfig
+
+	// Resoluções de candle calculadas e consultáveis (ex.: 1h, 4h, 1d, 1w)
+	Resolutions []model.Resolution
+
+	// Janelas de média móvel calculadas e consultáveis (ex.: 7, 9, 20, 21, 50, 100, 200)
+	MMSPeriods []int
+
+	// Parâmetros dos indicadores técnicos adicionais
+	Indicators indicator.Config
+
+	// Máximo de lacunas de dados recalculadas automaticamente pelo worker em cada execução
+	BackfillMaxRanges int
+
+	// Quantidade de candles mais recentes reconciliados entre os dois primeiros provedores em cada
+	// execução do worker (0 desativa a reconciliação)
+	ReconciliationCandles int
+
+	// Diferenças aceitas entre os provedores na reconciliação, em percentual
+	ReconciliationTolerance reconciliation.Tolerance
+
+	// Tratamento dos candles com problemas de qualidade (reject, drop ou forward_fill)
+	CandleValidationPolicy quality.Policy
+
+	// Porta do servidor de status do worker (/health e /metrics)
+	WorkerStatusPort string
+
+	// Token exigido pelas rotas de administração (vazio desativa as rotas, que respondem 503)
+	AdminToken string
+
 	// Alert configuration
 	AlertConfig monitoring.AlertConfig
 }
 
 // Load carrega as configurações do ambiente
 func Load() (*Config, error) {
+	periods := model.DefaultPeriods
+	if value := os.Getenv("MMS_PERIODS"); value != "" {
+		parsed, err := model.ParsePeriods(value)
+		if err != nil {
+			return nil, err
+		}
+		periods = parsed
+	}
+
+	resolutions := []model.Resolution{model.DefaultResolution}
+	if value := os.Getenv("RESOLUTIONS"); value != "" {
+		parsed, err := model.ParseResolutions(value)
+		if err != nil {
+			return nil, err
+		}
+		resolutions = parsed
+	}
+
+	validationPolicy := quality.DefaultPolicy
+	if value := os.Getenv("CANDLE_VALIDATION_POLICY"); value != "" {
+		parsed, err := quality.ParsePolicy(value)
+		if err != nil {
+			return nil, err
+		}
+		validationPolicy = parsed
+	}
+
+	providers, err := parseCandleProviders(getEnv("CANDLE_PROVIDERS", ProviderMercadoBitcoin))
+	if err != nil {
+		return nil, err
+	}
+
 	return &Config{
+		CandleProviders: providers,
 		Database: postgres.Config{
 			Host:     os.Getenv("DB_HOST"),
 			Port:     os.Getenv("DB_PORT"),
@@ -30,7 +147,54 @@ func Load() (*Config, error) {
 			Password: os.Getenv("DB_PASSWORD"),
 			DBName:   os.Getenv("DB_NAME"),
 		},
-		MercadoBitcoinBaseURL: os.Getenv("MB_API_URL"),
+		MercadoBitcoinBaseURL:     os.Getenv("MB_API_URL"),
+		MercadoBitcoinSymbols:     getEnvAsMap("MB_SYMBOL_OVERRIDES", ","),
+		MercadoBitcoinMaxCandles:  getEnvAsInt("MB_MAX_CANDLES_PER_REQUEST", 500),
+		MercadoBitcoinConcurrency: getEnvAsInt("MB_FETCH_CONCURRENCY", 4),
+		MercadoBitcoinRateLimit:   getEnvAsFloat("MB_RATE_LIMIT_RPS", 5),
+		MercadoBitcoinRateBurst:   getEnvAsInt("MB_RATE_LIMIT_BURST", 5),
+		BinanceBaseURL:            getEnv("BINANCE_API_URL", "https://api.binance.com"),
+		BinanceSymbols:            getEnvAsMap("BINANCE_SYMBOL_OVERRIDES", ","),
+		BinanceMaxCandles:         getEnvAsInt("BINANCE_MAX_CANDLES_PER_REQUEST", 1000),
+		BinanceRateLimit:          getEnvAsFloat("BINANCE_RATE_LIMIT_RPS", 10),
+		BinanceRateBurst:          getEnvAsInt("BINANCE_RATE_LIMIT_BURST", 10),
+		CandleFileDir:             getEnv("CANDLE_FILE_DIR", "data/candles"),
+		CandleFileFormat:          getEnv("CANDLE_FILE_FORMAT", "csv"),
+		CandleFileColumns:         getEnvAsMap("CANDLE_FILE_COLUMNS", ","),
+		CandleFileTimezone:        getEnv("CANDLE_FILE_TIMEZONE", "UTC"),
+		CandleFileTimeLayout:      os.Getenv("CANDLE_FILE_TIME_FORMAT"),
+		HTTPRetry: httpclient.RetryPolicy{
+			MaxAttempts: getEnvAsInt("HTTP_RETRY_MAX_ATTEMPTS", httpclient.DefaultRetryPolicy.MaxAttempts),
+			BaseDelay:   getEnvAsDuration("HTTP_RETRY_BASE_DELAY", httpclient.DefaultRetryPolicy.BaseDelay),
+			MaxDelay:    getEnvAsDuration("HTTP_RETRY_MAX_DELAY", httpclient.DefaultRetryPolicy.MaxDelay),
+		},
+		CircuitBreaker: httpclient.CircuitBreakerConfig{
+			FailureThreshold: getEnvAsInt("CIRCUIT_BREAKER_FAILURE_THRESHOLD", httpclient.DefaultCircuitBreakerConfig.FailureThreshold),
+			OpenTimeout:      getEnvAsDuration("CIRCUIT_BREAKER_OPEN_TIMEOUT", httpclient.DefaultCircuitBreakerConfig.OpenTimeout),
+			HalfOpenProbes:   getEnvAsInt("CIRCUIT_BREAKER_HALF_OPEN_PROBES", httpclient.DefaultCircuitBreakerConfig.HalfOpenProbes),
+		},
+		Resolutions: resolutions,
+		MMSPeriods:  periods,
+		Indicators: indicator.Config{
+			BollingerPeriod:  getEnvAsInt("BOLLINGER_PERIOD", 20),
+			RSIPeriod:        getEnvAsInt("RSI_PERIOD", 14),
+			MACDFast:         getEnvAsInt("MACD_FAST", 12),
+			MACDSlow:         getEnvAsInt("MACD_SLOW", 26),
+			MACDSignal:       getEnvAsInt("MACD_SIGNAL", 9),
+			VWAPPeriod:       getEnvAsInt("VWAP_PERIOD", 20),
+			ATRPeriod:        getEnvAsInt("ATR_PERIOD", 14),
+			VolatilityWindow: getEnvAsInt("VOLATILITY_WINDOW", 30),
+			RangePeriod:      getEnvAsInt("RANGE_PERIOD", 14),
+		},
+		BackfillMaxRanges:     getEnvAsInt("BACKFILL_MAX_RANGES", 10),
+		ReconciliationCandles: getEnvAsInt("RECONCILIATION_CANDLES", 7),
+		ReconciliationTolerance: reconciliation.Tolerance{
+			Close:  decimal.NewFromFloat(getEnvAsFloat("RECONCILIATION_CLOSE_TOLERANCE", 1)),
+			Volume: decimal.NewFromFloat(getEnvAsFloat("RECONCILIATION_VOLUME_TOLERANCE", 50)),
+		},
+		CandleValidationPolicy: validationPolicy,
+		WorkerStatusPort:       getEnv("WORKER_STATUS_PORT", "9091"),
+		AdminToken:             os.Getenv("ADMIN_TOKEN"),
 		AlertConfig: monitoring.AlertConfig{
 			Enabled: os.Getenv("ALERT_ENABLED") == "true",
 			Email: monitoring.EmailConfig{
@@ -46,6 +210,44 @@ func Load() (*Config, error) {
 	}, nil
 }
 
+// parseCandleProviders valida a lista ordenada de provedores de candles (ex.: "mercadobitcoin,binance")
+func parseCandleProviders(value string) ([]string, error) {
+	var providers []string
+	seen := make(map[string]bool)
+	for _, item := range strings.Split(value, ",") {
+		provider := strings.ToLower(strings.TrimSpace(item))
+		if provider == "" {
+			continue
+		}
+
+		supported := false
+		for _, p := range SupportedCandleProviders {
+			supported = supported || p == provider
+		}
+		if !supported {
+			return nil, fmt.Errorf("provedor de candles inválido: %q (use %s)", item, strings.Join(SupportedCandleProviders, ", "))
+		}
+		if seen[provider] {
+			return nil, fmt.Errorf("provedor de candles repetido: %q", item)
+		}
+		seen[provider] = true
+		providers = append(providers, provider)
+	}
+
+	if len(providers) == 0 {
+		return nil, fmt.Errorf("nenhum provedor de candles configurado")
+	}
+	return providers, nil
+}
+
+// getEnv retorna uma variável de ambiente ou o valor padrão quando ela não está definida
+func getEnv(key string, defaultVal string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultVal
+}
+
 // getEnvAsInt retorna uma variável de ambiente como inteiro
 func getEnvAsInt(key string, defaultVal int) int {
 	if value := os.Getenv(key); value != "" {
@@ -56,6 +258,26 @@ func getEnvAsInt(key string, defaultVal int) int {
 	return defaultVal
 }
 
+// getEnvAsFloat retorna uma variável de ambiente como número decimal
+func getEnvAsFloat(key string, defaultVal float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	}
+	return defaultVal
+}
+
+// getEnvAsDuration retorna uma variável de ambiente como duração (ex.: 500ms, 30s)
+func getEnvAsDuration(key string, defaultVal time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return defaultVal
+}
+
 // getEnvAsSlice retorna uma variável de ambiente como slice usando o separador fornecido
 func getEnvAsSlice(key string, sep string) []string {
 	if value := os.Getenv(key); value != "" {
@@ -64,6 +286,22 @@ func getEnvAsSlice(key string, sep string) []string {
 	return []string{}
 }
 
+// getEnvAsMap retorna uma variável de ambiente no formato chave=valor, separada pelo separador fornecido
+func getEnvAsMap(key string, sep string) map[string]string {
+	result := make(map[string]string)
+	for _, entry := range getEnvAsSlice(key, sep) {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		k, v := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		if k != "" && v != "" {
+			result[k] = v
+		}
+	}
+	return result
+}
+
 // Para testar alertas de email com Mailhog:
 // - Rode: docker run -d -p 1025:1025 -p 8025:8025 mailhog/mailhog
 // - Configure as variáveis de ambiente:
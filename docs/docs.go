@@ -15,9 +15,411 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin/pairs": {
+            "get": {
+                "description": "Retorna todos os pares cadastrados no registro, habilitados ou não",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Administração"
+                ],
+                "summary": "Listar pares",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token de administração, igual ao ADMIN_TOKEN configurado",
+                        "name": "X-Admin-Token",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Lista de pares",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.PairResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Token de administração inválido",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Rotas de administração desativadas: ADMIN_TOKEN não configurado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Cadastra um novo par no registro, informado no formato canônico (cotação e base, ex.: BRLBTC) ou com separador (base e cotação, ex.: SOL-USDT). O par fica habilitado por padrão e passa a ser processado na próxima execução do worker",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Administração"
+                ],
+                "summary": "Cadastrar par",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token de administração, igual ao ADMIN_TOKEN configurado",
+                        "name": "X-Admin-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Par a cadastrar",
+                        "name": "pair",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreatePairRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Par cadastrado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PairResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de validação",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Token de administração inválido",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Par já cadastrado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Rotas de administração desativadas: ADMIN_TOKEN não configurado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/pairs/{symbol}": {
+            "patch": {
+                "description": "Habilita ou desabilita um par cadastrado. Pares desabilitados deixam de ser processados pelo worker e consultáveis pela API",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Administração"
+                ],
+                "summary": "Habilitar ou desabilitar par",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token de administração, igual ao ADMIN_TOKEN configurado",
+                        "name": "X-Admin-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Símbolo do par (ex.: BRLBTC ou BTC-BRL)",
+                        "name": "symbol",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Novo estado do par",
+                        "name": "pair",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdatePairRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Par atualizado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PairResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de validação",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Token de administração inválido",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Par não cadastrado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Rotas de administração desativadas: ADMIN_TOKEN não configurado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/{pair}/bollinger": {
+            "get": {
+                "description": "Retorna as Bandas de Bollinger (média ± k desvios padrão dos fechamentos) e a largura relativa das bandas para um par de criptomoedas em um intervalo de tempo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Indicadores"
+                ],
+                "summary": "Obter Bandas de Bollinger",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Par de criptomoedas habilitado no registro de pares (ex.: BRLBTC ou BTC-BRL)",
+                        "name": "pair",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Timestamp Unix de início",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Timestamp Unix de fim (opcional, default: dia anterior)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resolução dos candles (1h, 4h, 1d ou 1w, entre as configuradas; default: 1d)",
+                        "name": "resolution",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Quantidade de desvios padrão das bandas (default: 2)",
+                        "name": "k",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Lista de Bandas de Bollinger",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.BollingerResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de validação",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/{pair}/indicators/{name}": {
+            "get": {
+                "description": "Retorna os valores de um indicador técnico registrado (ex.: sma20, ema50, rsi14, macd12_26_9, vwap20, obv, atr14, volatility30, range14) para um par de criptomoedas em um intervalo de tempo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Indicadores"
+                ],
+                "summary": "Obter indicador técnico",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Par de criptomoedas habilitado no registro de pares (ex.: BRLBTC ou BTC-BRL)",
+                        "name": "pair",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nome do indicador (ex.: sma20, ema50, rsi14, macd12_26_9)",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Timestamp Unix de início",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Timestamp Unix de fim (opcional, default: dia anterior)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resolução dos candles (1h, 4h, 1d ou 1w, entre as configuradas; default: 1d)",
+                        "name": "resolution",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Lista de valores do indicador",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.IndicatorResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de validação",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Indicador desconhecido",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
         "/{pair}/mms": {
             "get": {
-                "description": "Retorna as médias móveis simples (MMS) para um par de criptomoedas em um intervalo de tempo",
+                "description": "Retorna as médias móveis simples (MMS) ou exponenciais (MME) para um par de criptomoedas em um intervalo de tempo",
                 "consumes": [
                     "application/json"
                 ],
@@ -27,11 +429,11 @@ const docTemplate = `{
                 "tags": [
                     "MMS"
                 ],
-                "summary": "Obter médias móveis simples",
+                "summary": "Obter médias móveis",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Par de criptomoedas (BRLBTC ou BRLETH)",
+                        "description": "Par de criptomoedas habilitado no registro de pares (ex.: BRLBTC ou BTC-BRL)",
                         "name": "pair",
                         "in": "path",
                         "required": true
@@ -49,12 +451,24 @@ const docTemplate = `{
                         "name": "to",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "Resolução dos candles (1h, 4h, 1d ou 1w, entre as configuradas; default: 1d)",
+                        "name": "resolution",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Período da média móvel (20, 50 ou 200)",
+                        "description": "Período da média móvel (uma das janelas configuradas, ex.: 20, 50 ou 200)",
                         "name": "range",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tipo da média móvel (sma ou ema, default: sma)",
+                        "name": "type",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -87,19 +501,226 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "/{pair}/signals": {
+            "get": {
+                "description": "Retorna os cruzamentos detectados entre as médias configuradas (golden_cross e death_cross) e entre o fechamento e cada média (price_cross_above e price_cross_below) para um par de criptomoedas em um intervalo de tempo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sinais"
+                ],
+                "summary": "Obter sinais de cruzamento",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Par de criptomoedas habilitado no registro de pares (ex.: BRLBTC ou BTC-BRL)",
+                        "name": "pair",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Timestamp Unix de início",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Timestamp Unix de fim (opcional, default: dia anterior)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resolução dos candles (1h, 4h, 1d ou 1w, entre as configuradas; default: 1d)",
+                        "name": "resolution",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tipos de sinal separados por vírgula (golden_cross, death_cross, price_cross_above, price_cross_below; default: todos)",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Lista de sinais em ordem cronológica",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.SignalResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de validação",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
+        "handlers.BollingerResponse": {
+            "type": "object",
+            "properties": {
+                "lower": {
+                    "type": "number",
+                    "example": 43000
+                },
+                "middle": {
+                    "type": "number",
+                    "example": 45000
+                },
+                "timestamp": {
+                    "type": "integer",
+                    "example": 1620000000
+                },
+                "upper": {
+                    "type": "number",
+                    "example": 47000
+                },
+                "width": {
+                    "type": "number",
+                    "example": 0.0889
+                }
+            }
+        },
+        "handlers.CreatePairRequest": {
+            "type": "object",
+            "required": [
+                "symbol"
+            ],
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "symbol": {
+                    "type": "string",
+                    "example": "SOL-USDT"
+                }
+            }
+        },
+        "handlers.IndicatorResponse": {
+            "type": "object",
+            "properties": {
+                "timestamp": {
+                    "type": "integer",
+                    "example": 1620000000
+                },
+                "values": {
+                    "description": "séries null enquanto o histórico não cobre a janela",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                }
+            }
+        },
         "handlers.MMSResponse": {
             "type": "object",
             "properties": {
                 "mms": {
+                    "description": "null enquanto o histórico não cobre a janela",
                     "type": "number",
-                    "example": 45000
+                    "x-nullable": true,
+                    "example": 45000.12345678
+                },
+                "timestamp": {
+                    "type": "integer",
+                    "example": 1620000000
+                }
+            }
+        },
+        "handlers.PairResponse": {
+            "type": "object",
+            "properties": {
+                "base": {
+                    "type": "string",
+                    "example": "BTC"
+                },
+                "created_at": {
+                    "type": "integer",
+                    "example": 1620000000
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "quote": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "symbol": {
+                    "type": "string",
+                    "example": "BRLBTC"
+                },
+                "updated_at": {
+                    "type": "integer",
+                    "example": 1620000000
+                }
+            }
+        },
+        "handlers.SignalResponse": {
+            "type": "object",
+            "properties": {
+                "fast": {
+                    "type": "string",
+                    "example": "sma50"
+                },
+                "fast_value": {
+                    "type": "number",
+                    "example": 45100.12345678
+                },
+                "slow": {
+                    "type": "string",
+                    "example": "sma200"
+                },
+                "slow_value": {
+                    "type": "number",
+                    "example": 45000.12345678
                 },
                 "timestamp": {
                     "type": "integer",
                     "example": 1620000000
+                },
+                "type": {
+                    "type": "string",
+                    "example": "golden_cross"
+                }
+            }
+        },
+        "handlers.UpdatePairRequest": {
+            "type": "object",
+            "required": [
+                "enabled"
+            ],
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": false
                 }
             }
         }
@@ -108,12 +729,12 @@ const docTemplate = `{
 
 // SwaggerInfo holds exported Swagger Info so clients can modify it
 var SwaggerInfo = &swag.Spec{
-	Version:          "",
-	Host:             "",
-	BasePath:         "",
-	Schemes:          []string{},
-	Title:            "",
-	Description:      "",
+	Version:          "1.0",
+	Host:             "localhost:8080",
+	BasePath:         "/",
+	Schemes:          []string{"http", "https"},
+	Title:            "MMS API",
+	Description:      "API para cálculo e consulta de Médias Móveis Simples de criptomoedas",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 	LeftDelim:        "{{",
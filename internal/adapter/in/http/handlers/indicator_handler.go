@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"mms_api/internal/application/service"
+	"mms_api/internal/domain/indicator/bollinger"
+	"mms_api/pkg/logger"
+)
+
+// IndicatorResponse representa a resposta da API para consulta de indicadores
+type IndicatorResponse struct {
+	Timestamp int64                   `json:"timestamp" example:"1620000000"`
+	Values    map[string]*json.Number `json:"values" swaggertype:"object,number"` // séries null enquanto o histórico não cobre a janela
+}
+
+// BollingerResponse representa a resposta da API para consulta de Bandas de Bollinger
+type BollingerResponse struct {
+	Timestamp int64       `json:"timestamp" example:"1620000000"`
+	Middle    json.Number `json:"middle" swaggertype:"number" example:"45000.0"`
+	Upper     json.Number `json:"upper" swaggertype:"number" example:"47000.0"`
+	Lower     json.Number `json:"lower" swaggertype:"number" example:"43000.0"`
+	Width     json.Number `json:"width" swaggertype:"number" example:"0.0889"`
+}
+
+// Limite superior aceito para o multiplicador k das Bandas de Bollinger
+const maxBollingerK = 10.0
+
+// indicatorHandler implementa os handlers HTTP para indicadores técnicos
+type indicatorHandler struct {
+	mmsService  service.MMSService
+	pairService service.PairService
+	logger      logger.Logger
+}
+
+// NewIndicatorHandler cria um novo handler para indicadores técnicos
+func NewIndicatorHandler(mmsService service.MMSService, pairService service.PairService, logger logger.Logger) *indicatorHandler {
+	return &indicatorHandler{
+		mmsService:  mmsService,
+		pairService: pairService,
+		logger:      logger,
+	}
+}
+
+// GetIndicatorByPair implementa o handler para a rota GET /:pair/indicators/:name
+// @Summary Obter indicador técnico
+// @Description Retorna os valores de um indicador técnico registrado (ex.: sma20, ema50, rsi14, macd12_26_9, vwap20, obv, atr14, volatility30, range14) para um par de criptomoedas em um intervalo de tempo
+// @Tags Indicadores
+// @Accept json
+// @Produce json
+// @Param pair path string true "Par de criptomoedas habilitado no registro de pares (ex.: BRLBTC ou BTC-BRL)"
+// @Param name path string true "Nome do indicador (ex.: sma20, ema50, rsi14, macd12_26_9)"
+// @Param from query int true "Timestamp Unix de início"
+// @Param to query int false "Timestamp Unix de fim (opcional, default: dia anterior)"
+// @Param resolution query string false "Resolução dos candles (1h, 4h, 1d ou 1w, entre as configuradas; default: 1d)"
+// @Success 200 {array} IndicatorResponse "Lista de valores do indicador"
+// @Failure 400 {object} map[string]string "Erro de validação"
+// @Failure 404 {object} map[string]string "Indicador desconhecido"
+// @Failure 500 {object} map[string]string "Erro interno"
+// @Router /{pair}/indicators/{name} [get]
+func (h *indicatorHandler) GetIndicatorByPair(c *gin.Context) {
+	// Extrair o par dos parâmetros da URL
+	pair, ok := parsePair(c, h.pairService, h.logger)
+	if !ok {
+		return
+	}
+
+	// Validar o indicador solicitado
+	name := c.Param("name")
+	if !h.isRegistered(name) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Indicador desconhecido. Use um de: " + strings.Join(h.mmsService.Indicators(), ", ")})
+		return
+	}
+
+	resolution, ok := parseResolution(c, h.mmsService.Resolutions())
+	if !ok {
+		return
+	}
+
+	from, to, ok := parseTimeRange(c)
+	if !ok {
+		return
+	}
+
+	// Obter dados do serviço
+	result, err := h.mmsService.GetIndicatorByPairAndRange(c.Request.Context(), pair, resolution, name, from, to)
+	if err != nil {
+		h.logger.Error("erro ao buscar indicador", "error", err, "pair", pair, "indicator", name)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar a requisição"})
+		return
+	}
+
+	// Converter para o formato de resposta
+	response := make([]IndicatorResponse, 0, len(result))
+	for _, v := range result {
+		values := make(map[string]*json.Number, len(v.Values))
+		for series, value := range v.Values {
+			values[series] = nullDecimalNumber(value)
+		}
+		response = append(response, IndicatorResponse{
+			Timestamp: v.Timestamp.Unix(),
+			Values:    values,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// isRegistered verifica se o indicador está registrado no serviço
+func (h *indicatorHandler) isRegistered(name string) bool {
+	for _, n := range h.mmsService.Indicators() {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// GetBollingerBands implementa o handler para a rota GET /:pair/bollinger
+// @Summary Obter Bandas de Bollinger
+// @Description Retorna as Bandas de Bollinger (média ± k desvios padrão dos fechamentos) e a largura relativa das bandas para um par de criptomoedas em um intervalo de tempo
+// @Tags Indicadores
+// @Accept json
+// @Produce json
+// @Param pair path string true "Par de criptomoedas habilitado no registro de pares (ex.: BRLBTC ou BTC-BRL)"
+// @Param from query int true "Timestamp Unix de início"
+// @Param to query int false "Timestamp Unix de fim (opcional, default: dia anterior)"
+// @Param resolution query string false "Resolução dos candles (1h, 4h, 1d ou 1w, entre as configuradas; default: 1d)"
+// @Param k query number false "Quantidade de desvios padrão das bandas (default: 2)"
+// @Success 200 {array} BollingerResponse "Lista de Bandas de Bollinger"
+// @Failure 400 {object} map[string]string "Erro de validação"
+// @Failure 500 {object} map[string]string "Erro interno"
+// @Router /{pair}/bollinger [get]
+func (h *indicatorHandler) GetBollingerBands(c *gin.Context) {
+	// Extrair o par dos parâmetros da URL
+	pair, ok := parsePair(c, h.pairService, h.logger)
+	if !ok {
+		return
+	}
+
+	// Validar e converter o multiplicador k
+	k := bollinger.DefaultK
+	if kStr := c.Query("k"); kStr != "" {
+		parsed, err := strconv.ParseFloat(kStr, 64)
+		if err != nil || parsed <= 0 || parsed > maxBollingerK {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro 'k' inválido. Use um número maior que 0 e até 10"})
+			return
+		}
+		k = parsed
+	}
+
+	resolution, ok := parseResolution(c, h.mmsService.Resolutions())
+	if !ok {
+		return
+	}
+
+	from, to, ok := parseTimeRange(c)
+	if !ok {
+		return
+	}
+
+	// Obter dados do serviço
+	result, err := h.mmsService.GetBollingerBands(c.Request.Context(), pair, resolution, from, to, k)
+	if err != nil {
+		h.logger.Error("erro ao buscar bandas de Bollinger", "error", err, "pair", pair)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar a requisição"})
+		return
+	}
+
+	// Converter para o formato de resposta
+	response := make([]BollingerResponse, 0, len(result))
+	for _, band := range result {
+		response = append(response, BollingerResponse{
+			Timestamp: band.Timestamp.Unix(),
+			Middle:    decimalNumber(band.Middle),
+			Upper:     decimalNumber(band.Upper),
+			Lower:     decimalNumber(band.Lower),
+			Width:     decimalNumber(band.Width),
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -2,9 +2,11 @@
 package handlers
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
-	"time"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
@@ -15,81 +17,78 @@ import (
 
 // MMSResponse representa a resposta da API para consulta de MMS
 type MMSResponse struct {
-	Timestamp int64   `json:"timestamp" example:"1620000000"`
-	MMS       float64 `json:"mms" example:"45000.0"`
+	Timestamp int64        `json:"timestamp" example:"1620000000"`
+	MMS       *json.Number `json:"mms" swaggertype:"number" extensions:"x-nullable" example:"45000.12345678"` // null enquanto o histórico não cobre a janela
 }
 
 // mmsHandler implementa os handlers HTTP para MMS
 type mmsHandler struct {
-	mmsService service.MMSService
-	logger     logger.Logger
+	mmsService  service.MMSService
+	pairService service.PairService
+	logger      logger.Logger
 }
 
 // NewMMSHandler cria um novo handler para MMS
-func NewMMSHandler(mmsService service.MMSService, logger logger.Logger) *mmsHandler {
+func NewMMSHandler(mmsService service.MMSService, pairService service.PairService, logger logger.Logger) *mmsHandler {
 	return &mmsHandler{
-		mmsService: mmsService,
-		logger:     logger,
+		mmsService:  mmsService,
+		pairService: pairService,
+		logger:      logger,
 	}
 }
 
 // GetMMSByPair implementa o handler para a rota GET /:pair/mms
-// @Summary Obter médias móveis simples
-// @Description Retorna as médias móveis simples (MMS) para um par de criptomoedas em um intervalo de tempo
+// @Summary Obter médias móveis
+// @Description Retorna as médias móveis simples (MMS) ou exponenciais (MME) para um par de criptomoedas em um intervalo de tempo
 // @Tags MMS
 // @Accept json
 // @Produce json
-// @Param pair path string true "Par de criptomoedas (BRLBTC ou BRLETH)"
+// @Param pair path string true "Par de criptomoedas habilitado no registro de pares (ex.: BRLBTC ou BTC-BRL)"
 // @Param from query int true "Timestamp Unix de início"
 // @Param to query int false "Timestamp Unix de fim (opcional, default: dia anterior)"
-// @Param range query int true "Período da média móvel (20, 50 ou 200)"
+// @Param resolution query string false "Resolução dos candles (1h, 4h, 1d ou 1w, entre as configuradas; default: 1d)"
+// @Param range query int true "Período da média móvel (uma das janelas configuradas, ex.: 20, 50 ou 200)"
+// @Param type query string false "Tipo da média móvel (sma ou ema, default: sma)"
 // @Success 200 {array} MMSResponse "Lista de médias móveis"
 // @Failure 400 {object} map[string]string "Erro de validação"
 // @Failure 500 {object} map[string]string "Erro interno"
 // @Router /{pair}/mms [get]
 func (h *mmsHandler) GetMMSByPair(c *gin.Context) {
 	// Extrair o par dos parâmetros da URL
-	pair := c.Param("pair")
-	if !model.IsValidPair(pair) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Par inválido. Use BRLBTC ou BRLETH"})
+	pair, ok := parsePair(c, h.pairService, h.logger)
+	if !ok {
 		return
 	}
 
 	// Extrair parâmetros de consulta
-	fromStr := c.Query("from")
-	toStr := c.DefaultQuery("to", "")
 	rangeStr := c.Query("range")
+	avgType := model.AverageType(c.DefaultQuery("type", string(model.AverageSimple)))
 
-	// Validar e converter timestamp de início
-	fromTs, err := strconv.ParseInt(fromStr, 10, 64)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro 'from' inválido"})
+	resolution, ok := parseResolution(c, h.mmsService.Resolutions())
+	if !ok {
 		return
 	}
-	from := time.Unix(fromTs, 0)
-
-	// Validar e converter timestamp de fim (default: dia anterior)
-	var to time.Time
-	if toStr == "" {
-		to = time.Now().AddDate(0, 0, -1)
-	} else {
-		toTs, err := strconv.ParseInt(toStr, 10, 64)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro 'to' inválido"})
-			return
-		}
-		to = time.Unix(toTs, 0)
+
+	from, to, ok := parseTimeRange(c)
+	if !ok {
+		return
 	}
 
 	// Validar e converter intervalo de dias
 	period, err := strconv.Atoi(rangeStr)
-	if err != nil || !model.IsValidPeriod(period) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro 'range' inválido. Use 20, 50 ou 200"})
+	if err != nil || !h.isConfiguredPeriod(period) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro 'range' inválido. Use " + formatPeriods(h.mmsService.Periods())})
+		return
+	}
+
+	// Validar tipo da média móvel
+	if !model.IsValidAverageType(avgType) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro 'type' inválido. Use sma ou ema"})
 		return
 	}
 
 	// Obter dados do serviço
-	result, err := h.mmsService.GetMMSByPairAndRange(c.Request.Context(), pair, from, to, period)
+	result, err := h.mmsService.GetMMSByPairAndRange(c.Request.Context(), pair, resolution, from, to, period, avgType)
 	if err != nil {
 		h.logger.Error("erro ao buscar MMS", "error", err, "pair", pair)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar a requisição"})
@@ -99,23 +98,36 @@ func (h *mmsHandler) GetMMSByPair(c *gin.Context) {
 	// Converter para o formato de resposta
 	var response []MMSResponse
 	for _, mms := range result {
-		var value float64
-
-		// Obter o valor correto com base no período
-		switch period {
-		case model.Period20:
-			value = mms.MMS20
-		case model.Period50:
-			value = mms.MMS50
-		case model.Period200:
-			value = mms.MMS200
-		}
-
 		response = append(response, MMSResponse{
 			Timestamp: mms.Timestamp.Unix(),
-			MMS:       value,
+			MMS:       nullDecimalNumber(mms.Value),
 		})
 	}
 
 	c.JSON(http.StatusOK, response)
 }
+
+// isConfiguredPeriod verifica se a janela solicitada é calculada pelo serviço
+func (h *mmsHandler) isConfiguredPeriod(period int) bool {
+	if !model.IsValidPeriod(period) {
+		return false
+	}
+	for _, p := range h.mmsService.Periods() {
+		if p == period {
+			return true
+		}
+	}
+	return false
+}
+
+// formatPeriods formata as janelas configuradas para mensagens de erro (ex.: "20, 50 ou 200")
+func formatPeriods(periods []int) string {
+	parts := make([]string, len(periods))
+	for i, p := range periods {
+		parts[i] = strconv.Itoa(p)
+	}
+	if len(parts) <= 1 {
+		return strings.Join(parts, "")
+	}
+	return fmt.Sprintf("%s ou %s", strings.Join(parts[:len(parts)-1], ", "), parts[len(parts)-1])
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"mms_api/internal/application/service"
+	"mms_api/internal/domain/model"
+	"mms_api/pkg/logger"
+)
+
+// PairResponse representa um par do registro de pares na resposta da API
+type PairResponse struct {
+	Symbol    string `json:"symbol" example:"BRLBTC"`
+	Base      string `json:"base" example:"BTC"`
+	Quote     string `json:"quote" example:"BRL"`
+	Enabled   bool   `json:"enabled" example:"true"`
+	CreatedAt int64  `json:"created_at" example:"1620000000"`
+	UpdatedAt int64  `json:"updated_at" example:"1620000000"`
+}
+
+// CreatePairRequest representa o corpo da requisição de cadastro de par
+type CreatePairRequest struct {
+	Symbol  string `json:"symbol" binding:"required" example:"SOL-USDT"`
+	Enabled *bool  `json:"enabled" example:"true"`
+}
+
+// UpdatePairRequest representa o corpo da requisição de alteração de par
+type UpdatePairRequest struct {
+	Enabled *bool `json:"enabled" binding:"required" example:"false"`
+}
+
+// pairHandler implementa os handlers HTTP de administração do registro de pares
+type pairHandler struct {
+	pairService service.PairService
+	logger      logger.Logger
+}
+
+// NewPairHandler cria um novo handler para o registro de pares
+func NewPairHandler(pairService service.PairService, logger logger.Logger) *pairHandler {
+	return &pairHandler{
+		pairService: pairService,
+		logger:      logger,
+	}
+}
+
+// ListPairs implementa o handler para a rota GET /admin/pairs
+// @Summary Listar pares
+// @Description Retorna todos os pares cadastrados no registro, habilitados ou não
+// @Tags Administração
+// @Produce json
+// @Param X-Admin-Token header string true "Token de administração, igual ao ADMIN_TOKEN configurado"
+// @Success 200 {array} PairResponse "Lista de pares"
+// @Failure 401 {object} map[string]string "Token de administração inválido"
+// @Failure 503 {object} map[string]string "Rotas de administração desativadas: ADMIN_TOKEN não configurado"
+// @Failure 500 {object} map[string]string "Erro interno"
+// @Router /admin/pairs [get]
+func (h *pairHandler) ListPairs(c *gin.Context) {
+	pairs, err := h.pairService.ListPairs(c.Request.Context())
+	if err != nil {
+		h.logger.Error("erro ao listar pares", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar a requisição"})
+		return
+	}
+
+	response := make([]PairResponse, 0, len(pairs))
+	for _, p := range pairs {
+		response = append(response, toPairResponse(p))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CreatePair implementa o handler para a rota POST /admin/pairs
+// @Summary Cadastrar par
+// @Description Cadastra um novo par no registro, informado no formato canônico (cotação e base, ex.: BRLBTC) ou com separador (base e cotação, ex.: SOL-USDT). O par fica habilitado por padrão e passa a ser processado na próxima execução do worker
+// @Tags Administração
+// @Accept json
+// @Produce json
+// @Param X-Admin-Token header string true "Token de administração, igual ao ADMIN_TOKEN configurado"
+// @Param pair body CreatePairRequest true "Par a cadastrar"
+// @Success 201 {object} PairResponse "Par cadastrado"
+// @Failure 400 {object} map[string]string "Erro de validação"
+// @Failure 401 {object} map[string]string "Token de administração inválido"
+// @Failure 503 {object} map[string]string "Rotas de administração desativadas: ADMIN_TOKEN não configurado"
+// @Failure 409 {object} map[string]string "Par já cadastrado"
+// @Failure 500 {object} map[string]string "Erro interno"
+// @Router /admin/pairs [post]
+func (h *pairHandler) CreatePair(c *gin.Context) {
+	var req CreatePairRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Corpo da requisição inválido. Informe 'symbol'"})
+		return
+	}
+
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+
+	pair, err := h.pairService.AddPair(c.Request.Context(), req.Symbol, enabled)
+	if err != nil {
+		h.writeError(c, err, req.Symbol)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toPairResponse(pair))
+}
+
+// UpdatePair implementa o handler para a rota PATCH /admin/pairs/:symbol
+// @Summary Habilitar ou desabilitar par
+// @Description Habilita ou desabilita um par cadastrado. Pares desabilitados deixam de ser processados pelo worker e consultáveis pela API
+// @Tags Administração
+// @Accept json
+// @Produce json
+// @Param X-Admin-Token header string true "Token de administração, igual ao ADMIN_TOKEN configurado"
+// @Param symbol path string true "Símbolo do par (ex.: BRLBTC ou BTC-BRL)"
+// @Param pair body UpdatePairRequest true "Novo estado do par"
+// @Success 200 {object} PairResponse "Par atualizado"
+// @Failure 400 {object} map[string]string "Erro de validação"
+// @Failure 401 {object} map[string]string "Token de administração inválido"
+// @Failure 503 {object} map[string]string "Rotas de administração desativadas: ADMIN_TOKEN não configurado"
+// @Failure 404 {object} map[string]string "Par não cadastrado"
+// @Failure 500 {object} map[string]string "Erro interno"
+// @Router /admin/pairs/{symbol} [patch]
+func (h *pairHandler) UpdatePair(c *gin.Context) {
+	var req UpdatePairRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Corpo da requisição inválido. Informe 'enabled'"})
+		return
+	}
+
+	symbol := c.Param("symbol")
+	pair, err := h.pairService.SetPairEnabled(c.Request.Context(), symbol, *req.Enabled)
+	if err != nil {
+		h.writeError(c, err, symbol)
+		return
+	}
+
+	c.JSON(http.StatusOK, toPairResponse(pair))
+}
+
+// writeError converte os erros do registro de pares no status HTTP correspondente
+func (h *pairHandler) writeError(c *gin.Context, err error, symbol string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidPairSymbol):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Símbolo inválido. Use cotação e base (ex.: BRLBTC, USDTBTC) ou base e cotação com separador (ex.: BTC-BRL), com até 10 caracteres"})
+	case errors.Is(err, service.ErrPairAlreadyExists):
+		c.JSON(http.StatusConflict, gin.H{"error": "Par já cadastrado"})
+	case errors.Is(err, service.ErrPairNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Par não cadastrado"})
+	default:
+		h.logger.Error("erro ao atualizar registro de pares", "error", err, "pair", symbol)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar a requisição"})
+	}
+}
+
+// toPairResponse converte um par do registro para o formato de resposta
+func toPairResponse(p model.RegisteredPair) PairResponse {
+	return PairResponse{
+		Symbol:    p.Symbol,
+		Base:      p.Pair.Base,
+		Quote:     p.Pair.Quote,
+		Enabled:   p.Enabled,
+		CreatedAt: p.CreatedAt.Unix(),
+		UpdatedAt: p.UpdatedAt.Unix(),
+	}
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+
+	"mms_api/internal/application/service"
+	"mms_api/internal/domain/model"
+	"mms_api/pkg/logger"
+)
+
+// parsePair lê o parâmetro 'pair' da URL (BRLBTC ou BTC-BRL), converte para o símbolo canônico
+// e verifica se ele está habilitado no registro de pares.
+// Em caso de erro a resposta já é escrita e ok retorna false.
+func parsePair(c *gin.Context, pairs service.PairService, l logger.Logger) (pair string, ok bool) {
+	pair = model.NormalizePairSymbol(c.Param("pair"))
+	ctx := c.Request.Context()
+
+	enabled, err := pairs.IsEnabled(ctx, pair)
+	if err != nil {
+		l.Error("erro ao consultar registro de pares", "error", err, "pair", pair)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar a requisição"})
+		return "", false
+	}
+	if enabled {
+		return pair, true
+	}
+
+	// Listar os pares habilitados na mensagem de erro
+	symbols, err := pairs.EnabledPairs(ctx)
+	if err != nil || len(symbols) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Par inválido"})
+		return "", false
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": "Par inválido. Use um de: " + strings.Join(symbols, ", ")})
+	return "", false
+}
+
+// parseTimeRange lê os parâmetros 'from' (obrigatório) e 'to' (default: dia anterior) da query.
+// Em caso de erro a resposta 400 já é escrita e ok retorna false.
+func parseTimeRange(c *gin.Context) (from, to time.Time, ok bool) {
+	// Validar e converter timestamp de início
+	fromTs, err := strconv.ParseInt(c.Query("from"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro 'from' inválido"})
+		return time.Time{}, time.Time{}, false
+	}
+	from = time.Unix(fromTs, 0)
+
+	// Validar e converter timestamp de fim (default: dia anterior)
+	toStr := c.DefaultQuery("to", "")
+	if toStr == "" {
+		to = time.Now().AddDate(0, 0, -1)
+	} else {
+		toTs, err := strconv.ParseInt(toStr, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro 'to' inválido"})
+			return time.Time{}, time.Time{}, false
+		}
+		to = time.Unix(toTs, 0)
+	}
+
+	return from, to, true
+}
+
+// parseResolution lê o parâmetro 'resolution' da query (default: 1d, ou a primeira resolução
+// configurada quando 1d não está entre elas) e verifica se ele é uma das resoluções configuradas.
+// Em caso de erro a resposta 400 já é escrita e ok retorna false.
+func parseResolution(c *gin.Context, configured []model.Resolution) (resolution model.Resolution, ok bool) {
+	value := strings.ToLower(c.Query("resolution"))
+	if value == "" {
+		if len(configured) == 0 || containsResolution(configured, model.DefaultResolution) {
+			return model.DefaultResolution, true
+		}
+		return configured[0], true
+	}
+
+	resolution = model.Resolution(value)
+	if !containsResolution(configured, resolution) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro 'resolution' inválido. Use " + model.FormatResolutions(configured)})
+		return "", false
+	}
+	return resolution, true
+}
+
+// containsResolution verifica se a resolução está na lista
+func containsResolution(resolutions []model.Resolution, resolution model.Resolution) bool {
+	for _, r := range resolutions {
+		if r == resolution {
+			return true
+		}
+	}
+	return false
+}
+
+// decimalNumber representa um decimal como número JSON com os mesmos dígitos do valor persistido,
+// sem passar por float64
+func decimalNumber(d decimal.Decimal) json.Number {
+	return json.Number(d.String())
+}
+
+// nullDecimalNumber representa um decimal opcional como número JSON, ou null quando ausente
+func nullDecimalNumber(d decimal.NullDecimal) *json.Number {
+	if !d.Valid {
+		return nil
+	}
+	n := decimalNumber(d.Decimal)
+	return &n
+}
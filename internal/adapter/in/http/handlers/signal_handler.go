@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"mms_api/internal/application/service"
+	"mms_api/internal/domain/model"
+	"mms_api/pkg/logger"
+)
+
+// SignalResponse representa a resposta da API para consulta de sinais de cruzamento
+type SignalResponse struct {
+	Timestamp int64       `json:"timestamp" example:"1620000000"`
+	Type      string      `json:"type" example:"golden_cross"`
+	Fast      string      `json:"fast" example:"sma50"`
+	Slow      string      `json:"slow" example:"sma200"`
+	FastValue json.Number `json:"fast_value" swaggertype:"number" example:"45100.12345678"`
+	SlowValue json.Number `json:"slow_value" swaggertype:"number" example:"45000.12345678"`
+}
+
+// signalHandler implementa os handlers HTTP para sinais de cruzamento
+type signalHandler struct {
+	mmsService  service.MMSService
+	pairService service.PairService
+	logger      logger.Logger
+}
+
+// NewSignalHandler cria um novo handler para sinais de cruzamento
+func NewSignalHandler(mmsService service.MMSService, pairService service.PairService, logger logger.Logger) *signalHandler {
+	return &signalHandler{
+		mmsService:  mmsService,
+		pairService: pairService,
+		logger:      logger,
+	}
+}
+
+// GetSignalsByPair implementa o handler para a rota GET /:pair/signals
+// @Summary Obter sinais de cruzamento
+// @Description Retorna os cruzamentos detectados entre as médias configuradas (golden_cross e death_cross) e entre o fechamento e cada média (price_cross_above e price_cross_below) para um par de criptomoedas em um intervalo de tempo
+// @Tags Sinais
+// @Accept json
+// @Produce json
+// @Param pair path string true "Par de criptomoedas habilitado no registro de pares (ex.: BRLBTC ou BTC-BRL)"
+// @Param from query int true "Timestamp Unix de início"
+// @Param to query int false "Timestamp Unix de fim (opcional, default: dia anterior)"
+// @Param resolution query string false "Resolução dos candles (1h, 4h, 1d ou 1w, entre as configuradas; default: 1d)"
+// @Param type query string false "Tipos de sinal separados por vírgula (golden_cross, death_cross, price_cross_above, price_cross_below; default: todos)"
+// @Success 200 {array} SignalResponse "Lista de sinais em ordem cronológica"
+// @Failure 400 {object} map[string]string "Erro de validação"
+// @Failure 500 {object} map[string]string "Erro interno"
+// @Router /{pair}/signals [get]
+func (h *signalHandler) GetSignalsByPair(c *gin.Context) {
+	// Extrair o par dos parâmetros da URL
+	pair, ok := parsePair(c, h.pairService, h.logger)
+	if !ok {
+		return
+	}
+
+	// Validar os tipos de sinal solicitados
+	types, err := model.ParseSignalTypes(c.Query("type"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro 'type' inválido. Use " + model.FormatSignalTypes(model.SignalTypes)})
+		return
+	}
+
+	resolution, ok := parseResolution(c, h.mmsService.Resolutions())
+	if !ok {
+		return
+	}
+
+	from, to, ok := parseTimeRange(c)
+	if !ok {
+		return
+	}
+
+	// Obter dados do serviço
+	result, err := h.mmsService.GetSignalsByPairAndRange(c.Request.Context(), pair, resolution, from, to, types)
+	if err != nil {
+		h.logger.Error("erro ao buscar sinais", "error", err, "pair", pair)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar a requisição"})
+		return
+	}
+
+	// Converter para o formato de resposta
+	response := make([]SignalResponse, 0, len(result))
+	for _, s := range result {
+		fast := "close"
+		if s.FastPeriod > 0 {
+			fast = fmt.Sprintf("%s%d", s.AverageType, s.FastPeriod)
+		}
+		response = append(response, SignalResponse{
+			Timestamp: s.Timestamp.Unix(),
+			Type:      string(s.Type),
+			Fast:      fast,
+			Slow:      fmt.Sprintf("%s%d", s.AverageType, s.SlowPeriod),
+			FastValue: decimalNumber(s.FastValue),
+			SlowValue: decimalNumber(s.SlowValue),
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -1,6 +1,9 @@
 package http
 
 import (
+	"crypto/subtle"
+	"net/http"
+
 	"mms_api/internal/application/port/in"
 
 	"github.com/gin-gonic/gin"
@@ -15,12 +18,22 @@ import (
 // @BasePath /
 // @schemes http https
 type Router struct {
-	mmsHandler in.MMSHandler
+	mmsHandler       in.MMSHandler
+	indicatorHandler in.IndicatorHandler
+	signalHandler    in.SignalHandler
+	pairHandler      in.PairHandler
+	adminToken       string
 }
 
-func NewRouter(mmsHandler in.MMSHandler) *Router {
+// NewRouter cria o roteador. As rotas de administração exigem o cabeçalho X-Admin-Token com o
+// valor de adminToken; sem token configurado elas ficam desativadas.
+func NewRouter(mmsHandler in.MMSHandler, indicatorHandler in.IndicatorHandler, signalHandler in.SignalHandler, pairHandler in.PairHandler, adminToken string) *Router {
 	return &Router{
-		mmsHandler: mmsHandler,
+		mmsHandler:       mmsHandler,
+		indicatorHandler: indicatorHandler,
+		signalHandler:    signalHandler,
+		pairHandler:      pairHandler,
+		adminToken:       adminToken,
 	}
 }
 
@@ -42,13 +55,25 @@ func (r *Router) SetupRoutes() *gin.Engine {
 	// API v1 routes group
 	v1 := router.Group("/api/v1")
 	{
-		v1.GET("/:pair/mms", r.mmsHandler.GetMMSByPair) // Get MMS by pair and timeframe
+		v1.GET("/:pair/mms", r.mmsHandler.GetMMSByPair)                          // Get MMS by pair and timeframe
+		v1.GET("/:pair/indicators/:name", r.indicatorHandler.GetIndicatorByPair) // Get any registered indicator by pair and timeframe
+		v1.GET("/:pair/bollinger", r.indicatorHandler.GetBollingerBands)         // Get Bollinger Bands with configurable k
+		v1.GET("/:pair/signals", r.signalHandler.GetSignalsByPair)               // Get crossover signals by pair, type and timeframe
+	}
+
+	// Admin routes group
+	admin := v1.Group("/admin", r.requireAdminToken())
+	{
+		admin.GET("/pairs", r.pairHandler.ListPairs)            // List registered pairs
+		admin.POST("/pairs", r.pairHandler.CreatePair)          // Register a new pair
+		admin.PATCH("/pairs/:symbol", r.pairHandler.UpdatePair) // Enable or disable a pair
 	}
 
 	return router
 }
 
-// handleHealth returns the health check handler
+// handleHealth returns the health check handler. The API only reads stored data; the state of the
+// candle providers is reported by the worker status server.
 func (r *Router) handleHealth() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.JSON(200, gin.H{
@@ -56,3 +81,21 @@ func (r *Router) handleHealth() gin.HandlerFunc {
 		})
 	}
 }
+
+// requireAdminToken rejects admin requests without the configured token. Without a configured
+// token the admin routes fail closed, since they change the pair registry.
+func (r *Router) requireAdminToken() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if r.adminToken == "" {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "Rotas de administração desativadas: ADMIN_TOKEN não configurado"})
+			return
+		}
+
+		token := c.GetHeader("X-Admin-Token")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(r.adminToken)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token de administração inválido"})
+			return
+		}
+		c.Next()
+	}
+}
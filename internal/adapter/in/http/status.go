@@ -0,0 +1,107 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"mms_api/pkg/httpclient"
+
+	"github.com/gin-gonic/gin"
+)
+
+// circuitStates são os estados exportados na métrica de estado de cada circuito, um por série
+var circuitStates = []httpclient.CircuitState{httpclient.CircuitClosed, httpclient.CircuitOpen, httpclient.CircuitHalfOpen}
+
+// StatusRouter expõe o estado do worker, o processo que consulta os provedores de candles: o health
+// check com os circuit breakers de cada provedor e as mesmas informações no formato de texto do Prometheus
+type StatusRouter struct {
+	breakers []*httpclient.CircuitBreaker
+}
+
+// NewStatusRouter cria o roteador de status com os circuit breakers dos provedores de candles
+func NewStatusRouter(breakers ...*httpclient.CircuitBreaker) *StatusRouter {
+	return &StatusRouter{breakers: breakers}
+}
+
+// SetupRoutes configures the worker status routes
+func (r *StatusRouter) SetupRoutes() *gin.Engine {
+	router := gin.New()
+	router.Use(gin.Recovery())
+
+	router.GET("/health", r.handleHealth())
+	router.GET("/metrics", r.handleMetrics())
+
+	return router
+}
+
+// stats retorna o retrato de cada circuito e se algum deles não está fechado
+func (r *StatusRouter) stats() ([]httpclient.CircuitStats, bool) {
+	degraded := false
+	circuits := make([]httpclient.CircuitStats, 0, len(r.breakers))
+	for _, b := range r.breakers {
+		stats := b.Stats()
+		if stats.State != httpclient.CircuitClosed {
+			degraded = true
+		}
+		circuits = append(circuits, stats)
+	}
+	return circuits, degraded
+}
+
+// handleHealth returns the health check handler. The status is "degraded" while any provider
+// circuit is not closed; the worker keeps running and retries on the next execution.
+func (r *StatusRouter) handleHealth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		circuits, degraded := r.stats()
+		status := "healthy"
+		if degraded {
+			status = "degraded"
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status":   status,
+			"circuits": circuits,
+		})
+	}
+}
+
+// handleMetrics returns the circuit breaker metrics in the Prometheus text exposition format
+func (r *StatusRouter) handleMetrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		circuits, _ := r.stats()
+
+		var b strings.Builder
+		b.WriteString("# HELP mms_circuit_breaker_state Estado do circuit breaker do provedor (1 no estado atual)\n")
+		b.WriteString("# TYPE mms_circuit_breaker_state gauge\n")
+		for _, stats := range circuits {
+			for _, state := range circuitStates {
+				value := 0
+				if stats.State == state {
+					value = 1
+				}
+				fmt.Fprintf(&b, "mms_circuit_breaker_state{provider=%q,state=%q} %d\n", stats.Name, state, value)
+			}
+		}
+
+		metrics := []struct {
+			name, help, kind string
+			value            func(httpclient.CircuitStats) int
+		}{
+			{"mms_circuit_breaker_consecutive_failures", "Falhas consecutivas do provedor", "gauge",
+				func(s httpclient.CircuitStats) int { return s.ConsecutiveFailures }},
+			{"mms_circuit_breaker_opens_total", "Vezes em que o circuito do provedor abriu", "counter",
+				func(s httpclient.CircuitStats) int { return s.Opens }},
+			{"mms_circuit_breaker_rejected_total", "Consultas recusadas com o circuito do provedor aberto", "counter",
+				func(s httpclient.CircuitStats) int { return s.Rejected }},
+		}
+		for _, m := range metrics {
+			fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", m.name, m.help, m.name, m.kind)
+			for _, stats := range circuits {
+				fmt.Fprintf(&b, "%s{provider=%q} %d\n", m.name, stats.Name, m.value(stats))
+			}
+		}
+
+		c.Data(http.StatusOK, "text/plain; version=0.0.4; charset=utf-8", []byte(b.String()))
+	}
+}
@@ -0,0 +1,255 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"mms_api/internal/domain/model"
+	"mms_api/pkg/httpclient"
+	"mms_api/pkg/logger"
+)
+
+// intervalCodes mapeia as resoluções para os intervalos de kline aceitos pela API. Os candles
+// semanais da Binance também começam na segunda-feira, como model.Resolution1w.
+var intervalCodes = map[model.Resolution]string{
+	model.Resolution1h: "1h",
+	model.Resolution4h: "4h",
+	model.Resolution1d: "1d",
+	model.Resolution1w: "1w",
+}
+
+// Limite padrão de candles por requisição (o máximo aceito pelo endpoint de klines)
+const DefaultMaxCandlesPerRequest = 1000
+
+// Symbols é o formato de símbolo da Binance: base e cotação sem separador (ex.: BTCBRL)
+var Symbols = model.SymbolMap{BaseFirst: true}
+
+// Índices das colunas de cada kline na resposta:
+// [abertura (ms), open, high, low, close, volume, fechamento (ms), volume na cotação, ...]
+const (
+	columnOpenTime = iota
+	columnOpen
+	columnHigh
+	columnLow
+	columnClose
+	columnVolume
+	minColumns
+)
+
+// CandleAPI encapsula a comunicação com o endpoint de klines no formato da Binance
+type CandleAPI struct {
+	baseURL           string
+	httpClient        *http.Client
+	logger            logger.Logger
+	symbols           model.SymbolMap
+	maxCandlesPerCall int                    // Máximo de candles pedidos em uma requisição
+	retry             httpclient.RetryPolicy // Novas tentativas de cada requisição
+	breaker           *httpclient.CircuitBreaker
+}
+
+// Option configura parâmetros opcionais do cliente da API
+type Option func(*CandleAPI)
+
+// WithSymbolOverrides define símbolos específicos da Binance por par (ex.: BRLMATIC -> POLBRL),
+// para ativos cujo código na corretora difere do código usado no registro de pares
+func WithSymbolOverrides(overrides map[string]string) Option {
+	return func(api *CandleAPI) {
+		symbols, err := api.symbols.WithOverrides(overrides)
+		if err != nil {
+			api.logger.Error("símbolos específicos ignorados", "error", err)
+			return
+		}
+		api.symbols = symbols
+	}
+}
+
+// WithMaxCandlesPerRequest define quantos candles são pedidos por requisição (parâmetro limit); intervalos
+// maiores são divididos em janelas desse tamanho. Valores não positivos são ignorados.
+func WithMaxCandlesPerRequest(max int) Option {
+	return func(api *CandleAPI) {
+		if max > 0 {
+			api.maxCandlesPerCall = max
+		}
+	}
+}
+
+// WithRetryPolicy define as novas tentativas das requisições que falham com erro de rede, 429 ou 5xx
+func WithRetryPolicy(policy httpclient.RetryPolicy) Option {
+	return func(api *CandleAPI) {
+		api.retry = policy
+	}
+}
+
+// WithCircuitBreaker protege o provedor com um circuit breaker: com o circuito aberto, GetCandles
+// falha com httpclient.ErrCircuitOpen sem enviar requisições
+func WithCircuitBreaker(breaker *httpclient.CircuitBreaker) Option {
+	return func(api *CandleAPI) {
+		api.breaker = breaker
+	}
+}
+
+// NewCandleAPI cria uma nova instância do cliente da API
+func NewCandleAPI(baseURL string, httpClient *http.Client, logger logger.Logger, opts ...Option) *CandleAPI {
+	if httpClient == nil {
+		httpClient = httpclient.Shared()
+	}
+
+	api := &CandleAPI{
+		baseURL:           baseURL,
+		httpClient:        httpClient,
+		logger:            logger,
+		symbols:           Symbols,
+		maxCandlesPerCall: DefaultMaxCandlesPerRequest,
+		retry:             httpclient.DefaultRetryPolicy,
+	}
+
+	for _, opt := range opts {
+		opt(api)
+	}
+
+	return api
+}
+
+// GetCandles obtém os candles de uma resolução para um par em um intervalo de tempo
+func (api *CandleAPI) GetCandles(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) ([]model.Candle, error) {
+	interval, ok := intervalCodes[resolution]
+	if !ok {
+		err := fmt.Errorf("resolução não suportada pela Binance: %s", resolution)
+		api.logger.Error("Resolução inválida", err)
+		return nil, err
+	}
+
+	// Converter o par canônico (ex.: BRLBTC) para o símbolo da corretora (ex.: BTCBRL)
+	p, err := model.ParsePair(pair)
+	if err != nil {
+		api.logger.Error("Par inválido", err, "pair", pair)
+		return nil, err
+	}
+
+	symbol := api.symbols.Symbol(p)
+
+	// Intervalos longos são divididos em janelas do tamanho aceito pelo provedor. As janelas não se
+	// sobrepõem, pois startTime e endTime filtram o horário de abertura de cada kline.
+	windows := resolution.SplitRange(from, to, api.maxCandlesPerCall)
+
+	var candles []model.Candle
+	err = api.breaker.Execute(ctx, func(ctx context.Context) error {
+		for _, w := range windows {
+			window, err := api.fetchWindow(ctx, pair, symbol, resolution, interval, w.From, w.To)
+			if err != nil {
+				return err
+			}
+			candles = append(candles, window...)
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, httpclient.ErrCircuitOpen) {
+			api.logger.Error("Requisição à Binance recusada", err, "pair", pair)
+		}
+		return nil, err
+	}
+
+	api.logger.Info("Quantidade de candles retornados pela API da Binance", "count", len(candles), "requests", len(windows))
+
+	return candles, nil
+}
+
+// fetchWindow busca os klines de uma única janela, que não excede o limite por requisição do provedor
+func (api *CandleAPI) fetchWindow(ctx context.Context, pair, symbol string, resolution model.Resolution, interval string, from, to time.Time) ([]model.Candle, error) {
+	query := url.Values{}
+	query.Set("symbol", symbol)
+	query.Set("interval", interval)
+	query.Set("startTime", strconv.FormatInt(from.UnixMilli(), 10))
+	query.Set("endTime", strconv.FormatInt(to.UnixMilli(), 10))
+	query.Set("limit", strconv.Itoa(api.maxCandlesPerCall))
+	requestURL := fmt.Sprintf("%s/api/v3/klines?%s", api.baseURL, query.Encode())
+
+	api.logger.Info("Chamando URL da API da Binance", "url", requestURL)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
+	if err != nil {
+		api.logger.Error("Erro ao criar request", err)
+		return nil, err
+	}
+
+	resp, err := api.retry.Do(api.httpClient, req, api.logger)
+	if err != nil {
+		api.logger.Error("Erro ao fazer request", err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var klines [][]json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&klines); err != nil {
+		api.logger.Error("Erro ao decodificar resposta", err)
+		return nil, err
+	}
+
+	candles, err := parseKlines(klines, pair, resolution)
+	if err != nil {
+		api.logger.Error("Resposta inválida da API", err)
+		return nil, err
+	}
+
+	return candles, nil
+}
+
+// parseKlines converte os klines da resposta em candles. O horário de abertura é um inteiro em
+// milissegundos e os preços e volumes são decimais em texto, para não perder precisão.
+func parseKlines(klines [][]json.RawMessage, pair string, resolution model.Resolution) ([]model.Candle, error) {
+	candles := make([]model.Candle, 0, len(klines))
+	for i, kline := range klines {
+		if len(kline) < minColumns {
+			return nil, fmt.Errorf("kline %d com %d colunas, esperado ao menos %d", i, len(kline), minColumns)
+		}
+
+		var openTime int64
+		if err := json.Unmarshal(kline[columnOpenTime], &openTime); err != nil {
+			return nil, fmt.Errorf("horário de abertura inválido no kline %d: %w", i, err)
+		}
+
+		var values [5]decimal.Decimal
+		for j, column := range []int{columnOpen, columnHigh, columnLow, columnClose, columnVolume} {
+			value, err := parseDecimal(kline[column])
+			if err != nil {
+				return nil, fmt.Errorf("valor inválido na coluna %d do kline %d: %w", column, openTime, err)
+			}
+			values[j] = value
+		}
+
+		candles = append(candles, model.Candle{
+			Pair:       pair,
+			Resolution: resolution,
+			Timestamp:  time.UnixMilli(openTime).UTC(),
+			Open:       values[0],
+			High:       values[1],
+			Low:        values[2],
+			Close:      values[3],
+			Volume:     values[4],
+		})
+	}
+	return candles, nil
+}
+
+// parseDecimal converte um valor textual da API (ex.: "512345.67000000") em decimal exato, arredondado
+// às casas persistidas
+func parseDecimal(raw json.RawMessage) (decimal.Decimal, error) {
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return decimal.Zero, fmt.Errorf("esperado decimal em texto, recebido %s", raw)
+	}
+	d, err := decimal.NewFromString(value)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return d.Round(model.PriceScale), nil
+}
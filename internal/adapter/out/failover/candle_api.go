@@ -0,0 +1,112 @@
+package failover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"mms_api/internal/application/port/out"
+	"mms_api/internal/domain/model"
+	"mms_api/pkg/logger"
+)
+
+// CandleAPI consulta uma lista ordenada de provedores de candles: o primeiro é o principal e os
+// demais só são consultados quando os anteriores falham ou respondem com candles faltando no
+// intervalo. Cada candle retornado registra em Source o provedor que serviu o intervalo.
+type CandleAPI struct {
+	providers []out.CandleProvider
+	logger    logger.Logger
+}
+
+// NewCandleAPI cria a cadeia de provedores, na ordem de preferência
+func NewCandleAPI(providers []out.CandleProvider, logger logger.Logger) *CandleAPI {
+	return &CandleAPI{
+		providers: providers,
+		logger:    logger,
+	}
+}
+
+// Providers retorna os provedores da cadeia, na ordem de preferência
+func (api *CandleAPI) Providers() []out.CandleProvider {
+	return api.providers
+}
+
+// GetCandles retorna os candles do primeiro provedor com a resposta completa. Quando nenhum responde
+// com todos os candles esperados (ex.: intervalo anterior à listagem do par), prevalece a resposta
+// com mais candles, com preferência pela ordem da cadeia. Só falha quando todos os provedores falham.
+func (api *CandleAPI) GetCandles(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) ([]model.Candle, error) {
+	expected := expectedCandles(resolution, from, to)
+
+	var (
+		best     []model.Candle
+		bestName string
+		bestSize = -1
+		errs     []error
+	)
+	for i, p := range api.providers {
+		if i > 0 {
+			api.logger.Info("Consultando provedor de candles alternativo", "provider", p.Name, "pair", pair, "resolution", resolution)
+		}
+
+		candles, err := p.API.GetCandles(ctx, pair, resolution, from, to)
+		if err != nil {
+			// O cancelamento pelo chamador interrompe a cadeia
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			api.logger.Error("Falha no provedor de candles", err, "provider", p.Name, "pair", pair, "resolution", resolution)
+			errs = append(errs, fmt.Errorf("%s: %w", p.Name, err))
+			continue
+		}
+
+		received := countInRange(candles, from, to)
+		if received > bestSize {
+			best, bestName, bestSize = candles, p.Name, received
+		}
+		if received >= expected {
+			break
+		}
+		api.logger.Info("Resposta incompleta do provedor de candles", "provider", p.Name, "pair", pair, "resolution", resolution, "received", received, "expected", expected)
+	}
+
+	if bestSize < 0 {
+		return nil, fmt.Errorf("todos os provedores de candles falharam: %w", errors.Join(errs...))
+	}
+
+	for i := range best {
+		best[i].Source = bestName
+	}
+
+	api.logger.Info("Candles servidos pelo provedor", "provider", bestName, "pair", pair, "resolution", resolution,
+		"from", from.UTC().Format(time.RFC3339), "to", to.UTC().Format(time.RFC3339), "count", len(best), "expected", expected,
+		"fallback", bestName != api.providers[0].Name)
+
+	return best, nil
+}
+
+// expectedCandles conta os candles da resolução iniciados entre from e to
+func expectedCandles(resolution model.Resolution, from, to time.Time) int {
+	start := resolution.Truncate(from)
+	if start.Before(from) {
+		start = resolution.Next(start)
+	}
+
+	count := 0
+	for t := start; !t.After(to); t = resolution.Next(t) {
+		count++
+	}
+	return count
+}
+
+// countInRange conta os timestamps distintos entre from e to, ignorando repetições e candles fora do
+// intervalo que não ajudam a completar a resposta
+func countInRange(candles []model.Candle, from, to time.Time) int {
+	seen := make(map[int64]bool, len(candles))
+	for _, c := range candles {
+		if !c.Timestamp.Before(from) && !c.Timestamp.After(to) {
+			seen[c.Timestamp.Unix()] = true
+		}
+	}
+	return len(seen)
+}
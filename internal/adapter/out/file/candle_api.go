@@ -0,0 +1,440 @@
+package file
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	// Base de fusos horários embutida, para CANDLE_FILE_TIMEZONE funcionar em imagens sem tzdata
+	_ "time/tzdata"
+
+	"github.com/shopspring/decimal"
+
+	"mms_api/internal/domain/model"
+	"mms_api/pkg/logger"
+)
+
+// Format é o formato dos arquivos de candles
+type Format string
+
+// Formatos de arquivo suportados
+const (
+	FormatCSV   Format = "csv"   // CSV com cabeçalho
+	FormatJSONL Format = "jsonl" // JSON Lines: um objeto por linha
+)
+
+// ParseFormat valida o formato dos arquivos de candles
+func ParseFormat(value string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(value))); f {
+	case FormatCSV, FormatJSONL:
+		return f, nil
+	default:
+		return "", fmt.Errorf("formato de arquivo de candles inválido: %q (use csv ou jsonl)", value)
+	}
+}
+
+// Layouts especiais de timestamp, além dos layouts do pacote time
+const (
+	TimeLayoutUnix   = "unix"    // Segundos desde a época Unix
+	TimeLayoutUnixMs = "unix_ms" // Milissegundos desde a época Unix
+)
+
+// autoLayouts são os layouts tentados quando nenhum é configurado e o timestamp não é numérico
+var autoLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02"}
+
+// unixMillisThreshold separa timestamps numéricos em segundos dos em milissegundos na detecção
+// automática: 1e11 segundos fica no ano 5138, e 1e11 milissegundos em 1973
+const unixMillisThreshold = 1e11
+
+// Columns mapeia cada campo do candle para o nome da coluna no CSV ou da chave no JSON Lines
+type Columns struct {
+	Timestamp string
+	Open      string
+	High      string
+	Low       string
+	Close     string
+	Volume    string
+}
+
+// DefaultColumns usa o nome de cada campo como nome da coluna
+var DefaultColumns = Columns{
+	Timestamp: "timestamp",
+	Open:      "open",
+	High:      "high",
+	Low:       "low",
+	Close:     "close",
+	Volume:    "volume",
+}
+
+// ParseColumns aplica sobre DefaultColumns o mapeamento campo=coluna informado
+// (ex.: {"timestamp": "time", "volume": "vol"})
+func ParseColumns(mapping map[string]string) (Columns, error) {
+	columns := DefaultColumns
+	for field, column := range mapping {
+		column = strings.TrimSpace(column)
+		if column == "" {
+			return Columns{}, fmt.Errorf("coluna vazia para o campo %q", field)
+		}
+		target := columns.field(strings.ToLower(strings.TrimSpace(field)))
+		if target == nil {
+			return Columns{}, fmt.Errorf("campo de candle inválido no mapeamento de colunas: %q (use timestamp, open, high, low, close ou volume)", field)
+		}
+		*target = column
+	}
+	return columns, nil
+}
+
+// field retorna o endereço da coluna de um campo do candle, ou nil para campos desconhecidos
+func (c *Columns) field(name string) *string {
+	switch name {
+	case "timestamp":
+		return &c.Timestamp
+	case "open":
+		return &c.Open
+	case "high":
+		return &c.High
+	case "low":
+		return &c.Low
+	case "close":
+		return &c.Close
+	case "volume":
+		return &c.Volume
+	default:
+		return nil
+	}
+}
+
+// names retorna as colunas na ordem timestamp, open, high, low, close, volume
+func (c Columns) names() []string {
+	return []string{c.Timestamp, c.Open, c.High, c.Low, c.Close, c.Volume}
+}
+
+// CandleAPI lê candles de arquivos locais, um por par e resolução, no lugar de um provedor de rede.
+// O arquivo de BRLBTC na resolução 1d se chama BRLBTC_1d.csv (ou .jsonl) e fica no diretório configurado.
+// Os arquivos são lidos a cada consulta e os candles retornados na ordem do arquivo, para que a
+// validação de qualidade trate repetições e desordem como faria com um provedor de rede.
+type CandleAPI struct {
+	dir        string
+	logger     logger.Logger
+	format     Format
+	columns    Columns
+	location   *time.Location // Fuso dos timestamps sem fuso explícito
+	timeLayout string         // Layout dos timestamps; vazio detecta o formato de cada valor
+}
+
+// Option configura parâmetros opcionais da leitura de arquivos
+type Option func(*CandleAPI)
+
+// WithFormat define o formato dos arquivos (padrão CSV)
+func WithFormat(format Format) Option {
+	return func(api *CandleAPI) {
+		api.format = format
+	}
+}
+
+// WithColumns define os nomes das colunas de cada campo do candle
+func WithColumns(columns Columns) Option {
+	return func(api *CandleAPI) {
+		api.columns = columns
+	}
+}
+
+// WithLocation define o fuso dos timestamps sem fuso explícito (padrão UTC). Os candles retornados
+// sempre têm o timestamp em UTC.
+func WithLocation(location *time.Location) Option {
+	return func(api *CandleAPI) {
+		if location != nil {
+			api.location = location
+		}
+	}
+}
+
+// WithTimeLayout define o layout dos timestamps: unix, unix_ms ou um layout do pacote time
+// (ex.: 2006-01-02 15:04). Vazio detecta segundos, milissegundos, RFC 3339 e datas simples.
+func WithTimeLayout(layout string) Option {
+	return func(api *CandleAPI) {
+		api.timeLayout = layout
+	}
+}
+
+// NewCandleAPI cria uma nova instância da leitura de candles dos arquivos em dir
+func NewCandleAPI(dir string, logger logger.Logger, opts ...Option) *CandleAPI {
+	api := &CandleAPI{
+		dir:      dir,
+		logger:   logger,
+		format:   FormatCSV,
+		columns:  DefaultColumns,
+		location: time.UTC,
+	}
+
+	for _, opt := range opts {
+		opt(api)
+	}
+
+	return api
+}
+
+// Path retorna o caminho do arquivo com os candles do par na resolução
+func (api *CandleAPI) Path(pair string, resolution model.Resolution) (string, error) {
+	p, err := model.ParsePair(pair)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(api.dir, fmt.Sprintf("%s_%s.%s", p, resolution, api.format)), nil
+}
+
+// GetCandles lê do arquivo do par e da resolução os candles iniciados entre from e to. As linhas fora
+// do intervalo são ignoradas sem ler os valores, como os candles que um provedor de rede não enviaria.
+func (api *CandleAPI) GetCandles(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) ([]model.Candle, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	path, err := api.Path(pair, resolution)
+	if err != nil {
+		api.logger.Error("Par inválido", err, "pair", pair)
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			err = fmt.Errorf("arquivo de candles não encontrado para %s (%s): %w", pair, resolution, err)
+		}
+		api.logger.Error("Erro ao abrir arquivo de candles", err, "path", path)
+		return nil, err
+	}
+	defer f.Close()
+
+	var rows rowReader
+	switch api.format {
+	case FormatJSONL:
+		rows = newJSONLReader(f, api.columns)
+	default:
+		rows, err = newCSVReader(f, api.columns)
+		if err != nil {
+			err = fmt.Errorf("%s: %w", path, err)
+			api.logger.Error("Arquivo de candles inválido", err)
+			return nil, err
+		}
+	}
+
+	candles, err := api.readCandles(rows, pair, resolution, from, to)
+	if err != nil {
+		err = fmt.Errorf("%s: %w", path, err)
+		api.logger.Error("Arquivo de candles inválido", err)
+		return nil, err
+	}
+
+	api.logger.Info("Quantidade de candles lidos do arquivo", "path", path, "count", len(candles))
+
+	return candles, nil
+}
+
+// readCandles lê as linhas até o fim do arquivo, mantendo os candles do intervalo
+func (api *CandleAPI) readCandles(rows rowReader, pair string, resolution model.Resolution, from, to time.Time) ([]model.Candle, error) {
+	var candles []model.Candle
+	for {
+		values, line, err := rows.next()
+		if err == io.EOF {
+			return candles, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("linha %d: %w", line, err)
+		}
+
+		c, ok, err := api.parseRow(values, pair, resolution, from, to)
+		if err != nil {
+			return nil, fmt.Errorf("linha %d: %w", line, err)
+		}
+		if ok {
+			candles = append(candles, c)
+		}
+	}
+}
+
+// parseRow converte os valores de uma linha, na ordem de Columns.names, em candle; ok é falso quando
+// o candle começa fora do intervalo
+func (api *CandleAPI) parseRow(values []string, pair string, resolution model.Resolution, from, to time.Time) (model.Candle, bool, error) {
+	timestamp, err := api.parseTime(values[0])
+	if err != nil {
+		return model.Candle{}, false, fmt.Errorf("timestamp inválido %q: %w", values[0], err)
+	}
+	if timestamp.Before(from) || timestamp.After(to) {
+		return model.Candle{}, false, nil
+	}
+
+	var prices [5]decimal.Decimal
+	for i, raw := range values[1:] {
+		d, err := decimal.NewFromString(strings.TrimSpace(raw))
+		if err != nil {
+			return model.Candle{}, false, fmt.Errorf("valor inválido na coluna %s: %q", api.columns.names()[i+1], raw)
+		}
+		prices[i] = d.Round(model.PriceScale)
+	}
+
+	return model.Candle{
+		Pair:       pair,
+		Resolution: resolution,
+		Timestamp:  timestamp,
+		Open:       prices[0],
+		High:       prices[1],
+		Low:        prices[2],
+		Close:      prices[3],
+		Volume:     prices[4],
+	}, true, nil
+}
+
+// parseTime interpreta um timestamp conforme o layout configurado, retornando-o em UTC
+func (api *CandleAPI) parseTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	switch api.timeLayout {
+	case TimeLayoutUnix, TimeLayoutUnixMs:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if api.timeLayout == TimeLayoutUnixMs {
+			return time.UnixMilli(n).UTC(), nil
+		}
+		return time.Unix(n, 0).UTC(), nil
+	case "":
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			if n >= unixMillisThreshold {
+				return time.UnixMilli(n).UTC(), nil
+			}
+			return time.Unix(n, 0).UTC(), nil
+		}
+		for _, layout := range autoLayouts {
+			if t, err := time.ParseInLocation(layout, value, api.location); err == nil {
+				return t.UTC(), nil
+			}
+		}
+		return time.Time{}, errors.New("formato não reconhecido")
+	default:
+		t, err := time.ParseInLocation(api.timeLayout, value, api.location)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return t.UTC(), nil
+	}
+}
+
+// rowReader percorre as linhas de um arquivo, retornando os valores na ordem de Columns.names e o
+// número da linha; io.EOF encerra a leitura
+type rowReader interface {
+	next() ([]string, int, error)
+}
+
+// csvReader lê arquivos CSV, localizando as colunas pelo cabeçalho
+type csvReader struct {
+	reader  *csv.Reader
+	indexes []int
+}
+
+func newCSVReader(r io.Reader, columns Columns) (*csvReader, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("arquivo sem cabeçalho")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := make([]int, 0, len(header))
+	for _, name := range columns.names() {
+		index := -1
+		for i, h := range header {
+			if strings.EqualFold(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")), name) {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return nil, fmt.Errorf("coluna %q ausente no cabeçalho", name)
+		}
+		indexes = append(indexes, index)
+	}
+
+	return &csvReader{reader: reader, indexes: indexes}, nil
+}
+
+func (r *csvReader) next() ([]string, int, error) {
+	record, err := r.reader.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, parseErr.Line, parseErr.Err
+		}
+		return nil, 0, err
+	}
+	line, _ := r.reader.FieldPos(0)
+
+	values := make([]string, len(r.indexes))
+	for i, index := range r.indexes {
+		values[i] = record[index]
+	}
+	return values, line, nil
+}
+
+// jsonlReader lê arquivos JSON Lines, ignorando as linhas em branco. Os valores podem ser números ou
+// textos; os números são lidos sem conversão para ponto flutuante.
+type jsonlReader struct {
+	scanner *bufio.Scanner
+	columns []string
+	line    int
+}
+
+func newJSONLReader(r io.Reader, columns Columns) *jsonlReader {
+	return &jsonlReader{scanner: bufio.NewScanner(r), columns: columns.names()}
+}
+
+func (r *jsonlReader) next() ([]string, int, error) {
+	for r.scanner.Scan() {
+		r.line++
+		text := strings.TrimSpace(r.scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		decoder := json.NewDecoder(strings.NewReader(text))
+		decoder.UseNumber()
+		var object map[string]interface{}
+		if err := decoder.Decode(&object); err != nil {
+			return nil, r.line, err
+		}
+
+		values := make([]string, len(r.columns))
+		for i, name := range r.columns {
+			switch v := object[name].(type) {
+			case json.Number:
+				values[i] = v.String()
+			case string:
+				values[i] = v
+			case nil:
+				return nil, r.line, fmt.Errorf("chave %q ausente", name)
+			default:
+				return nil, r.line, fmt.Errorf("chave %q com valor não numérico: %v", name, v)
+			}
+		}
+		return values, r.line, nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return nil, r.line, err
+	}
+	return nil, r.line, io.EOF
+}
@@ -3,12 +3,16 @@ package mercadobitcoin
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
-	"strconv"
+	"sync"
 	"time"
 
+	"github.com/shopspring/decimal"
+
 	"mms_api/internal/domain/model"
+	"mms_api/pkg/httpclient"
 	"mms_api/pkg/logger"
 )
 
@@ -27,42 +31,226 @@ type apiResponse struct {
 //	Candles []apiCandle `json:"candles"`
 //}
 
+// resolutionCodes mapeia as resoluções para os códigos aceitos pela API. Resoluções sem código
+// próprio são montadas a partir de candles menores (4h a partir de 1h).
+var resolutionCodes = map[model.Resolution]string{
+	model.Resolution1h: "1h",
+	model.Resolution1d: "1d",
+	model.Resolution1w: "1w",
+}
+
+// aggregatedResolutions indica a resolução buscada na API para montar as resoluções sem código próprio
+var aggregatedResolutions = map[model.Resolution]model.Resolution{
+	model.Resolution4h: model.Resolution1h,
+}
+
+// Limites padrão da paginação: candles por requisição e requisições simultâneas
+const (
+	DefaultMaxCandlesPerRequest = 500
+	DefaultConcurrency          = 4
+)
+
+// Symbols é o formato de símbolo do Mercado Bitcoin: base e cotação separadas por hífen (ex.: BTC-BRL)
+var Symbols = model.SymbolMap{Separator: "-", BaseFirst: true}
+
 // CandleAPI encapsula a comunicação com a API do Mercado Bitcoin
 type CandleAPI struct {
-	baseURL    string
-	httpClient *http.Client
-	logger     logger.Logger
+	baseURL           string
+	httpClient        *http.Client
+	logger            logger.Logger
+	symbols           model.SymbolMap
+	maxCandlesPerCall int                    // Máximo de candles pedidos em uma requisição
+	concurrency       int                    // Máximo de requisições simultâneas de um mesmo intervalo
+	retry             httpclient.RetryPolicy // Novas tentativas de cada requisição
+	breaker           *httpclient.CircuitBreaker
+}
+
+// Option configura parâmetros opcionais do cliente da API
+type Option func(*CandleAPI)
+
+// WithSymbolOverrides define símbolos específicos do Mercado Bitcoin por par (ex.: BRLMATIC -> POL-BRL),
+// para ativos cujo código na corretora difere do código usado no registro de pares
+func WithSymbolOverrides(overrides map[string]string) Option {
+	return func(api *CandleAPI) {
+		symbols, err := api.symbols.WithOverrides(overrides)
+		if err != nil {
+			api.logger.Error("símbolos específicos ignorados", "error", err)
+			return
+		}
+		api.symbols = symbols
+	}
+}
+
+// WithMaxCandlesPerRequest define quantos candles são pedidos por requisição; intervalos maiores são
+// divididos em janelas desse tamanho. Valores não positivos são ignorados.
+func WithMaxCandlesPerRequest(max int) Option {
+	return func(api *CandleAPI) {
+		if max > 0 {
+			api.maxCandlesPerCall = max
+		}
+	}
+}
+
+// WithConcurrency define quantas janelas de um intervalo são buscadas ao mesmo tempo.
+// Valores não positivos são ignorados.
+func WithConcurrency(n int) Option {
+	return func(api *CandleAPI) {
+		if n > 0 {
+			api.concurrency = n
+		}
+	}
+}
+
+// WithRetryPolicy define as novas tentativas das requisições que falham com erro de rede, 429 ou 5xx
+func WithRetryPolicy(policy httpclient.RetryPolicy) Option {
+	return func(api *CandleAPI) {
+		api.retry = policy
+	}
+}
+
+// WithCircuitBreaker protege o provedor com um circuit breaker: com o circuito aberto, GetCandles
+// falha com httpclient.ErrCircuitOpen sem enviar requisições
+func WithCircuitBreaker(breaker *httpclient.CircuitBreaker) Option {
+	return func(api *CandleAPI) {
+		api.breaker = breaker
+	}
 }
 
 // NewCandleAPI cria uma nova instância do cliente da API
-func NewCandleAPI(baseURL string, httpClient *http.Client, logger logger.Logger) *CandleAPI {
+func NewCandleAPI(baseURL string, httpClient *http.Client, logger logger.Logger, opts ...Option) *CandleAPI {
 	if httpClient == nil {
-		httpClient = &http.Client{
-			Timeout: 30 * time.Second,
+		httpClient = httpclient.Shared()
+	}
+
+	api := &CandleAPI{
+		baseURL:           baseURL,
+		httpClient:        httpClient,
+		logger:            logger,
+		symbols:           Symbols,
+		maxCandlesPerCall: DefaultMaxCandlesPerRequest,
+		concurrency:       DefaultConcurrency,
+		retry:             httpclient.DefaultRetryPolicy,
+	}
+
+	for _, opt := range opts {
+		opt(api)
+	}
+
+	return api
+}
+
+// GetCandles obtém os candles de uma resolução para um par em um intervalo de tempo
+func (api *CandleAPI) GetCandles(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) ([]model.Candle, error) {
+	// Resoluções sem código na API são agregadas a partir de uma resolução menor
+	if source, ok := aggregatedResolutions[resolution]; ok {
+		from = resolution.Truncate(from)
+		to = resolution.Truncate(to).Add(resolution.Duration() - source.Duration())
+
+		candles, err := api.GetCandles(ctx, pair, source, from, to)
+		if err != nil {
+			return nil, err
 		}
+		return model.AggregateCandles(candles, resolution), nil
+	}
+
+	code, ok := resolutionCodes[resolution]
+	if !ok {
+		err := fmt.Errorf("resolução não suportada pelo Mercado Bitcoin: %s", resolution)
+		api.logger.Error("Resolução inválida", err)
+		return nil, err
+	}
+
+	// Converter o par canônico (ex.: BRLBTC) para o símbolo da corretora (ex.: BTC-BRL)
+	p, err := model.ParsePair(pair)
+	if err != nil {
+		api.logger.Error("Par inválido", err, "pair", pair)
+		return nil, err
 	}
 
-	return &CandleAPI{
-		baseURL:    baseURL,
-		httpClient: httpClient,
-		logger:     logger,
+	symbol := api.symbols.Symbol(p)
+
+	// Intervalos longos são divididos em janelas do tamanho aceito pelo provedor
+	windows := resolution.SplitRange(from, to, api.maxCandlesPerCall)
+
+	var results [][]model.Candle
+	err = api.breaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		results, err = api.fetchWindows(ctx, pair, symbol, resolution, code, windows)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, httpclient.ErrCircuitOpen) {
+			api.logger.Error("Requisição ao Mercado Bitcoin recusada", err, "pair", pair)
+		}
+		return nil, err
 	}
+
+	candles := mergeWindows(results)
+	api.logger.Info("Quantidade de candles retornados pela API do Mercado Bitcoin", "count", len(candles), "requests", len(windows))
+
+	return candles, nil
 }
 
-// convertPairFormat converte o formato do par de BRLBTC para BTC-BRL
-func convertPairFormat(pair string) string {
-	// De "BRLBTC" para "BTC-BRL"
-	return pair[3:] + "-BRL"
+// fetchWindows busca as janelas com no máximo api.concurrency requisições simultâneas, retornando os
+// candles de cada janela na mesma ordem. A primeira falha cancela as janelas restantes.
+func (api *CandleAPI) fetchWindows(ctx context.Context, pair, symbol string, resolution model.Resolution, code string, windows []model.TimeRange) ([][]model.Candle, error) {
+	results := make([][]model.Candle, len(windows))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		fetchErr error
+	)
+	sem := make(chan struct{}, api.concurrency)
+	for i, w := range windows {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, w model.TimeRange) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			candles, err := api.fetchWindow(ctx, pair, symbol, resolution, code, w.From, w.To)
+			if err != nil {
+				// A primeira falha cancela as janelas restantes
+				once.Do(func() {
+					fetchErr = err
+					cancel()
+				})
+				return
+			}
+			results[i] = candles
+		}(i, w)
+	}
+	wg.Wait()
+
+	if fetchErr == nil {
+		fetchErr = ctx.Err()
+	}
+	if fetchErr != nil {
+		return nil, fetchErr
+	}
+	return results, nil
 }
 
-// GetCandles obtém os candles para um par em um intervalo de tempo
-func (api *CandleAPI) GetCandles(ctx context.Context, pair string, from, to time.Time) ([]model.Candle, error) {
+// fetchWindow busca os candles de uma única janela, que não excede o limite por requisição do provedor
+func (api *CandleAPI) fetchWindow(ctx context.Context, pair, symbol string, resolution model.Resolution, code string, from, to time.Time) ([]model.Candle, error) {
 	url := fmt.Sprintf(
-		"%s/candles?symbol=%s&from=%d&to=%d&resolution=1d",
+		"%s/candles?symbol=%s&from=%d&to=%d&resolution=%s",
 		api.baseURL,
-		convertPairFormat(pair),
+		symbol,
 		from.Unix(),
 		to.Unix(),
+		code,
 	)
 
 	// Log da URL chamada
@@ -74,46 +262,96 @@ func (api *CandleAPI) GetCandles(ctx context.Context, pair string, from, to time
 		return nil, err
 	}
 
-	resp, err := api.httpClient.Do(req)
+	resp, err := api.retry.Do(api.httpClient, req, api.logger)
 	if err != nil {
 		api.logger.Error("Erro ao fazer request", err)
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		err := fmt.Errorf("status code inválido: %d", resp.StatusCode)
-		api.logger.Error("Resposta inválida da API", err)
-		return nil, err
-	}
-
 	var response apiResponse
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
 		api.logger.Error("Erro ao decodificar resposta", err)
 		return nil, err
 	}
 
-	candles := make([]model.Candle, 0, len(response.T))
-	for i := 0; i < len(response.T); i++ {
-		open, _ := strconv.ParseFloat(response.O[i], 64)
-		close, _ := strconv.ParseFloat(response.C[i], 64)
-		high, _ := strconv.ParseFloat(response.H[i], 64)
-		low, _ := strconv.ParseFloat(response.L[i], 64)
-		volume, _ := strconv.ParseFloat(response.V[i], 64)
-		candle := model.Candle{
-			Pair:      pair,
-			Timestamp: time.Unix(response.T[i], 0),
-			Open:      open,
-			High:      high,
-			Low:       low,
-			Close:     close,
-			Volume:    volume,
+	candles, err := response.candles(pair, resolution)
+	if err != nil {
+		api.logger.Error("Resposta inválida da API", err)
+		return nil, err
+	}
+
+	return candles, nil
+}
+
+// mergeWindows concatena os candles das janelas em ordem, descartando os timestamps já recebidos em uma
+// janela anterior (as bordas de janelas vizinhas podem se sobrepor). A ordem dentro de cada janela é
+// preservada, para que a validação dos candles ainda veja os problemas da resposta do provedor.
+func mergeWindows(windows [][]model.Candle) []model.Candle {
+	var merged []model.Candle
+	seen := make(map[int64]bool)
+	for _, candles := range windows {
+		current := make(map[int64]bool, len(candles))
+		for _, c := range candles {
+			key := c.Timestamp.Unix()
+			if seen[key] {
+				continue
+			}
+			current[key] = true
+			merged = append(merged, c)
+		}
+		for key := range current {
+			seen[key] = true
 		}
-		candles = append(candles, candle)
 	}
+	return merged
+}
 
-	candleCount := len(candles)
-	api.logger.Info("Quantidade de candles retornados pela API do Mercado Bitcoin", "count", candleCount)
+// candles converte as colunas da resposta em candles, verificando se todas as colunas têm um valor
+// por timestamp e se todos os valores são numéricos
+func (r apiResponse) candles(pair string, resolution model.Resolution) ([]model.Candle, error) {
+	columns := []struct {
+		name   string
+		values []string
+	}{
+		{"o", r.O}, {"h", r.H}, {"l", r.L}, {"c", r.C}, {"v", r.V},
+	}
+	for _, col := range columns {
+		if len(col.values) != len(r.T) {
+			return nil, fmt.Errorf("coluna %q com %d valores para %d timestamps", col.name, len(col.values), len(r.T))
+		}
+	}
+
+	candles := make([]model.Candle, 0, len(r.T))
+	for i, ts := range r.T {
+		var values [5]decimal.Decimal
+		for j, col := range columns {
+			value, err := parseDecimal(col.values[i])
+			if err != nil {
+				return nil, fmt.Errorf("valor inválido na coluna %q do candle %d: %w", col.name, ts, err)
+			}
+			values[j] = value
+		}
 
+		candles = append(candles, model.Candle{
+			Pair:       pair,
+			Resolution: resolution,
+			Timestamp:  time.Unix(ts, 0).UTC(),
+			Open:       values[0],
+			High:       values[1],
+			Low:        values[2],
+			Close:      values[3],
+			Volume:     values[4],
+		})
+	}
 	return candles, nil
 }
+
+// parseDecimal converte um valor textual da API em decimal exato, arredondado às casas persistidas
+func parseDecimal(value string) (decimal.Decimal, error) {
+	d, err := decimal.NewFromString(value)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return d.Round(model.PriceScale), nil
+}
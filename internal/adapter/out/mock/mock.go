@@ -2,6 +2,7 @@ package mock
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	"mms_api/internal/domain/model"
@@ -10,10 +11,10 @@ import (
 // MockMMSRepository é um mock do repositório MMS para testes
 type MockMMSRepository struct {
 	SaveBatchFunc             func(ctx context.Context, mms []model.MMS) error
-	FindByPairAndRangeFunc    func(ctx context.Context, pair string, from, to time.Time, period int) ([]model.MMS, error)
-	CheckDataCompletenessFunc func(ctx context.Context, pair string, from, to time.Time) (bool, []time.Time, error)
-	GetLastTimestampFunc      func(ctx context.Context, pair string) (time.Time, error)
-	GetMMSByPairFunc          func(ctx context.Context, pair string, timeframe string) ([]model.MMS, error)
+	FindByPairAndRangeFunc    func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time, period int, avgType model.AverageType) ([]model.MMS, error)
+	CheckDataCompletenessFunc func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) (bool, []time.Time, error)
+	GetLastTimestampFunc      func(ctx context.Context, pair string, resolution model.Resolution) (time.Time, error)
+	GetMMSByPairFunc          func(ctx context.Context, pair string, resolution model.Resolution, timeframe string) ([]model.MMS, error)
 	SaveMMSFunc               func(ctx context.Context, mms model.MMS) error
 }
 
@@ -24,30 +25,30 @@ func (m *MockMMSRepository) SaveBatch(ctx context.Context, mms []model.MMS) erro
 	return nil
 }
 
-func (m *MockMMSRepository) FindByPairAndTimeRange(ctx context.Context, pair string, from, to time.Time, period int) ([]model.MMS, error) {
+func (m *MockMMSRepository) FindByPairAndTimeRange(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time, period int, avgType model.AverageType) ([]model.MMS, error) {
 	if m.FindByPairAndRangeFunc != nil {
-		return m.FindByPairAndRangeFunc(ctx, pair, from, to, period)
+		return m.FindByPairAndRangeFunc(ctx, pair, resolution, from, to, period, avgType)
 	}
 	return nil, nil
 }
 
-func (m *MockMMSRepository) CheckDataCompleteness(ctx context.Context, pair string, from, to time.Time) (bool, []time.Time, error) {
+func (m *MockMMSRepository) CheckDataCompleteness(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) (bool, []time.Time, error) {
 	if m.CheckDataCompletenessFunc != nil {
-		return m.CheckDataCompletenessFunc(ctx, pair, from, to)
+		return m.CheckDataCompletenessFunc(ctx, pair, resolution, from, to)
 	}
 	return true, nil, nil
 }
 
-func (m *MockMMSRepository) GetLastTimestamp(ctx context.Context, pair string) (time.Time, error) {
+func (m *MockMMSRepository) GetLastTimestamp(ctx context.Context, pair string, resolution model.Resolution) (time.Time, error) {
 	if m.GetLastTimestampFunc != nil {
-		return m.GetLastTimestampFunc(ctx, pair)
+		return m.GetLastTimestampFunc(ctx, pair, resolution)
 	}
 	return time.Time{}, nil
 }
 
-func (m *MockMMSRepository) GetMMSByPair(ctx context.Context, pair string, timeframe string) ([]model.MMS, error) {
+func (m *MockMMSRepository) GetMMSByPair(ctx context.Context, pair string, resolution model.Resolution, timeframe string) ([]model.MMS, error) {
 	if m.GetMMSByPairFunc != nil {
-		return m.GetMMSByPairFunc(ctx, pair, timeframe)
+		return m.GetMMSByPairFunc(ctx, pair, resolution, timeframe)
 	}
 	return nil, nil
 }
@@ -59,14 +60,168 @@ func (m *MockMMSRepository) SaveMMS(ctx context.Context, mms model.MMS) error {
 	return nil
 }
 
+// MockIndicatorRepository é um mock do repositório de indicadores para testes
+type MockIndicatorRepository struct {
+	SaveBatchFunc          func(ctx context.Context, values []model.IndicatorValue) error
+	FindByPairAndRangeFunc func(ctx context.Context, pair string, resolution model.Resolution, indicator string, from, to time.Time) ([]model.IndicatorValue, error)
+}
+
+func (m *MockIndicatorRepository) SaveBatch(ctx context.Context, values []model.IndicatorValue) error {
+	if m.SaveBatchFunc != nil {
+		return m.SaveBatchFunc(ctx, values)
+	}
+	return nil
+}
+
+func (m *MockIndicatorRepository) FindByPairAndTimeRange(ctx context.Context, pair string, resolution model.Resolution, indicator string, from, to time.Time) ([]model.IndicatorValue, error) {
+	if m.FindByPairAndRangeFunc != nil {
+		return m.FindByPairAndRangeFunc(ctx, pair, resolution, indicator, from, to)
+	}
+	return nil, nil
+}
+
+// MockCandleRepository é um mock do repositório de candles para testes.
+// Sem funções configuradas, os candles ficam em memória no campo Candles.
+type MockCandleRepository struct {
+	Candles                []model.Candle
+	SaveBatchFunc          func(ctx context.Context, candles []model.Candle) error
+	FindByPairAndRangeFunc func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) ([]model.Candle, error)
+}
+
+func (m *MockCandleRepository) SaveBatch(ctx context.Context, candles []model.Candle) error {
+	if m.SaveBatchFunc != nil {
+		return m.SaveBatchFunc(ctx, candles)
+	}
+	for _, c := range candles {
+		replaced := false
+		for i := range m.Candles {
+			if m.Candles[i].Pair == c.Pair && m.Candles[i].Resolution == c.Resolution && m.Candles[i].Timestamp.Equal(c.Timestamp) {
+				m.Candles[i] = c
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			m.Candles = append(m.Candles, c)
+		}
+	}
+	sort.Slice(m.Candles, func(i, j int) bool {
+		return m.Candles[i].Timestamp.Before(m.Candles[j].Timestamp)
+	})
+	return nil
+}
+
+func (m *MockCandleRepository) FindByPairAndTimeRange(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) ([]model.Candle, error) {
+	if m.FindByPairAndRangeFunc != nil {
+		return m.FindByPairAndRangeFunc(ctx, pair, resolution, from, to)
+	}
+	var result []model.Candle
+	for _, c := range m.Candles {
+		if c.Pair == pair && c.Resolution == resolution && !c.Timestamp.Before(from) && !c.Timestamp.After(to) {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+// MockSignalRepository é um mock do repositório de sinais para testes
+type MockSignalRepository struct {
+	SaveBatchFunc          func(ctx context.Context, signals []model.Signal) error
+	FindByPairAndRangeFunc func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time, types []model.SignalType) ([]model.Signal, error)
+}
+
+func (m *MockSignalRepository) SaveBatch(ctx context.Context, signals []model.Signal) error {
+	if m.SaveBatchFunc != nil {
+		return m.SaveBatchFunc(ctx, signals)
+	}
+	return nil
+}
+
+func (m *MockSignalRepository) FindByPairAndTimeRange(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time, types []model.SignalType) ([]model.Signal, error) {
+	if m.FindByPairAndRangeFunc != nil {
+		return m.FindByPairAndRangeFunc(ctx, pair, resolution, from, to, types)
+	}
+	return nil, nil
+}
+
+// MockDivergenceRepository é um mock do repositório de divergências entre provedores para testes
+type MockDivergenceRepository struct {
+	SaveBatchFunc          func(ctx context.Context, divergences []model.Divergence) error
+	FindByPairAndRangeFunc func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) ([]model.Divergence, error)
+}
+
+func (m *MockDivergenceRepository) SaveBatch(ctx context.Context, divergences []model.Divergence) error {
+	if m.SaveBatchFunc != nil {
+		return m.SaveBatchFunc(ctx, divergences)
+	}
+	return nil
+}
+
+func (m *MockDivergenceRepository) FindByPairAndTimeRange(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) ([]model.Divergence, error) {
+	if m.FindByPairAndRangeFunc != nil {
+		return m.FindByPairAndRangeFunc(ctx, pair, resolution, from, to)
+	}
+	return nil, nil
+}
+
+// MockPairRepository é um mock do registro de pares para testes.
+// Sem funções configuradas, os pares ficam em memória no campo Pairs.
+type MockPairRepository struct {
+	Pairs            []model.RegisteredPair
+	FindAllFunc      func(ctx context.Context) ([]model.RegisteredPair, error)
+	FindBySymbolFunc func(ctx context.Context, symbol string) (*model.RegisteredPair, error)
+	CreateFunc       func(ctx context.Context, pair model.RegisteredPair) error
+	SetEnabledFunc   func(ctx context.Context, symbol string, enabled bool) error
+}
+
+func (m *MockPairRepository) FindAll(ctx context.Context) ([]model.RegisteredPair, error) {
+	if m.FindAllFunc != nil {
+		return m.FindAllFunc(ctx)
+	}
+	return m.Pairs, nil
+}
+
+func (m *MockPairRepository) FindBySymbol(ctx context.Context, symbol string) (*model.RegisteredPair, error) {
+	if m.FindBySymbolFunc != nil {
+		return m.FindBySymbolFunc(ctx, symbol)
+	}
+	for i := range m.Pairs {
+		if m.Pairs[i].Symbol == symbol {
+			pair := m.Pairs[i]
+			return &pair, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockPairRepository) Create(ctx context.Context, pair model.RegisteredPair) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, pair)
+	}
+	m.Pairs = append(m.Pairs, pair)
+	return nil
+}
+
+func (m *MockPairRepository) SetEnabled(ctx context.Context, symbol string, enabled bool) error {
+	if m.SetEnabledFunc != nil {
+		return m.SetEnabledFunc(ctx, symbol, enabled)
+	}
+	for i := range m.Pairs {
+		if m.Pairs[i].Symbol == symbol {
+			m.Pairs[i].Enabled = enabled
+		}
+	}
+	return nil
+}
+
 // MockCandleAPI é um mock da API de candles para testes
 type MockCandleAPI struct {
-	GetCandlesFunc func(ctx context.Context, pair string, from, to time.Time) ([]model.Candle, error)
+	GetCandlesFunc func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) ([]model.Candle, error)
 }
 
-func (m *MockCandleAPI) GetCandles(ctx context.Context, pair string, from, to time.Time) ([]model.Candle, error) {
+func (m *MockCandleAPI) GetCandles(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) ([]model.Candle, error) {
 	if m.GetCandlesFunc != nil {
-		return m.GetCandlesFunc(ctx, pair, from, to)
+		return m.GetCandlesFunc(ctx, pair, resolution, from, to)
 	}
 	return nil, nil
 }
@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"mms_api/internal/domain/model"
+	"mms_api/pkg/logger"
+)
+
+// CandleRepository persiste os candles brutos com uma linha por (par, resolução, timestamp)
+type CandleRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewCandleRepository(db *sql.DB, logger logger.Logger) *CandleRepository {
+	return &CandleRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *CandleRepository) SaveBatch(ctx context.Context, candles []model.Candle) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.Error("Erro ao iniciar transação", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO candles (pair, resolution, timestamp, open, high, low, close, volume, source)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''))
+		ON CONFLICT (pair, resolution, timestamp)
+		DO UPDATE SET open = EXCLUDED.open, high = EXCLUDED.high, low = EXCLUDED.low,
+			close = EXCLUDED.close, volume = EXCLUDED.volume,
+			source = COALESCE(EXCLUDED.source, candles.source)
+	`)
+	if err != nil {
+		r.logger.Error("Erro ao preparar statement", err)
+		return err
+	}
+	defer stmt.Close()
+
+	for _, c := range candles {
+		_, err = stmt.ExecContext(ctx, c.Pair, c.Resolution, c.Timestamp.UTC(), c.Open, c.High, c.Low, c.Close, c.Volume, c.Source)
+		if err != nil {
+			r.logger.Error("Erro ao salvar candle", err, "pair", c.Pair)
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.logger.Error("Erro ao commitar transação", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *CandleRepository) FindByPairAndTimeRange(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) ([]model.Candle, error) {
+	query := `
+		SELECT timestamp, open, high, low, close, volume, COALESCE(source, '')
+		FROM candles
+		WHERE pair = $1
+		AND resolution = $2
+		AND timestamp BETWEEN $3 AND $4
+		ORDER BY timestamp ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pair, resolution, from.UTC(), to.UTC())
+	if err != nil {
+		r.logger.Error("Erro ao buscar candles", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []model.Candle
+	for rows.Next() {
+		c := model.Candle{Pair: pair, Resolution: resolution}
+		if err := rows.Scan(&c.Timestamp, &c.Open, &c.High, &c.Low, &c.Close, &c.Volume, &c.Source); err != nil {
+			r.logger.Error("Erro ao ler candle do banco", err)
+			return nil, err
+		}
+		// A coluna não guarda fuso; os candles são sempre gravados em UTC
+		c.Timestamp = c.Timestamp.UTC()
+		result = append(result, c)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.Error("Erro ao iterar sobre resultados", err)
+		return nil, err
+	}
+
+	return result, nil
+}
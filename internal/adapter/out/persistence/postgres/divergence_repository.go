@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"mms_api/internal/domain/model"
+	"mms_api/pkg/logger"
+)
+
+// DivergenceRepository persiste as divergências encontradas na reconciliação entre provedores de candles
+type DivergenceRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewDivergenceRepository(db *sql.DB, logger logger.Logger) *DivergenceRepository {
+	return &DivergenceRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *DivergenceRepository) SaveBatch(ctx context.Context, divergences []model.Divergence) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.Error("Erro ao iniciar transação", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO candle_divergences (pair, resolution, timestamp, field, primary_provider, secondary_provider, primary_value, secondary_value, difference)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (pair, resolution, timestamp, field, primary_provider, secondary_provider)
+		DO UPDATE SET primary_value = EXCLUDED.primary_value, secondary_value = EXCLUDED.secondary_value, difference = EXCLUDED.difference
+	`)
+	if err != nil {
+		r.logger.Error("Erro ao preparar statement", err)
+		return err
+	}
+	defer stmt.Close()
+
+	for _, d := range divergences {
+		_, err = stmt.ExecContext(ctx, d.Pair, d.Resolution, d.Timestamp.UTC(), d.Field, d.Primary, d.Secondary, d.PrimaryValue, d.SecondaryValue, d.Difference)
+		if err != nil {
+			r.logger.Error("Erro ao salvar divergência", err, "pair", d.Pair)
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.logger.Error("Erro ao commitar transação", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *DivergenceRepository) FindByPairAndTimeRange(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) ([]model.Divergence, error) {
+	query := `
+		SELECT timestamp, field, primary_provider, secondary_provider, primary_value, secondary_value, difference
+		FROM candle_divergences
+		WHERE pair = $1
+		AND resolution = $2
+		AND timestamp BETWEEN $3 AND $4
+		ORDER BY timestamp ASC, field ASC, primary_provider ASC, secondary_provider ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pair, resolution, from.UTC(), to.UTC())
+	if err != nil {
+		r.logger.Error("Erro ao buscar divergências", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []model.Divergence
+	for rows.Next() {
+		d := model.Divergence{Pair: pair, Resolution: resolution}
+		if err := rows.Scan(&d.Timestamp, &d.Field, &d.Primary, &d.Secondary, &d.PrimaryValue, &d.SecondaryValue, &d.Difference); err != nil {
+			r.logger.Error("Erro ao ler divergência do banco", err)
+			return nil, err
+		}
+		d.Timestamp = d.Timestamp.UTC()
+		result = append(result, d)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.Error("Erro ao iterar sobre resultados", err)
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"mms_api/internal/domain/model"
+	"mms_api/pkg/logger"
+)
+
+// IndicatorRepository persiste indicadores técnicos com uma linha por série
+type IndicatorRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewIndicatorRepository(db *sql.DB, logger logger.Logger) *IndicatorRepository {
+	return &IndicatorRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *IndicatorRepository) SaveBatch(ctx context.Context, values []model.IndicatorValue) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.Error("Erro ao iniciar transação", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO indicator_values (pair, resolution, indicator, timestamp, series, value)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (pair, resolution, indicator, timestamp, series)
+		DO UPDATE SET value = EXCLUDED.value
+	`)
+	if err != nil {
+		r.logger.Error("Erro ao preparar statement", err)
+		return err
+	}
+	defer stmt.Close()
+
+	for _, v := range values {
+		for series, value := range v.Values {
+			// Séries sem valor não têm linha na tabela
+			if !value.Valid {
+				continue
+			}
+			_, err = stmt.ExecContext(ctx, v.Pair, v.Resolution, v.Indicator, v.Timestamp, series, value)
+			if err != nil {
+				r.logger.Error("Erro ao salvar indicador", err, "indicator", v.Indicator)
+				return err
+			}
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.logger.Error("Erro ao commitar transação", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *IndicatorRepository) FindByPairAndTimeRange(ctx context.Context, pair string, resolution model.Resolution, indicator string, from, to time.Time) ([]model.IndicatorValue, error) {
+	query := `
+		SELECT timestamp, series, value
+		FROM indicator_values
+		WHERE pair = $1
+		AND resolution = $2
+		AND indicator = $3
+		AND timestamp BETWEEN $4 AND $5
+		ORDER BY timestamp DESC, series ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pair, resolution, indicator, from, to)
+	if err != nil {
+		r.logger.Error("Erro ao buscar indicador", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	// As linhas chegam ordenadas por timestamp, então agrupamos as séries consecutivas
+	var result []model.IndicatorValue
+	for rows.Next() {
+		var (
+			timestamp time.Time
+			series    string
+			value     decimal.NullDecimal
+		)
+		if err := rows.Scan(&timestamp, &series, &value); err != nil {
+			r.logger.Error("Erro ao ler indicador do banco", err)
+			return nil, err
+		}
+
+		if n := len(result); n == 0 || !result[n-1].Timestamp.Equal(timestamp) {
+			result = append(result, model.IndicatorValue{
+				Pair:       pair,
+				Resolution: resolution,
+				Indicator:  indicator,
+				Timestamp:  timestamp,
+				Values:     make(map[string]decimal.NullDecimal),
+			})
+		}
+		result[len(result)-1].Values[series] = value
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.Error("Erro ao iterar sobre resultados", err)
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -21,11 +21,11 @@ func NewMMSRepository(db *sql.DB, logger logger.Logger) *MMSRepository {
 	}
 }
 
-func (r *MMSRepository) GetLastTimestamp(ctx context.Context, pair string) (time.Time, error) {
+func (r *MMSRepository) GetLastTimestamp(ctx context.Context, pair string, resolution model.Resolution) (time.Time, error) {
 	var timestamp sql.NullTime
-	query := `SELECT MAX(timestamp) FROM mms WHERE pair = $1`
+	query := `SELECT MAX(timestamp) FROM mms WHERE pair = $1 AND resolution = $2`
 
-	err := r.db.QueryRowContext(ctx, query, pair).Scan(&timestamp)
+	err := r.db.QueryRowContext(ctx, query, pair, resolution).Scan(&timestamp)
 	if err == sql.ErrNoRows || !timestamp.Valid {
 		return time.Time{}, nil
 	}
@@ -39,16 +39,13 @@ func (r *MMSRepository) GetLastTimestamp(ctx context.Context, pair string) (time
 
 func (r *MMSRepository) SaveMMS(ctx context.Context, mms model.MMS) error {
 	query := `
-		INSERT INTO mms (pair, timestamp, mms20, mms50, mms200)
-		VALUES ($1, $2, $3, $4, $5)
-		ON CONFLICT (pair, timestamp)
-		DO UPDATE SET 
-			mms20 = EXCLUDED.mms20,
-			mms50 = EXCLUDED.mms50,
-			mms200 = EXCLUDED.mms200
+		INSERT INTO mms (pair, resolution, timestamp, type, period, value)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (pair, resolution, timestamp, type, period)
+		DO UPDATE SET value = COALESCE(EXCLUDED.value, mms.value)
 	`
 
-	_, err := r.db.ExecContext(ctx, query, mms.Pair, mms.Timestamp, mms.MMS20, mms.MMS50, mms.MMS200)
+	_, err := r.db.ExecContext(ctx, query, mms.Pair, mms.Resolution, mms.Timestamp, mms.Type, mms.Period, mms.Value)
 	if err != nil {
 		r.logger.Error("Erro ao salvar MMS", err)
 		return err
@@ -66,13 +63,10 @@ func (r *MMSRepository) SaveBatch(ctx context.Context, mms []model.MMS) error {
 	defer tx.Rollback()
 
 	stmt, err := tx.PrepareContext(ctx, `
-		INSERT INTO mms (pair, timestamp, mms20, mms50, mms200)
-		VALUES ($1, $2, $3, $4, $5)
-		ON CONFLICT (pair, timestamp)
-		DO UPDATE SET 
-			mms20 = EXCLUDED.mms20,
-			mms50 = EXCLUDED.mms50,
-			mms200 = EXCLUDED.mms200
+		INSERT INTO mms (pair, resolution, timestamp, type, period, value)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (pair, resolution, timestamp, type, period)
+		DO UPDATE SET value = COALESCE(EXCLUDED.value, mms.value)
 	`)
 	if err != nil {
 		r.logger.Error("Erro ao preparar statement", err)
@@ -81,7 +75,7 @@ func (r *MMSRepository) SaveBatch(ctx context.Context, mms []model.MMS) error {
 	defer stmt.Close()
 
 	for _, m := range mms {
-		_, err = stmt.ExecContext(ctx, m.Pair, m.Timestamp, m.MMS20, m.MMS50, m.MMS200)
+		_, err = stmt.ExecContext(ctx, m.Pair, m.Resolution, m.Timestamp, m.Type, m.Period, m.Value)
 		if err != nil {
 			r.logger.Error("Erro ao salvar MMS", err)
 			return err
@@ -96,16 +90,19 @@ func (r *MMSRepository) SaveBatch(ctx context.Context, mms []model.MMS) error {
 	return nil
 }
 
-func (r *MMSRepository) FindByPairAndTimeRange(ctx context.Context, pair string, from, to time.Time, period int) ([]model.MMS, error) {
+func (r *MMSRepository) FindByPairAndTimeRange(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time, period int, avgType model.AverageType) ([]model.MMS, error) {
 	query := `
-		SELECT pair, timestamp, mms20, mms50, mms200
+		SELECT pair, resolution, timestamp, type, period, value
 		FROM mms
 		WHERE pair = $1
-		AND timestamp BETWEEN $2 AND $3
+		AND resolution = $2
+		AND type = $3
+		AND period = $4
+		AND timestamp BETWEEN $5 AND $6
 		ORDER BY timestamp DESC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, pair, from, to)
+	rows, err := r.db.QueryContext(ctx, query, pair, resolution, avgType, period, from, to)
 	if err != nil {
 		r.logger.Error("Erro ao buscar MMS", err)
 		return nil, err
@@ -115,7 +112,7 @@ func (r *MMSRepository) FindByPairAndTimeRange(ctx context.Context, pair string,
 	var result []model.MMS
 	for rows.Next() {
 		var mms model.MMS
-		err := rows.Scan(&mms.Pair, &mms.Timestamp, &mms.MMS20, &mms.MMS50, &mms.MMS200)
+		err := rows.Scan(&mms.Pair, &mms.Resolution, &mms.Timestamp, &mms.Type, &mms.Period, &mms.Value)
 		if err != nil {
 			r.logger.Error("Erro ao ler MMS do banco", err)
 			return nil, err
@@ -131,16 +128,17 @@ func (r *MMSRepository) FindByPairAndTimeRange(ctx context.Context, pair string,
 	return result, nil
 }
 
-func (r *MMSRepository) GetMMSByPair(ctx context.Context, pair string, timeframe string) ([]model.MMS, error) {
+func (r *MMSRepository) GetMMSByPair(ctx context.Context, pair string, resolution model.Resolution, timeframe string) ([]model.MMS, error) {
 	query := `
-		SELECT pair, timestamp, mms20, mms50, mms200
+		SELECT pair, resolution, timestamp, type, period, value
 		FROM mms
 		WHERE pair = $1
-		AND timestamp >= NOW() - $2::interval
-		ORDER BY timestamp ASC
+		AND resolution = $2
+		AND timestamp >= NOW() - $3::interval
+		ORDER BY timestamp ASC, type ASC, period ASC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, pair, timeframe)
+	rows, err := r.db.QueryContext(ctx, query, pair, resolution, timeframe)
 	if err != nil {
 		r.logger.Error("Erro ao buscar MMS", err)
 		return nil, err
@@ -150,7 +148,7 @@ func (r *MMSRepository) GetMMSByPair(ctx context.Context, pair string, timeframe
 	var result []model.MMS
 	for rows.Next() {
 		var mms model.MMS
-		err := rows.Scan(&mms.Pair, &mms.Timestamp, &mms.MMS20, &mms.MMS50, &mms.MMS200)
+		err := rows.Scan(&mms.Pair, &mms.Resolution, &mms.Timestamp, &mms.Type, &mms.Period, &mms.Value)
 		if err != nil {
 			r.logger.Error("Erro ao ler MMS do banco", err)
 			return nil, err
@@ -166,32 +164,33 @@ func (r *MMSRepository) GetMMSByPair(ctx context.Context, pair string, timeframe
 	return result, nil
 }
 
-func (r *MMSRepository) CheckDataCompleteness(ctx context.Context, pair string, from, to time.Time) (bool, []time.Time, error) {
-	// Primeiro, vamos buscar as datas que temos dados
+func (r *MMSRepository) CheckDataCompleteness(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) (bool, []time.Time, error) {
+	// Primeiro, vamos buscar os candles que temos dados
 	query := `
-		SELECT DISTINCT timestamp::date
+		SELECT DISTINCT timestamp
 		FROM mms
 		WHERE pair = $1
-		AND timestamp BETWEEN $2 AND $3
-		ORDER BY timestamp::date ASC
+		AND resolution = $2
+		AND timestamp BETWEEN $3 AND $4
+		ORDER BY timestamp ASC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, pair, from, to)
+	rows, err := r.db.QueryContext(ctx, query, pair, resolution, from, to)
 	if err != nil {
 		r.logger.Error("Erro ao buscar timestamps", err)
 		return false, nil, err
 	}
 	defer rows.Close()
 
-	// Criar mapa de datas existentes
-	existingDates := make(map[time.Time]bool)
+	// Criar mapa de candles existentes, pelo início do candle
+	existing := make(map[int64]bool)
 	for rows.Next() {
-		var date time.Time
-		if err := rows.Scan(&date); err != nil {
+		var timestamp time.Time
+		if err := rows.Scan(&timestamp); err != nil {
 			r.logger.Error("Erro ao ler timestamp", err)
 			return false, nil, err
 		}
-		existingDates[date] = true
+		existing[resolution.Truncate(timestamp).Unix()] = true
 	}
 
 	if err = rows.Err(); err != nil {
@@ -199,12 +198,14 @@ func (r *MMSRepository) CheckDataCompleteness(ctx context.Context, pair string,
 		return false, nil, err
 	}
 
-	// Verificar cada data no intervalo
+	// Verificar cada candle da resolução no intervalo
 	var missingDates []time.Time
-	for current := from; !current.After(to); current = current.AddDate(0, 0, 1) {
-		currentDate := time.Date(current.Year(), current.Month(), current.Day(), 0, 0, 0, 0, time.UTC)
-		if !existingDates[currentDate] {
-			missingDates = append(missingDates, currentDate)
+	for current := resolution.Truncate(from); !current.After(to); current = resolution.Next(current) {
+		if current.Before(from) {
+			continue
+		}
+		if !existing[current.Unix()] {
+			missingDates = append(missingDates, current)
 		}
 	}
 
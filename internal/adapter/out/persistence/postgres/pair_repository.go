@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"mms_api/internal/domain/model"
+	"mms_api/pkg/logger"
+)
+
+// PairRepository persiste o registro de pares na tabela pairs
+type PairRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewPairRepository(db *sql.DB, logger logger.Logger) *PairRepository {
+	return &PairRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *PairRepository) FindAll(ctx context.Context) ([]model.RegisteredPair, error) {
+	query := `
+		SELECT symbol, base, quote, enabled, created_at, updated_at
+		FROM pairs
+		ORDER BY symbol ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		r.logger.Error("Erro ao buscar pares", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []model.RegisteredPair
+	for rows.Next() {
+		var p model.RegisteredPair
+		if err := rows.Scan(&p.Symbol, &p.Pair.Base, &p.Pair.Quote, &p.Enabled, &p.CreatedAt, &p.UpdatedAt); err != nil {
+			r.logger.Error("Erro ao ler par do banco", err)
+			return nil, err
+		}
+		result = append(result, p)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.Error("Erro ao iterar sobre resultados", err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *PairRepository) FindBySymbol(ctx context.Context, symbol string) (*model.RegisteredPair, error) {
+	query := `
+		SELECT symbol, base, quote, enabled, created_at, updated_at
+		FROM pairs
+		WHERE symbol = $1
+	`
+
+	var p model.RegisteredPair
+	err := r.db.QueryRowContext(ctx, query, symbol).Scan(&p.Symbol, &p.Pair.Base, &p.Pair.Quote, &p.Enabled, &p.CreatedAt, &p.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.Error("Erro ao buscar par", err, "symbol", symbol)
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+func (r *PairRepository) Create(ctx context.Context, pair model.RegisteredPair) error {
+	query := `
+		INSERT INTO pairs (symbol, base, quote, enabled)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, pair.Symbol, pair.Pair.Base, pair.Pair.Quote, pair.Enabled); err != nil {
+		r.logger.Error("Erro ao cadastrar par", err, "symbol", pair.Symbol)
+		return err
+	}
+
+	return nil
+}
+
+func (r *PairRepository) SetEnabled(ctx context.Context, symbol string, enabled bool) error {
+	query := `
+		UPDATE pairs
+		SET enabled = $2
+		WHERE symbol = $1
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, symbol, enabled); err != nil {
+		r.logger.Error("Erro ao atualizar par", err, "symbol", symbol)
+		return err
+	}
+
+	return nil
+}
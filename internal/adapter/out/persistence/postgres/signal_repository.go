@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+
+	"mms_api/internal/domain/model"
+	"mms_api/pkg/logger"
+)
+
+// SignalRepository persiste os sinais de cruzamento detectados pelo worker
+type SignalRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewSignalRepository(db *sql.DB, logger logger.Logger) *SignalRepository {
+	return &SignalRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *SignalRepository) SaveBatch(ctx context.Context, signals []model.Signal) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.Error("Erro ao iniciar transação", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO signals (pair, resolution, timestamp, type, average_type, fast_period, slow_period, fast_value, slow_value)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (pair, resolution, timestamp, average_type, fast_period, slow_period)
+		DO UPDATE SET type = EXCLUDED.type, fast_value = EXCLUDED.fast_value, slow_value = EXCLUDED.slow_value
+	`)
+	if err != nil {
+		r.logger.Error("Erro ao preparar statement", err)
+		return err
+	}
+	defer stmt.Close()
+
+	for _, s := range signals {
+		_, err = stmt.ExecContext(ctx, s.Pair, s.Resolution, s.Timestamp.UTC(), s.Type, s.AverageType, s.FastPeriod, s.SlowPeriod, s.FastValue, s.SlowValue)
+		if err != nil {
+			r.logger.Error("Erro ao salvar sinal", err, "pair", s.Pair)
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.logger.Error("Erro ao commitar transação", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *SignalRepository) FindByPairAndTimeRange(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time, types []model.SignalType) ([]model.Signal, error) {
+	query := `
+		SELECT timestamp, type, average_type, fast_period, slow_period, fast_value, slow_value
+		FROM signals
+		WHERE pair = $1
+		AND resolution = $2
+		AND timestamp BETWEEN $3 AND $4
+		AND (cardinality($5::text[]) = 0 OR type = ANY($5::text[]))
+		ORDER BY timestamp ASC, average_type ASC, fast_period ASC, slow_period ASC
+	`
+
+	filter := make([]string, len(types))
+	for i, t := range types {
+		filter[i] = string(t)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, pair, resolution, from.UTC(), to.UTC(), pq.Array(filter))
+	if err != nil {
+		r.logger.Error("Erro ao buscar sinais", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []model.Signal
+	for rows.Next() {
+		s := model.Signal{Pair: pair, Resolution: resolution}
+		if err := rows.Scan(&s.Timestamp, &s.Type, &s.AverageType, &s.FastPeriod, &s.SlowPeriod, &s.FastValue, &s.SlowValue); err != nil {
+			r.logger.Error("Erro ao ler sinal do banco", err)
+			return nil, err
+		}
+		s.Timestamp = s.Timestamp.UTC()
+		result = append(result, s)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.Error("Erro ao iterar sobre resultados", err)
+		return nil, err
+	}
+
+	return result, nil
+}
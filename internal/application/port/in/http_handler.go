@@ -9,3 +9,30 @@ type MMSHandler interface {
 	// Obter MMSs para um par específico em um intervalo de tempo
 	GetMMSByPair(c *gin.Context)
 }
+
+// IndicatorHandler define o contrato para handlers HTTP de indicadores técnicos
+type IndicatorHandler interface {
+	// Obter valores de um indicador para um par específico em um intervalo de tempo
+	GetIndicatorByPair(c *gin.Context)
+
+	// Obter Bandas de Bollinger com k configurável para um par específico
+	GetBollingerBands(c *gin.Context)
+}
+
+// SignalHandler define o contrato para handlers HTTP de sinais de cruzamento
+type SignalHandler interface {
+	// Obter os sinais de cruzamento de um par específico em um intervalo de tempo
+	GetSignalsByPair(c *gin.Context)
+}
+
+// PairHandler define o contrato para handlers HTTP de administração do registro de pares
+type PairHandler interface {
+	// Listar os pares cadastrados
+	ListPairs(c *gin.Context)
+
+	// Cadastrar um novo par
+	CreatePair(c *gin.Context)
+
+	// Habilitar ou desabilitar um par cadastrado
+	UpdatePair(c *gin.Context)
+}
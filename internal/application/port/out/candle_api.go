@@ -9,5 +9,13 @@ import (
 
 // CandleAPI define o contrato para obtenção de candles
 type CandleAPI interface {
-	GetCandles(ctx context.Context, pair string, from, to time.Time) ([]model.Candle, error)
+	// GetCandles retorna os candles da resolução iniciados entre from e to, em ordem crescente
+	GetCandles(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) ([]model.Candle, error)
+}
+
+// CandleProvider é um provedor de candles identificado pelo nome (ex.: mercadobitcoin), usado nos logs,
+// na origem dos candles e na reconciliação entre provedores
+type CandleProvider struct {
+	Name string
+	API  CandleAPI
 }
@@ -0,0 +1,16 @@
+package out
+
+import (
+	"context"
+	"time"
+
+	"mms_api/internal/domain/model"
+)
+
+// CandleRepository define o contrato para persistência dos candles brutos obtidos dos provedores
+type CandleRepository interface {
+	// SaveBatch insere ou atualiza candles identificados por (par, resolução, timestamp)
+	SaveBatch(ctx context.Context, candles []model.Candle) error
+	// FindByPairAndTimeRange retorna os candles iniciados entre from e to, em ordem crescente
+	FindByPairAndTimeRange(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) ([]model.Candle, error)
+}
@@ -0,0 +1,15 @@
+package out
+
+import (
+	"context"
+	"time"
+
+	"mms_api/internal/domain/model"
+)
+
+// DivergenceRepository define o contrato para persistência das divergências entre provedores de candles
+type DivergenceRepository interface {
+	SaveBatch(ctx context.Context, divergences []model.Divergence) error
+	// FindByPairAndTimeRange retorna as divergências do intervalo em ordem crescente de timestamp
+	FindByPairAndTimeRange(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) ([]model.Divergence, error)
+}
@@ -0,0 +1,14 @@
+package out
+
+import (
+	"context"
+	"time"
+
+	"mms_api/internal/domain/model"
+)
+
+// IndicatorRepository define o contrato para persistência genérica de indicadores técnicos
+type IndicatorRepository interface {
+	SaveBatch(ctx context.Context, values []model.IndicatorValue) error
+	FindByPairAndTimeRange(ctx context.Context, pair string, resolution model.Resolution, indicator string, from, to time.Time) ([]model.IndicatorValue, error)
+}
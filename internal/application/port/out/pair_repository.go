@@ -0,0 +1,17 @@
+package out
+
+import (
+	"context"
+
+	"mms_api/internal/domain/model"
+)
+
+// PairRepository define o contrato para persistência do registro de pares
+type PairRepository interface {
+	// FindAll retorna todos os pares cadastrados, ordenados pelo símbolo
+	FindAll(ctx context.Context) ([]model.RegisteredPair, error)
+	// FindBySymbol retorna o par cadastrado ou nil quando o símbolo não existe
+	FindBySymbol(ctx context.Context, symbol string) (*model.RegisteredPair, error)
+	Create(ctx context.Context, pair model.RegisteredPair) error
+	SetEnabled(ctx context.Context, symbol string, enabled bool) error
+}
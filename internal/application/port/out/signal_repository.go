@@ -0,0 +1,15 @@
+package out
+
+import (
+	"context"
+	"time"
+
+	"mms_api/internal/domain/model"
+)
+
+// SignalRepository define o contrato para persistência dos sinais de cruzamento
+type SignalRepository interface {
+	SaveBatch(ctx context.Context, signals []model.Signal) error
+	// FindByPairAndTimeRange retorna os sinais do intervalo em ordem crescente; types vazio não filtra por tipo
+	FindByPairAndTimeRange(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time, types []model.SignalType) ([]model.Signal, error)
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"mms_api/internal/domain/indicator"
+	"mms_api/internal/domain/indicator/atr"
+	"mms_api/internal/domain/indicator/bollinger"
+	"mms_api/internal/domain/indicator/dailyrange"
+	"mms_api/internal/domain/indicator/macd"
+	"mms_api/internal/domain/indicator/obv"
+	"mms_api/internal/domain/indicator/rsi"
+	"mms_api/internal/domain/indicator/volatility"
+	"mms_api/internal/domain/indicator/vwap"
+	"mms_api/internal/domain/model"
+)
+
+// IndicatorSet cria os indicadores adicionais calculados para uma resolução
+type IndicatorSet func(resolution model.Resolution) []indicator.Indicator
+
+// NewIndicatorSet cria os indicadores técnicos configurados para cada resolução
+func NewIndicatorSet(cfg indicator.Config) IndicatorSet {
+	return func(resolution model.Resolution) []indicator.Indicator {
+		return NewIndicators(cfg, resolution)
+	}
+}
+
+// NewIndicators cria os indicadores técnicos configurados para uma resolução, usando os valores
+// padrão para parâmetros não informados. Janelas são contadas em candles da resolução.
+func NewIndicators(cfg indicator.Config, resolution model.Resolution) []indicator.Indicator {
+	if cfg.BollingerPeriod <= 0 {
+		cfg.BollingerPeriod = bollinger.DefaultPeriod
+	}
+	if cfg.RSIPeriod <= 0 {
+		cfg.RSIPeriod = rsi.DefaultPeriod
+	}
+	if cfg.MACDFast <= 0 || cfg.MACDSlow <= cfg.MACDFast || cfg.MACDSignal <= 0 {
+		cfg.MACDFast, cfg.MACDSlow, cfg.MACDSignal = macd.DefaultFast, macd.DefaultSlow, macd.DefaultSignal
+	}
+	if cfg.VWAPPeriod <= 0 {
+		cfg.VWAPPeriod = vwap.DefaultPeriod
+	}
+	if cfg.ATRPeriod <= 0 {
+		cfg.ATRPeriod = atr.DefaultPeriod
+	}
+	if cfg.VolatilityWindow <= 1 {
+		cfg.VolatilityWindow = volatility.DefaultPeriod
+	}
+	if cfg.RangePeriod <= 0 {
+		cfg.RangePeriod = dailyrange.DefaultPeriod
+	}
+
+	return []indicator.Indicator{
+		bollinger.New(cfg.BollingerPeriod),
+		rsi.New(cfg.RSIPeriod),
+		macd.New(cfg.MACDFast, cfg.MACDSlow, cfg.MACDSignal),
+		vwap.New(cfg.VWAPPeriod),
+		obv.New(),
+		atr.New(cfg.ATRPeriod),
+		volatility.New(cfg.VolatilityWindow, resolution.PeriodsPerYear()),
+		dailyrange.New(cfg.RangePeriod),
+	}
+}
@@ -3,103 +3,387 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sort"
 	"time"
 
+	"github.com/shopspring/decimal"
+
 	"mms_api/internal/application/port/out"
+	"mms_api/internal/domain/crossover"
+	"mms_api/internal/domain/indicator"
+	"mms_api/internal/domain/indicator/bollinger"
+	"mms_api/internal/domain/indicator/ema"
+	"mms_api/internal/domain/indicator/sma"
 	"mms_api/internal/domain/model"
+	"mms_api/internal/domain/quality"
 	"mms_api/pkg/logger"
+	"mms_api/pkg/monitoring"
 )
 
 // MMSService define o contrato para o serviço de MMS
 type MMSService interface {
-	// Calcular e salvar MMSs para um par em um intervalo
-	CalculateAndSaveMMSForRange(ctx context.Context, pair string, from, to time.Time) error
+	// Calcular e salvar MMSs para um par e uma resolução em um intervalo
+	CalculateAndSaveMMSForRange(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) error
 
-	// Obter MMSs para um par em um intervalo com um período específico
-	GetMMSByPairAndRange(ctx context.Context, pair string, from, to time.Time, period int) ([]model.MMS, error)
+	// Obter médias móveis de um tipo (sma, ema) para um par em um intervalo com um período específico
+	GetMMSByPairAndRange(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time, period int, avgType model.AverageType) ([]model.MMS, error)
 
 	// Verificar completude dos dados nos últimos 365 dias
-	CheckDataCompleteness(ctx context.Context, pair string) (bool, []time.Time, error)
+	CheckDataCompleteness(ctx context.Context, pair string, resolution model.Resolution) (bool, []time.Time, error)
 
 	// Obter MMSs por par e timeframe
-	GetMMSByPair(ctx context.Context, pair string, timeframe string) ([]model.MMS, error)
+	GetMMSByPair(ctx context.Context, pair string, resolution model.Resolution, timeframe string) ([]model.MMS, error)
+
+	// Obter as janelas de média móvel configuradas, em ordem crescente
+	Periods() []int
+
+	// Obter as resoluções de candle configuradas, da menor para a maior
+	Resolutions() []model.Resolution
+
+	// Obter valores de um indicador registrado para um par em um intervalo
+	GetIndicatorByPairAndRange(ctx context.Context, pair string, resolution model.Resolution, name string, from, to time.Time) ([]model.IndicatorValue, error)
+
+	// Obter os nomes dos indicadores registrados
+	Indicators() []string
+
+	// Obter as Bandas de Bollinger com k desvios padrão para um par em um intervalo
+	GetBollingerBands(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time, k float64) ([]model.BollingerBand, error)
+
+	// Obter os sinais de cruzamento de um par em um intervalo, opcionalmente filtrados por tipo
+	GetSignalsByPairAndRange(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time, types []model.SignalType) ([]model.Signal, error)
+}
+
+// Option configura parâmetros opcionais do serviço de MMS
+type Option func(*mmsServiceImpl)
+
+// WithPeriods define as janelas de média móvel calculadas e consultáveis pelo serviço.
+// Listas inválidas são ignoradas e o serviço mantém as janelas padrão.
+func WithPeriods(periods ...int) Option {
+	return func(s *mmsServiceImpl) {
+		normalized, err := model.NormalizePeriods(periods)
+		if err != nil {
+			s.logger.Error("períodos ignorados", "error", err)
+			return
+		}
+		s.periods = normalized
+	}
+}
+
+// WithIndicators registra indicadores adicionais às médias móveis, calculados
+// na mesma execução e persistidos no repositório genérico de indicadores
+func WithIndicators(indicators ...indicator.Indicator) Option {
+	return func(s *mmsServiceImpl) {
+		s.extraIndicators = append(s.extraIndicators, indicators...)
+	}
+}
+
+// WithIndicatorSet registra indicadores adicionais cujos parâmetros dependem da resolução
+// (ex.: a anualização da volatilidade)
+func WithIndicatorSet(set IndicatorSet) Option {
+	return func(s *mmsServiceImpl) {
+		s.indicatorSet = set
+	}
+}
+
+// WithResolutions define as resoluções de candle calculadas e consultáveis pelo serviço.
+// Listas inválidas são ignoradas e o serviço mantém a resolução padrão.
+func WithResolutions(resolutions ...model.Resolution) Option {
+	return func(s *mmsServiceImpl) {
+		normalized, err := model.NormalizeResolutions(resolutions)
+		if err != nil {
+			s.logger.Error("resoluções ignoradas", "error", err)
+			return
+		}
+		s.resolutions = normalized
+	}
+}
+
+// WithIndicatorRepository define o repositório usado pelos indicadores que não são médias móveis
+func WithIndicatorRepository(repo out.IndicatorRepository) Option {
+	return func(s *mmsServiceImpl) {
+		s.indicatorRepo = repo
+	}
+}
+
+// WithCandleRepository define o repositório local de candles. Com ele configurado, os cálculos
+// usam os candles armazenados e buscam no provedor apenas o trecho que ainda falta.
+func WithCandleRepository(repo out.CandleRepository) Option {
+	return func(s *mmsServiceImpl) {
+		s.candleRepo = repo
+	}
+}
+
+// WithSignalRepository define o repositório dos sinais de cruzamento. Com ele configurado, cada
+// cálculo detecta os cruzamentos entre as médias configuradas e entre o preço e cada média.
+func WithSignalRepository(repo out.SignalRepository) Option {
+	return func(s *mmsServiceImpl) {
+		s.signalRepo = repo
+	}
+}
+
+// WithPairService define o registro de pares usado para validar os pares solicitados.
+// Sem registro configurado, apenas os pares padrão são aceitos.
+func WithPairService(pairs PairService) Option {
+	return func(s *mmsServiceImpl) {
+		s.pairs = pairs
+	}
+}
+
+// WithValidationPolicy define o tratamento dos candles com problemas de qualidade (fora de ordem,
+// duplicados, ausentes ou com valores inválidos). Políticas desconhecidas são ignoradas.
+func WithValidationPolicy(policy quality.Policy) Option {
+	return func(s *mmsServiceImpl) {
+		parsed, err := quality.ParsePolicy(string(policy))
+		if err != nil {
+			s.logger.Error("política de validação ignorada", "error", err)
+			return
+		}
+		s.validationPolicy = parsed
+	}
+}
+
+// WithAlertMonitor define o monitor que recebe os alertas de qualidade dos candles
+func WithAlertMonitor(alerts monitoring.AlertMonitor) Option {
+	return func(s *mmsServiceImpl) {
+		s.alerts = alerts
+	}
 }
 
 // mmsServiceImpl implementa a interface MMSService
 type mmsServiceImpl struct {
-	repo      out.MMSRepository
-	candleAPI out.CandleAPI
-	logger    logger.Logger
+	repo             out.MMSRepository
+	indicatorRepo    out.IndicatorRepository
+	candleRepo       out.CandleRepository
+	signalRepo       out.SignalRepository
+	pairs            PairService
+	candleAPI        out.CandleAPI
+	alerts           monitoring.AlertMonitor
+	logger           logger.Logger
+	periods          []int
+	resolutions      []model.Resolution
+	extraIndicators  []indicator.Indicator
+	indicatorSet     IndicatorSet
+	registries       map[model.Resolution]*indicator.Registry
+	validationPolicy quality.Policy
 }
 
-// NewMMSService cria uma nova instância do serviço
-func NewMMSService(repo out.MMSRepository, candleAPI out.CandleAPI, logger logger.Logger) MMSService {
-	return &mmsServiceImpl{
-		repo:      repo,
-		candleAPI: candleAPI,
-		logger:    logger,
+// NewMMSService cria uma nova instância do serviço. candleAPI pode ser nil nos processos que apenas
+// consultam os dados persistidos; nesse caso os cálculos falham sem consultar provedores.
+func NewMMSService(repo out.MMSRepository, candleAPI out.CandleAPI, logger logger.Logger, opts ...Option) MMSService {
+	s := &mmsServiceImpl{
+		repo:             repo,
+		candleAPI:        candleAPI,
+		logger:           logger,
+		periods:          model.DefaultPeriods,
+		resolutions:      []model.Resolution{model.DefaultResolution},
+		validationPolicy: quality.DefaultPolicy,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.registries = make(map[model.Resolution]*indicator.Registry, len(model.Resolutions))
+	for _, resolution := range model.Resolutions {
+		s.registries[resolution] = s.buildRegistry(resolution)
+	}
+
+	return s
+}
+
+// buildRegistry registra uma MMS e uma MME por janela configurada, seguidas dos indicadores adicionais.
+// A sma da janela das Bandas de Bollinger, que é a banda do meio, é registrada mesmo fora das janelas configuradas.
+func (s *mmsServiceImpl) buildRegistry(resolution model.Resolution) *indicator.Registry {
+	registry, _ := indicator.NewRegistry()
+	averages := make([]indicator.Indicator, 0, 2*len(s.periods))
+	for _, period := range s.periods {
+		averages = append(averages, sma.New(period))
+	}
+	for _, period := range s.periods {
+		averages = append(averages, ema.New(period))
+	}
+	for _, ind := range averages {
+		if err := registry.Register(ind); err != nil {
+			s.logger.Error("média móvel ignorada", "error", err, "resolution", resolution)
+		}
+	}
+
+	extras := s.extraIndicators
+	if s.indicatorSet != nil {
+		extras = append(append([]indicator.Indicator{}, extras...), s.indicatorSet(resolution)...)
+	}
+	for _, ind := range extras {
+		if err := registry.Register(ind); err != nil {
+			s.logger.Error("indicador ignorado", "error", err, "resolution", resolution)
+			continue
+		}
+		if bands, ok := ind.(*bollinger.Bollinger); ok {
+			middle := sma.New(bands.Period())
+			if _, registered := registry.Get(middle.Name()); !registered {
+				_ = registry.Register(middle)
+			}
+		}
+	}
+
+	return registry
+}
+
+// Resolutions retorna as resoluções de candle configuradas
+func (s *mmsServiceImpl) Resolutions() []model.Resolution {
+	resolutions := make([]model.Resolution, len(s.resolutions))
+	copy(resolutions, s.resolutions)
+	return resolutions
+}
+
+// registry retorna os indicadores da resolução, ou um erro se ela não está configurada
+func (s *mmsServiceImpl) registry(resolution model.Resolution) (*indicator.Registry, error) {
+	for _, r := range s.resolutions {
+		if r == resolution {
+			return s.registries[resolution], nil
+		}
 	}
+	return nil, errors.New("resolução inválida")
+}
+
+// Periods retorna as janelas de média móvel configuradas
+func (s *mmsServiceImpl) Periods() []int {
+	periods := make([]int, len(s.periods))
+	copy(periods, s.periods)
+	return periods
+}
+
+// isConfiguredPeriod verifica se a janela faz parte das janelas configuradas
+func (s *mmsServiceImpl) isConfiguredPeriod(period int) bool {
+	for _, p := range s.periods {
+		if p == period {
+			return true
+		}
+	}
+	return false
+}
+
+// validatePair verifica se o par está cadastrado e habilitado no registro de pares
+func (s *mmsServiceImpl) validatePair(ctx context.Context, pair string) error {
+	if s.pairs == nil {
+		if !model.IsDefaultPair(pair) {
+			return errors.New("par inválido")
+		}
+		return nil
+	}
+
+	enabled, err := s.pairs.IsEnabled(ctx, pair)
+	if err != nil {
+		s.logger.Error("falha ao consultar registro de pares", "error", err, "pair", pair)
+		return err
+	}
+	if !enabled {
+		return errors.New("par inválido")
+	}
+	return nil
 }
 
 // CalculateAndSaveMMSForRange implementa o cálculo e persistência de MMSs para um intervalo
-func (s *mmsServiceImpl) CalculateAndSaveMMSForRange(ctx context.Context, pair string, from, to time.Time) error {
+func (s *mmsServiceImpl) CalculateAndSaveMMSForRange(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) error {
 	// Validar par
-	if !model.IsValidPair(pair) {
-		return errors.New("par inválido")
+	if err := s.validatePair(ctx, pair); err != nil {
+		return err
 	}
 
-	// Precisamos de dados históricos suficientes para calcular a maior MMS (200 dias)
-	historicalFrom := from.AddDate(0, 0, -200)
+	// Validar resolução
+	registry, err := s.registry(resolution)
+	if err != nil {
+		return err
+	}
 
-	// Buscar candles da API
-	candles, err := s.candleAPI.GetCandles(ctx, pair, historicalFrom, to)
+	// Buscamos histórico suficiente para o indicador de maior lookback; com menos histórico (ex.: um
+	// par recém-listado), cada indicador é calculado a partir do candle em que o seu lookback é atendido
+	candles, err := s.loadHistory(ctx, pair, resolution, registry.Lookback(), from, to)
 	if err != nil {
 		s.logger.Error("falha ao obter candles", "error", err, "pair", pair)
 		return err
 	}
 
-	if len(candles) < 200 {
+	// Validar a qualidade dos candles antes do cálculo
+	candles, err = s.validateCandles(pair, resolution, candles, from, to)
+	if err != nil {
+		return err
+	}
+
+	if len(candles) == 0 {
 		return errors.New("dados insuficientes para calcular MMS")
 	}
 
-	// Calcular MMSs
+	// Calcular cada indicador registrado, guardando as séries completas das médias para a detecção de cruzamentos
 	var mmsEntries []model.MMS
+	var indicatorValues []model.IndicatorValue
+	averages := make(map[model.AverageType]map[int]map[int64]decimal.Decimal)
 
-	// A partir do índice 199 (ou seja, temos 200 dias de histórico)
-	for i := 199; i < len(candles); i++ {
-		// Se a data do candle é anterior à data solicitada, pulamos
-		if candles[i].Timestamp.Before(from) {
-			continue
+	// As médias simples de todas as janelas são calculadas juntas, em uma única passada pelos candles
+	var simplePeriods []int
+	for _, ind := range registry.All() {
+		if ma, ok := ind.(indicator.MovingAverage); ok && ma.AverageType() == model.AverageSimple {
+			simplePeriods = append(simplePeriods, ma.Period())
 		}
+	}
+	simpleAverages := sma.AveragesByPeriod(candles, simplePeriods...)
 
-		// Calcular MMS 20, 50 e 200
-		var sum20, sum50, sum200 float64
-
-		for j := 0; j < 200; j++ {
-			// Índice do candle a ser usado no cálculo
-			idx := i - j
-			closePrice := candles[idx].Close
+	for _, ind := range registry.All() {
+		// Médias móveis são calculadas em aritmética decimal e persistidas na tabela mms
+		if ma, isMovingAverage := ind.(indicator.MovingAverage); isMovingAverage {
+			if averages[ma.AverageType()] == nil {
+				averages[ma.AverageType()] = make(map[int]map[int64]decimal.Decimal)
+			}
+			series := make(map[int64]decimal.Decimal)
+			averages[ma.AverageType()][ma.Period()] = series
 
-			// Somar para cada período
-			if j < 20 {
-				sum20 += closePrice
+			values := simpleAverages[ma.Period()]
+			if ma.AverageType() != model.AverageSimple {
+				values = ma.Averages(candles)
+			}
+			for _, avg := range values {
+				series[avg.Timestamp.Unix()] = avg.Value
 			}
-			if j < 50 {
-				sum50 += closePrice
+
+			// Cada candle do intervalo recebe uma linha por média, nula enquanto o histórico não cobre a janela
+			for _, c := range candles {
+				if c.Timestamp.Before(from) {
+					continue
+				}
+
+				value, ok := series[c.Timestamp.Unix()]
+				mmsEntries = append(mmsEntries, model.MMS{
+					Pair:       pair,
+					Resolution: resolution,
+					Timestamp:  c.Timestamp,
+					Type:       ma.AverageType(),
+					Period:     ma.Period(),
+					Value:      decimal.NullDecimal{Decimal: value, Valid: ok},
+				})
 			}
-			sum200 += closePrice
+			continue
 		}
 
-		// Criar entrada de MMS
-		mms := model.MMS{
-			Pair:      pair,
-			Timestamp: candles[i].Timestamp,
-			MMS20:     sum20 / 20,
-			MMS50:     sum50 / 50,
-			MMS200:    sum200 / 200,
+		// Indicadores acumulados continuam a série persistida em vez de recomeçar no histórico carregado
+		var values []model.IndicatorValue
+		if cumulative, ok := ind.(indicator.Cumulative); ok {
+			if values, err = s.computeCumulative(ctx, pair, resolution, cumulative, candles, from); err != nil {
+				s.logger.Error("falha ao buscar indicador acumulado", "error", err, "pair", pair, "indicator", ind.Name())
+				return err
+			}
+		} else {
+			values = ind.Compute(candles)
 		}
 
-		mmsEntries = append(mmsEntries, mms)
+		for _, value := range values {
+			// Se a data do candle é anterior à data solicitada, pulamos
+			if value.Timestamp.Before(from) {
+				continue
+			}
+
+			value.Resolution = resolution
+			indicatorValues = append(indicatorValues, value)
+		}
 	}
 
 	// Salvar no banco de dados
@@ -108,23 +392,306 @@ func (s *mmsServiceImpl) CalculateAndSaveMMSForRange(ctx context.Context, pair s
 		return err
 	}
 
+	if len(indicatorValues) > 0 {
+		if s.indicatorRepo == nil {
+			return errors.New("repositório de indicadores não configurado")
+		}
+		if err := s.indicatorRepo.SaveBatch(ctx, indicatorValues); err != nil {
+			s.logger.Error("falha ao salvar indicadores", "error", err, "pair", pair)
+			return err
+		}
+	}
+
+	if s.signalRepo != nil {
+		signals := s.detectSignals(pair, resolution, candles, averages, from)
+		if len(signals) > 0 {
+			if err := s.signalRepo.SaveBatch(ctx, signals); err != nil {
+				s.logger.Error("falha ao salvar sinais", "error", err, "pair", pair)
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
+// computeCumulative calcula um indicador acumulado continuando o último valor persistido antes de
+// from no histórico carregado, para que a série não recomece do zero a cada execução. Sem valor
+// persistido (ex.: a primeira execução do par), o acumulado começa no primeiro candle carregado.
+func (s *mmsServiceImpl) computeCumulative(ctx context.Context, pair string, resolution model.Resolution, ind indicator.Cumulative, candles []model.Candle, from time.Time) ([]model.IndicatorValue, error) {
+	if s.indicatorRepo == nil || !candles[0].Timestamp.Before(from) {
+		return ind.Compute(candles), nil
+	}
+
+	// Os valores chegam do mais recente para o mais antigo
+	stored, err := s.indicatorRepo.FindByPairAndTimeRange(ctx, pair, resolution, ind.Name(), candles[0].Timestamp, from.Add(-resolution.Duration()))
+	if err != nil {
+		return nil, err
+	}
+	if len(stored) == 0 {
+		return ind.Compute(candles), nil
+	}
+
+	seed := stored[0]
+	for i, c := range candles {
+		if c.Timestamp.Equal(seed.Timestamp) {
+			return ind.ComputeFrom(seed, candles[i:]), nil
+		}
+	}
+
+	s.logger.Info("valor persistido sem candle correspondente; acumulado recalculado do início do histórico", "pair", pair, "indicator", ind.Name(), "timestamp", seed.Timestamp)
+	return ind.Compute(candles), nil
+}
+
+// detectSignals detecta, para cada tipo de média, os cruzamentos entre todas as combinações de janelas
+// configuradas e entre o fechamento e cada janela. Os candles anteriores a from servem apenas
+// de referência para um cruzamento no primeiro candle do intervalo.
+func (s *mmsServiceImpl) detectSignals(pair string, resolution model.Resolution, candles []model.Candle, averages map[model.AverageType]map[int]map[int64]decimal.Decimal, from time.Time) []model.Signal {
+	timestamps := make([]time.Time, len(candles))
+	closes := make(map[int64]decimal.Decimal, len(candles))
+	for i, c := range candles {
+		timestamps[i] = c.Timestamp
+		closes[c.Timestamp.Unix()] = c.Close
+	}
+
+	var signals []model.Signal
+	add := func(avgType model.AverageType, fastPeriod, slowPeriod int, fast, slow map[int64]decimal.Decimal, up, down model.SignalType) {
+		for _, crossing := range crossover.Detect(timestamps, fast, slow) {
+			if crossing.Timestamp.Before(from) {
+				continue
+			}
+			signalType := up
+			if crossing.Direction == crossover.Down {
+				signalType = down
+			}
+			signals = append(signals, model.Signal{
+				Pair:        pair,
+				Resolution:  resolution,
+				Timestamp:   crossing.Timestamp,
+				Type:        signalType,
+				AverageType: avgType,
+				FastPeriod:  fastPeriod,
+				SlowPeriod:  slowPeriod,
+				FastValue:   crossing.Fast,
+				SlowValue:   crossing.Slow,
+			})
+		}
+	}
+
+	for _, avgType := range []model.AverageType{model.AverageSimple, model.AverageExponential} {
+		series := averages[avgType]
+		for i, fast := range s.periods {
+			for _, slow := range s.periods[i+1:] {
+				add(avgType, fast, slow, series[fast], series[slow], model.SignalGoldenCross, model.SignalDeathCross)
+			}
+			add(avgType, 0, fast, closes, series[fast], model.SignalPriceCrossAbove, model.SignalPriceCrossBelow)
+		}
+	}
+
+	sort.SliceStable(signals, func(i, j int) bool {
+		return signals[i].Timestamp.Before(signals[j].Timestamp)
+	})
+	return signals
+}
+
+// maxHistoryExtensions limita quantas vezes a busca do histórico recua além da estimativa inicial
+const maxHistoryExtensions = 5
+
+// historyCoverage descreve o histórico disponível antes do intervalo calculado, em candles
+type historyCoverage struct {
+	Required   int       // Candles anteriores a 'from' exigidos pelo indicador de maior lookback
+	Available  int       // Candles anteriores a 'from' efetivamente obtidos
+	Oldest     time.Time // Candle mais antigo obtido
+	Extensions int       // Quantas vezes a busca recuou além da estimativa inicial
+}
+
+// Complete indica se o histórico cobre o lookback de todos os indicadores
+func (c historyCoverage) Complete() bool {
+	return c.Available >= c.Required
+}
+
+// loadHistory carrega os candles do intervalo com lookback candles de histórico, contados na resolução.
+// A busca começa lookback candles antes de 'from' e, se a exchange pulou candles, recua progressivamente
+// (dobrando o trecho a cada tentativa) até atender o lookback, até um trecho não ter nenhum candle (a
+// data de listagem do par) ou até maxHistoryExtensions tentativas.
+func (s *mmsServiceImpl) loadHistory(ctx context.Context, pair string, resolution model.Resolution, lookback int, from, to time.Time) ([]model.Candle, error) {
+	historicalFrom := from.Add(-time.Duration(lookback) * resolution.Duration())
+	candles, err := s.loadCandles(ctx, pair, resolution, historicalFrom, to)
+	if err != nil {
+		return nil, err
+	}
+
+	coverage := historyCoverage{Required: lookback - 1, Available: countBefore(candles, from)}
+	for !coverage.Complete() && coverage.Extensions < maxHistoryExtensions {
+		missing := coverage.Required - coverage.Available
+		extendedFrom := historicalFrom.Add(-time.Duration(missing<<coverage.Extensions) * resolution.Duration())
+		coverage.Extensions++
+
+		older, err := s.loadCandles(ctx, pair, resolution, extendedFrom, historicalFrom.Add(-resolution.Duration()))
+		if err != nil {
+			return nil, err
+		}
+		historicalFrom = extendedFrom
+		if len(older) == 0 {
+			break
+		}
+		candles = append(older, candles...)
+		coverage.Available = countBefore(candles, from)
+	}
+
+	for _, c := range candles {
+		if coverage.Oldest.IsZero() || c.Timestamp.Before(coverage.Oldest) {
+			coverage.Oldest = c.Timestamp
+		}
+	}
+
+	if coverage.Complete() {
+		s.logger.Info("histórico disponível", "pair", pair, "resolution", resolution, "required", coverage.Required,
+			"available", coverage.Available, "oldest", coverage.Oldest, "extensions", coverage.Extensions)
+	} else {
+		s.logger.Info("histórico insuficiente para o maior lookback", "pair", pair, "resolution", resolution, "required", coverage.Required,
+			"available", coverage.Available, "oldest", coverage.Oldest, "extensions", coverage.Extensions)
+	}
+
+	return candles, nil
+}
+
+// countBefore conta os candles distintos anteriores a t
+func countBefore(candles []model.Candle, t time.Time) int {
+	seen := make(map[int64]bool, len(candles))
+	for _, c := range candles {
+		if c.Timestamp.Before(t) {
+			seen[c.Timestamp.Unix()] = true
+		}
+	}
+	return len(seen)
+}
+
+// validateCandles aplica a política de validação aos candles, registrando os problemas encontrados.
+// O alerta considera apenas os problemas do intervalo calculado, para que um problema no histórico
+// não seja alertado novamente a cada execução.
+func (s *mmsServiceImpl) validateCandles(pair string, resolution model.Resolution, candles []model.Candle, from, to time.Time) ([]model.Candle, error) {
+	valid, report, err := quality.Validate(candles, resolution, s.validationPolicy)
+	if report.Clean() {
+		return valid, nil
+	}
+
+	s.logger.Info("problemas de qualidade nos candles", "pair", pair, "resolution", resolution, "policy", report.Policy,
+		"issues", report.Summary(), "dropped", report.Dropped, "filled", report.Filled)
+
+	if recent := report.Between(from, to); s.alerts != nil && !recent.Clean() {
+		s.alerts.SendAlert("dados_invalidos", fmt.Sprintf("Problemas de qualidade nos candles de %s (%s), política %s: %s",
+			pair, resolution, report.Policy, recent.Summary()))
+	}
+
+	if err != nil {
+		s.logger.Error("candles recusados pela validação", "error", err, "pair", pair, "resolution", resolution)
+		return nil, err
+	}
+	return valid, nil
+}
+
+// loadCandles retorna os candles do intervalo na ordem recebida, sem deduplicação, que fica a cargo
+// da validação. Com um repositório de candles configurado, lê o histórico local e busca no provedor
+// apenas o início e o fim que faltam, persistindo os candles encerrados e válidos para as próximas execuções.
+func (s *mmsServiceImpl) loadCandles(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) ([]model.Candle, error) {
+	if s.candleAPI == nil {
+		return nil, errors.New("provedor de candles não configurado")
+	}
+	if s.candleRepo == nil {
+		return s.candleAPI.GetCandles(ctx, pair, resolution, from, to)
+	}
+
+	local, err := s.candleRepo.FindByPairAndTimeRange(ctx, pair, resolution, from, to)
+	if err != nil {
+		s.logger.Error("falha ao ler candles locais", "error", err, "pair", pair)
+		return nil, err
+	}
+
+	// Intervalos ausentes no repositório: tudo, ou o trecho antes do primeiro candle local, os buracos
+	// entre os trechos contíguos (inclusive candles descartados pela validação ao salvar) e o trecho final
+	var gaps []model.TimeRange
+	if len(local) == 0 {
+		gaps = append(gaps, model.TimeRange{From: from, To: to})
+	} else {
+		timestamps := make([]time.Time, len(local))
+		for i, c := range local {
+			timestamps[i] = c.Timestamp
+		}
+		next := from
+		for _, r := range resolution.ContiguousRanges(timestamps) {
+			if r.From.Sub(next) >= resolution.Duration() {
+				gaps = append(gaps, model.TimeRange{From: next, To: r.From.Add(-resolution.Duration())})
+			}
+			next = resolution.Next(r.To)
+		}
+		if !next.After(to) {
+			gaps = append(gaps, model.TimeRange{From: next, To: to})
+		}
+	}
+
+	var fetched []model.Candle
+	for _, g := range gaps {
+		candles, err := s.candleAPI.GetCandles(ctx, pair, resolution, g.From, g.To)
+		if err != nil {
+			return nil, err
+		}
+		fetched = append(fetched, candles...)
+	}
+
+	if len(fetched) == 0 {
+		return local, nil
+	}
+
+	// Só persistimos candles encerrados e com valores válidos; o candle em andamento é buscado novamente na próxima execução
+	now := time.Now()
+	closed := make([]model.Candle, 0, len(fetched))
+	for _, c := range fetched {
+		if _, ok := quality.CheckCandle(c); !ok {
+			continue
+		}
+		if !c.Timestamp.Add(resolution.Duration()).After(now) {
+			closed = append(closed, c)
+		}
+	}
+	if len(closed) > 0 {
+		if err := s.candleRepo.SaveBatch(ctx, closed); err != nil {
+			s.logger.Error("falha ao salvar candles", "error", err, "pair", pair)
+			return nil, err
+		}
+	}
+
+	s.logger.Info("candles atualizados", "pair", pair, "resolution", resolution, "local", len(local), "fetched", len(fetched))
+
+	// Os candles buscados preenchem os buracos do histórico local, preservando a ordem cronológica
+	candles := make([]model.Candle, 0, len(local)+len(fetched))
+	candles = append(candles, local...)
+	candles = append(candles, fetched...)
+	sort.SliceStable(candles, func(i, j int) bool {
+		return candles[i].Timestamp.Before(candles[j].Timestamp)
+	})
+	return candles, nil
+}
+
 // CheckDataCompleteness verifica a completude dos dados nos últimos 365 dias
-func (s *mmsServiceImpl) CheckDataCompleteness(ctx context.Context, pair string) (bool, []time.Time, error) {
+func (s *mmsServiceImpl) CheckDataCompleteness(ctx context.Context, pair string, resolution model.Resolution) (bool, []time.Time, error) {
 	// Validar par
-	if !model.IsValidPair(pair) {
-		return false, nil, errors.New("par inválido")
+	if err := s.validatePair(ctx, pair); err != nil {
+		return false, nil, err
+	}
+
+	// Validar resolução
+	if _, err := s.registry(resolution); err != nil {
+		return false, nil, err
 	}
 
-	// Definir intervalo de verificação
+	// Definir intervalo de verificação: do último ano até o último candle completo
 	now := time.Now()
-	from := now.AddDate(-1, 0, 0) // 1 ano atrás
-	to := now
+	from := resolution.Truncate(now.AddDate(-1, 0, 0)) // 1 ano atrás
+	to := resolution.Truncate(now).Add(-resolution.Duration())
 
 	// Verificar completude
-	isComplete, missingDates, err := s.repo.CheckDataCompleteness(ctx, pair, from, to)
+	isComplete, missingDates, err := s.repo.CheckDataCompleteness(ctx, pair, resolution, from, to)
 	if err != nil {
 		return false, nil, err
 	}
@@ -133,26 +700,170 @@ func (s *mmsServiceImpl) CheckDataCompleteness(ctx context.Context, pair string)
 }
 
 // GetMMSByPair retorna as médias móveis para um par específico e timeframe
-func (s *mmsServiceImpl) GetMMSByPair(ctx context.Context, pair string, timeframe string) ([]model.MMS, error) {
+func (s *mmsServiceImpl) GetMMSByPair(ctx context.Context, pair string, resolution model.Resolution, timeframe string) ([]model.MMS, error) {
 	// Validar par
-	if !model.IsValidPair(pair) {
-		return nil, errors.New("par inválido")
+	if err := s.validatePair(ctx, pair); err != nil {
+		return nil, err
 	}
 
-	return s.repo.GetMMSByPair(ctx, pair, timeframe)
+	// Validar resolução
+	if _, err := s.registry(resolution); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetMMSByPair(ctx, pair, resolution, timeframe)
 }
 
-// GetMMSByPairAndRange retorna as médias móveis para um par em um intervalo
-func (s *mmsServiceImpl) GetMMSByPairAndRange(ctx context.Context, pair string, from, to time.Time, period int) ([]model.MMS, error) {
+// GetMMSByPairAndRange retorna as médias móveis de um tipo para um par em um intervalo
+func (s *mmsServiceImpl) GetMMSByPairAndRange(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time, period int, avgType model.AverageType) ([]model.MMS, error) {
 	// Validar par
-	if !model.IsValidPair(pair) {
-		return nil, errors.New("par inválido")
+	if err := s.validatePair(ctx, pair); err != nil {
+		return nil, err
+	}
+
+	// Validar resolução
+	if _, err := s.registry(resolution); err != nil {
+		return nil, err
 	}
-	
+
 	// Validar período
-	if !model.IsValidPeriod(period) {
+	if !s.isConfiguredPeriod(period) {
 		return nil, errors.New("período inválido")
 	}
 
-	return s.repo.FindByPairAndTimeRange(ctx, pair, from, to, period)
+	// Validar tipo de média
+	if !model.IsValidAverageType(avgType) {
+		return nil, errors.New("tipo de média inválido")
+	}
+
+	return s.repo.FindByPairAndTimeRange(ctx, pair, resolution, from, to, period, avgType)
+}
+
+// Indicators retorna os nomes dos indicadores registrados, que são os mesmos em todas as resoluções
+func (s *mmsServiceImpl) Indicators() []string {
+	return s.registries[model.DefaultResolution].Names()
+}
+
+// GetIndicatorByPairAndRange retorna os valores de um indicador para um par em um intervalo
+func (s *mmsServiceImpl) GetIndicatorByPairAndRange(ctx context.Context, pair string, resolution model.Resolution, name string, from, to time.Time) ([]model.IndicatorValue, error) {
+	// Validar par
+	if err := s.validatePair(ctx, pair); err != nil {
+		return nil, err
+	}
+
+	// Validar resolução
+	registry, err := s.registry(resolution)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validar indicador
+	ind, ok := registry.Get(name)
+	if !ok {
+		return nil, errors.New("indicador desconhecido")
+	}
+
+	// Médias móveis são lidas da tabela mms
+	if ma, isMovingAverage := ind.(indicator.MovingAverage); isMovingAverage {
+		mms, err := s.repo.FindByPairAndTimeRange(ctx, pair, resolution, from, to, ma.Period(), ma.AverageType())
+		if err != nil {
+			return nil, err
+		}
+
+		values := make([]model.IndicatorValue, 0, len(mms))
+		for _, m := range mms {
+			// Dias sem histórico suficiente para a janela são retornados com valor nulo, como na rota /mms
+			values = append(values, model.IndicatorValue{
+				Pair:       m.Pair,
+				Resolution: m.Resolution,
+				Indicator:  name,
+				Timestamp:  m.Timestamp,
+				Values:     map[string]decimal.NullDecimal{indicator.SeriesValue: m.Value},
+			})
+		}
+		return values, nil
+	}
+
+	if s.indicatorRepo == nil {
+		return nil, errors.New("repositório de indicadores não configurado")
+	}
+
+	return s.indicatorRepo.FindByPairAndTimeRange(ctx, pair, resolution, name, from, to)
+}
+
+// GetBollingerBands retorna as Bandas de Bollinger para um par em um intervalo,
+// derivando as bandas de k a partir da média e do desvio padrão persistidos
+func (s *mmsServiceImpl) GetBollingerBands(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time, k float64) ([]model.BollingerBand, error) {
+	if k <= 0 {
+		return nil, errors.New("multiplicador k inválido")
+	}
+
+	registry, err := s.registry(resolution)
+	if err != nil {
+		return nil, err
+	}
+
+	// Localizar o indicador de Bollinger registrado na resolução
+	var bands *bollinger.Bollinger
+	for _, ind := range registry.All() {
+		if b, ok := ind.(*bollinger.Bollinger); ok {
+			bands = b
+			break
+		}
+	}
+	if bands == nil {
+		return nil, errors.New("bandas de Bollinger não configuradas")
+	}
+
+	values, err := s.GetIndicatorByPairAndRange(ctx, pair, resolution, bands.Name(), from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// A banda do meio é a sma de igual período persistida na tabela mms
+	averages, err := s.GetIndicatorByPairAndRange(ctx, pair, resolution, sma.New(bands.Period()).Name(), from, to)
+	if err != nil {
+		return nil, err
+	}
+	middles := make(map[time.Time]decimal.NullDecimal, len(averages))
+	for _, a := range averages {
+		middles[a.Timestamp.UTC()] = a.Values[indicator.SeriesValue]
+	}
+
+	result := make([]model.BollingerBand, 0, len(values))
+	for _, v := range values {
+		middle, ok := middles[v.Timestamp.UTC()]
+		if !ok || !middle.Valid {
+			continue
+		}
+		result = append(result, bollinger.Bands(v, middle.Decimal, k))
+	}
+
+	return result, nil
+}
+
+// GetSignalsByPairAndRange retorna os sinais de cruzamento de um par em um intervalo
+func (s *mmsServiceImpl) GetSignalsByPairAndRange(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time, types []model.SignalType) ([]model.Signal, error) {
+	// Validar par
+	if err := s.validatePair(ctx, pair); err != nil {
+		return nil, err
+	}
+
+	// Validar resolução
+	if _, err := s.registry(resolution); err != nil {
+		return nil, err
+	}
+
+	// Validar tipos de sinal
+	for _, t := range types {
+		if !model.IsValidSignalType(t) {
+			return nil, errors.New("tipo de sinal inválido")
+		}
+	}
+
+	if s.signalRepo == nil {
+		return nil, errors.New("repositório de sinais não configurado")
+	}
+
+	return s.signalRepo.FindByPairAndTimeRange(ctx, pair, resolution, from, to, types)
 }
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"mms_api/internal/application/port/out"
+	"mms_api/internal/domain/model"
+	"mms_api/pkg/logger"
+)
+
+// Erros do registro de pares, usados pelos handlers para escolher o status HTTP
+var (
+	ErrInvalidPairSymbol = errors.New("símbolo de par inválido")
+	ErrPairAlreadyExists = errors.New("par já cadastrado")
+	ErrPairNotFound      = errors.New("par não cadastrado")
+)
+
+// PairService define o contrato para o registro de pares
+type PairService interface {
+	// Listar todos os pares cadastrados, habilitados ou não
+	ListPairs(ctx context.Context) ([]model.RegisteredPair, error)
+
+	// Obter os símbolos dos pares habilitados, em ordem alfabética
+	EnabledPairs(ctx context.Context) ([]string, error)
+
+	// Verificar se o par está cadastrado e habilitado
+	IsEnabled(ctx context.Context, symbol string) (bool, error)
+
+	// Cadastrar um novo par
+	AddPair(ctx context.Context, symbol string, enabled bool) (model.RegisteredPair, error)
+
+	// Habilitar ou desabilitar um par cadastrado
+	SetPairEnabled(ctx context.Context, symbol string, enabled bool) (model.RegisteredPair, error)
+}
+
+// pairServiceImpl implementa a interface PairService sobre o repositório de pares
+type pairServiceImpl struct {
+	repo   out.PairRepository
+	logger logger.Logger
+}
+
+// NewPairService cria uma nova instância do serviço de pares
+func NewPairService(repo out.PairRepository, logger logger.Logger) PairService {
+	return &pairServiceImpl{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// ListPairs retorna todos os pares cadastrados
+func (s *pairServiceImpl) ListPairs(ctx context.Context) ([]model.RegisteredPair, error) {
+	return s.repo.FindAll(ctx)
+}
+
+// EnabledPairs retorna os símbolos dos pares habilitados
+func (s *pairServiceImpl) EnabledPairs(ctx context.Context) ([]string, error) {
+	pairs, err := s.repo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var symbols []string
+	for _, p := range pairs {
+		if p.Enabled {
+			symbols = append(symbols, p.Symbol)
+		}
+	}
+	return symbols, nil
+}
+
+// IsEnabled verifica se o par está cadastrado e habilitado
+func (s *pairServiceImpl) IsEnabled(ctx context.Context, symbol string) (bool, error) {
+	if !model.IsValidPairSymbol(symbol) {
+		return false, nil
+	}
+
+	pair, err := s.repo.FindBySymbol(ctx, symbol)
+	if err != nil {
+		return false, err
+	}
+	return pair != nil && pair.Enabled, nil
+}
+
+// AddPair cadastra um novo par, aceito no formato canônico (BRLBTC) ou com separador (BTC-BRL)
+func (s *pairServiceImpl) AddPair(ctx context.Context, symbol string, enabled bool) (model.RegisteredPair, error) {
+	pair, err := model.ParsePair(symbol)
+	if err != nil {
+		return model.RegisteredPair{}, ErrInvalidPairSymbol
+	}
+	symbol = pair.String()
+
+	// O símbolo canônico precisa identificar o mesmo par: TUSD-USD vira USDTUSD, que é lido como
+	// USD cotado em USDT e resolveria para outro par nas consultas e nos provedores
+	if canonical, err := model.ParsePair(symbol); err != nil || canonical != pair {
+		s.logger.Info("par recusado: símbolo canônico ambíguo", "pair", symbol, "base", pair.Base, "quote", pair.Quote)
+		return model.RegisteredPair{}, ErrInvalidPairSymbol
+	}
+
+	existing, err := s.repo.FindBySymbol(ctx, symbol)
+	if err != nil {
+		return model.RegisteredPair{}, err
+	}
+	if existing != nil {
+		return model.RegisteredPair{}, ErrPairAlreadyExists
+	}
+
+	if err := s.repo.Create(ctx, model.RegisteredPair{Symbol: symbol, Pair: pair, Enabled: enabled}); err != nil {
+		return model.RegisteredPair{}, err
+	}
+	s.logger.Info("par cadastrado", "pair", symbol, "base", pair.Base, "quote", pair.Quote, "enabled", enabled)
+
+	return s.find(ctx, symbol)
+}
+
+// SetPairEnabled habilita ou desabilita um par cadastrado
+func (s *pairServiceImpl) SetPairEnabled(ctx context.Context, symbol string, enabled bool) (model.RegisteredPair, error) {
+	symbol = model.NormalizePairSymbol(symbol)
+
+	if _, err := s.find(ctx, symbol); err != nil {
+		return model.RegisteredPair{}, err
+	}
+
+	if err := s.repo.SetEnabled(ctx, symbol, enabled); err != nil {
+		return model.RegisteredPair{}, err
+	}
+	s.logger.Info("par atualizado", "pair", symbol, "enabled", enabled)
+
+	return s.find(ctx, symbol)
+}
+
+// find busca um par cadastrado, retornando ErrPairNotFound quando ele não existe
+func (s *pairServiceImpl) find(ctx context.Context, symbol string) (model.RegisteredPair, error) {
+	pair, err := s.repo.FindBySymbol(ctx, symbol)
+	if err != nil {
+		return model.RegisteredPair{}, err
+	}
+	if pair == nil {
+		return model.RegisteredPair{}, ErrPairNotFound
+	}
+	return *pair, nil
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"mms_api/internal/application/port/out"
+	"mms_api/internal/domain/model"
+	"mms_api/internal/domain/reconciliation"
+	"mms_api/pkg/logger"
+	"mms_api/pkg/monitoring"
+)
+
+// ReconciliationService define o contrato para a reconciliação de candles entre dois provedores
+type ReconciliationService interface {
+	// Comparar os candles do par no intervalo entre os dois provedores, gravando e alertando as divergências
+	Reconcile(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) (reconciliation.Report, error)
+}
+
+// reconciliationServiceImpl consulta o mesmo intervalo nos dois provedores, diretamente e sem a cadeia
+// de failover, para que cada lado reflita apenas o próprio provedor
+type reconciliationServiceImpl struct {
+	primary   out.CandleProvider
+	secondary out.CandleProvider
+	repo      out.DivergenceRepository
+	tolerance reconciliation.Tolerance
+	logger    logger.Logger
+	alerts    monitoring.AlertMonitor
+}
+
+// NewReconciliationService cria uma nova instância do serviço de reconciliação. repo e alerts podem ser
+// nil; sem eles as divergências são apenas registradas no log.
+func NewReconciliationService(primary, secondary out.CandleProvider, repo out.DivergenceRepository, tolerance reconciliation.Tolerance, logger logger.Logger, alerts monitoring.AlertMonitor) ReconciliationService {
+	return &reconciliationServiceImpl{
+		primary:   primary,
+		secondary: secondary,
+		repo:      repo,
+		tolerance: tolerance,
+		logger:    logger,
+		alerts:    alerts,
+	}
+}
+
+// Reconcile busca o intervalo nos dois provedores e compara os candles. A falha de qualquer um dos
+// provedores interrompe a reconciliação, já que um lado vazio marcaria todos os candles como ausentes.
+func (s *reconciliationServiceImpl) Reconcile(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) (reconciliation.Report, error) {
+	primaryCandles, err := s.primary.API.GetCandles(ctx, pair, resolution, from, to)
+	if err != nil {
+		return reconciliation.Report{}, fmt.Errorf("erro ao buscar candles em %s: %w", s.primary.Name, err)
+	}
+	secondaryCandles, err := s.secondary.API.GetCandles(ctx, pair, resolution, from, to)
+	if err != nil {
+		return reconciliation.Report{}, fmt.Errorf("erro ao buscar candles em %s: %w", s.secondary.Name, err)
+	}
+
+	report := reconciliation.Compare(pair, resolution,
+		s.primary.Name, inRange(primaryCandles, from, to),
+		s.secondary.Name, inRange(secondaryCandles, from, to),
+		s.tolerance)
+
+	if report.Clean() {
+		s.logger.Info("Provedores de candles conciliados", "pair", pair, "resolution", resolution,
+			"primary", report.Primary, "secondary", report.Secondary, "compared", report.Compared)
+		return report, nil
+	}
+
+	summary := report.Summary()
+	s.logger.Info("Divergências entre provedores de candles", "pair", pair, "resolution", resolution,
+		"primary", report.Primary, "secondary", report.Secondary, "compared", report.Compared,
+		"divergences", len(report.Divergences), "summary", summary)
+
+	if s.repo != nil {
+		if err := s.repo.SaveBatch(ctx, report.Divergences); err != nil {
+			return report, fmt.Errorf("erro ao salvar divergências: %w", err)
+		}
+	}
+
+	if s.alerts != nil {
+		s.alerts.SendAlert("divergencia_provedores", fmt.Sprintf("Divergências entre %s e %s nos candles de %s (%s): %s",
+			report.Primary, report.Secondary, pair, resolution, summary))
+	}
+
+	return report, nil
+}
+
+// inRange descarta os candles fora do intervalo pedido, que os provedores podem incluir nas bordas
+func inRange(candles []model.Candle, from, to time.Time) []model.Candle {
+	var result []model.Candle
+	for _, c := range candles {
+		if !c.Timestamp.Before(from) && !c.Timestamp.After(to) {
+			result = append(result, c)
+		}
+	}
+	return result
+}
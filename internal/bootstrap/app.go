@@ -1,18 +1,15 @@
 package bootstrap
 
 import (
-	"net/http"
-	"time"
-
 	"mms_api/config"
 	httpAdapter "mms_api/internal/adapter/in/http"
 	"mms_api/internal/adapter/in/http/handlers"
 	"mms_api/internal/adapter/in/http/server"
-	"mms_api/internal/adapter/out/mercadobitcoin"
 	"mms_api/internal/adapter/out/persistence/postgres"
 	"mms_api/internal/application/service"
 	pgconfig "mms_api/pkg/db/postgres"
 	"mms_api/pkg/logger"
+	"mms_api/pkg/monitoring"
 )
 
 // App encapsula todas as dependências da aplicação
@@ -40,21 +37,38 @@ func NewApp(port string) *App {
 
 	// Initialize repositories
 	mmsRepo := postgres.NewMMSRepository(db, log)
+	indicatorRepo := postgres.NewIndicatorRepository(db, log)
+	candleRepo := postgres.NewCandleRepository(db, log)
+	signalRepo := postgres.NewSignalRepository(db, log)
+	pairRepo := postgres.NewPairRepository(db, log)
 
-	// Initialize HTTP client for external APIs
-	httpClient := &http.Client{
-		Timeout: 30 * time.Second,
-	}
-
-	// Initialize external APIs
-	candleAPI := mercadobitcoin.NewCandleAPI(cfg.MercadoBitcoinBaseURL, httpClient, log)
+	// Initialize alert monitor
+	alertMonitor := monitoring.NewAlertMonitor(cfg.AlertConfig, log)
 
-	// Setup service and handlers
-	mmsService := service.NewMMSService(mmsRepo, candleAPI, log)
-	mmsHandler := handlers.NewMMSHandler(mmsService, log)
+	// Setup services and handlers. The API only serves stored data, so it has no candle provider:
+	// candles are fetched and the provider circuit breakers tripped by the worker.
+	pairService := service.NewPairService(pairRepo, log)
+	mmsService := service.NewMMSService(mmsRepo, nil, log,
+		service.WithPeriods(cfg.MMSPeriods...),
+		service.WithResolutions(cfg.Resolutions...),
+		service.WithIndicatorSet(service.NewIndicatorSet(cfg.Indicators)),
+		service.WithIndicatorRepository(indicatorRepo),
+		service.WithCandleRepository(candleRepo),
+		service.WithSignalRepository(signalRepo),
+		service.WithPairService(pairService),
+		service.WithValidationPolicy(cfg.CandleValidationPolicy),
+		service.WithAlertMonitor(alertMonitor),
+	)
+	mmsHandler := handlers.NewMMSHandler(mmsService, pairService, log)
+	indicatorHandler := handlers.NewIndicatorHandler(mmsService, pairService, log)
+	signalHandler := handlers.NewSignalHandler(mmsService, pairService, log)
+	pairHandler := handlers.NewPairHandler(pairService, log)
 
 	// Initialize router
-	router := httpAdapter.NewRouter(mmsHandler)
+	if cfg.AdminToken == "" {
+		log.Info("ADMIN_TOKEN não configurado: rotas de administração desativadas")
+	}
+	router := httpAdapter.NewRouter(mmsHandler, indicatorHandler, signalHandler, pairHandler, cfg.AdminToken)
 	ginEngine := router.SetupRoutes()
 
 	// Create server
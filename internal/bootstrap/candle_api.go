@@ -0,0 +1,103 @@
+package bootstrap
+
+import (
+	"fmt"
+	"time"
+
+	"mms_api/config"
+	"mms_api/internal/adapter/out/binance"
+	"mms_api/internal/adapter/out/failover"
+	"mms_api/internal/adapter/out/file"
+	"mms_api/internal/adapter/out/mercadobitcoin"
+	"mms_api/internal/application/port/out"
+	"mms_api/pkg/httpclient"
+	"mms_api/pkg/logger"
+	"mms_api/pkg/monitoring"
+)
+
+// NewCandleAPI cria a cadeia dos provedores de candles configurados em cfg.CandleProviders, em ordem de
+// preferência. As requisições de cada provedor usam o cliente HTTP compartilhado do processo, com o
+// limitador de requisições do provedor, e passam pelo circuit breaker do provedor. Os circuit breakers
+// são retornados para serem expostos pelo servidor de status do worker, e os provedores da cadeia,
+// usados na reconciliação, ficam disponíveis em Providers. Os arquivos locais (provedor file) não fazem
+// requisições e não têm circuit breaker. alerts pode ser nil.
+func NewCandleAPI(cfg *config.Config, log logger.Logger, alerts monitoring.AlertMonitor) (*failover.CandleAPI, []*httpclient.CircuitBreaker, error) {
+	var (
+		providers []out.CandleProvider
+		breakers  []*httpclient.CircuitBreaker
+	)
+	for _, name := range cfg.CandleProviders {
+		api, breaker, err := newProvider(name, cfg, log, alerts)
+		if err != nil {
+			return nil, nil, err
+		}
+		providers = append(providers, out.CandleProvider{Name: name, API: api})
+		if breaker != nil {
+			breakers = append(breakers, breaker)
+		}
+	}
+
+	return failover.NewCandleAPI(providers, log), breakers, nil
+}
+
+// newProvider cria o cliente de um provedor de candles e o seu circuit breaker; os arquivos locais
+// não usam a rede nem circuit breaker
+func newProvider(name string, cfg *config.Config, log logger.Logger, alerts monitoring.AlertMonitor) (out.CandleAPI, *httpclient.CircuitBreaker, error) {
+	if name == config.ProviderFile {
+		api, err := newFileProvider(cfg, log)
+		return api, nil, err
+	}
+
+	breaker := httpclient.NewCircuitBreaker(name, cfg.CircuitBreaker, log, alerts)
+
+	switch name {
+	case config.ProviderMercadoBitcoin:
+		httpClient := httpclient.WithRateLimit(httpclient.Shared(),
+			httpclient.NewRateLimiter(cfg.MercadoBitcoinRateLimit, cfg.MercadoBitcoinRateBurst))
+		api := mercadobitcoin.NewCandleAPI(cfg.MercadoBitcoinBaseURL, httpClient, log,
+			mercadobitcoin.WithSymbolOverrides(cfg.MercadoBitcoinSymbols),
+			mercadobitcoin.WithMaxCandlesPerRequest(cfg.MercadoBitcoinMaxCandles),
+			mercadobitcoin.WithConcurrency(cfg.MercadoBitcoinConcurrency),
+			mercadobitcoin.WithRetryPolicy(cfg.HTTPRetry),
+			mercadobitcoin.WithCircuitBreaker(breaker),
+		)
+		return api, breaker, nil
+
+	case config.ProviderBinance:
+		httpClient := httpclient.WithRateLimit(httpclient.Shared(),
+			httpclient.NewRateLimiter(cfg.BinanceRateLimit, cfg.BinanceRateBurst))
+		api := binance.NewCandleAPI(cfg.BinanceBaseURL, httpClient, log,
+			binance.WithSymbolOverrides(cfg.BinanceSymbols),
+			binance.WithMaxCandlesPerRequest(cfg.BinanceMaxCandles),
+			binance.WithRetryPolicy(cfg.HTTPRetry),
+			binance.WithCircuitBreaker(breaker),
+		)
+		return api, breaker, nil
+
+	default:
+		return nil, nil, fmt.Errorf("provedor de candles não suportado: %q", name)
+	}
+}
+
+// newFileProvider cria a leitura de candles dos arquivos locais, validando formato, colunas e fuso
+func newFileProvider(cfg *config.Config, log logger.Logger) (out.CandleAPI, error) {
+	format, err := file.ParseFormat(cfg.CandleFileFormat)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := file.ParseColumns(cfg.CandleFileColumns)
+	if err != nil {
+		return nil, err
+	}
+	location, err := time.LoadLocation(cfg.CandleFileTimezone)
+	if err != nil {
+		return nil, fmt.Errorf("fuso horário inválido para os arquivos de candles: %w", err)
+	}
+
+	return file.NewCandleAPI(cfg.CandleFileDir, log,
+		file.WithFormat(format),
+		file.WithColumns(columns),
+		file.WithLocation(location),
+		file.WithTimeLayout(cfg.CandleFileTimeLayout),
+	), nil
+}
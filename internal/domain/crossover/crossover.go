@@ -0,0 +1,63 @@
+// Package crossover detecta cruzamentos entre duas séries temporais, como uma média
+// curta e uma longa ou o preço de fechamento e uma média
+package crossover
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Direction indica o sentido do cruzamento da série rápida em relação à lenta
+type Direction int
+
+// Sentidos de cruzamento
+const (
+	Up   Direction = 1  // A série rápida passou a ficar acima da lenta
+	Down Direction = -1 // A série rápida passou a ficar abaixo da lenta
+)
+
+// Crossing representa um cruzamento em um timestamp
+type Crossing struct {
+	Timestamp time.Time
+	Direction Direction
+	Fast      decimal.Decimal
+	Slow      decimal.Decimal
+}
+
+// Detect percorre os timestamps em ordem crescente e retorna os cruzamentos entre as séries,
+// indexadas pelo timestamp Unix. Timestamps em que alguma das séries não tem valor são ignorados.
+// Um cruzamento ocorre quando a série rápida fica estritamente acima (ou abaixo) da lenta depois
+// de ter estado do lado oposto; toques em que as séries se igualam e voltam não geram eventos.
+func Detect(timestamps []time.Time, fast, slow map[int64]decimal.Decimal) []Crossing {
+	var (
+		result []Crossing
+		side   Direction
+	)
+	for _, ts := range timestamps {
+		f, ok := fast[ts.Unix()]
+		if !ok {
+			continue
+		}
+		s, ok := slow[ts.Unix()]
+		if !ok {
+			continue
+		}
+
+		var current Direction
+		switch f.Cmp(s) {
+		case 1:
+			current = Up
+		case -1:
+			current = Down
+		default:
+			continue
+		}
+
+		if side != 0 && current != side {
+			result = append(result, Crossing{Timestamp: ts, Direction: current, Fast: f, Slow: s})
+		}
+		side = current
+	}
+	return result
+}
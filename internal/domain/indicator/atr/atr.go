@@ -0,0 +1,79 @@
+// Package atr implementa o Average True Range (ATR) com suavização de Wilder
+package atr
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/shopspring/decimal"
+
+	"mms_api/internal/domain/indicator"
+	"mms_api/internal/domain/model"
+)
+
+// Séries produzidas pelo indicador
+const (
+	SeriesATR        = "atr"     // Média do true range, na moeda de cotação
+	SeriesATRPercent = "atr_pct" // ATR relativo ao fechamento
+)
+
+// DefaultPeriod é a janela clássica do ATR
+const DefaultPeriod = 14
+
+// ATR calcula a média do true range, semeada pela média simples dos N primeiros
+// true ranges e suavizada por Wilder: atr = (atr anterior * (N-1) + tr) / N
+type ATR struct {
+	period int
+}
+
+// New cria o indicador ATR para a janela informada
+func New(period int) *ATR {
+	return &ATR{period: period}
+}
+
+// Name retorna o nome do indicador (ex.: atr14)
+func (a *ATR) Name() string {
+	return fmt.Sprintf("atr%d", a.period)
+}
+
+// Lookback retorna a quantidade de candles necessária para o primeiro valor.
+// O true range usa o fechamento anterior, então são necessários N+1 candles.
+func (a *ATR) Lookback() int {
+	return a.period + 1
+}
+
+// Compute calcula o ATR para cada candle com N true ranges anteriores disponíveis
+func (a *ATR) Compute(candles []model.Candle) []model.IndicatorValue {
+	if a.period < 1 || len(candles) < a.Lookback() {
+		return nil
+	}
+
+	var atr float64
+	for i := 1; i <= a.period; i++ {
+		atr += TrueRange(candles[i-1], candles[i])
+	}
+	atr /= float64(a.period)
+
+	result := []model.IndicatorValue{a.value(candles[a.period], atr)}
+	for i := a.period + 1; i < len(candles); i++ {
+		atr = (atr*float64(a.period-1) + TrueRange(candles[i-1], candles[i])) / float64(a.period)
+		result = append(result, a.value(candles[i], atr))
+	}
+	return result
+}
+
+// value cria o valor do indicador para o candle
+func (a *ATR) value(candle model.Candle, atr float64) model.IndicatorValue {
+	values := map[string]decimal.Decimal{SeriesATR: indicator.FromFloat(atr)}
+	if !candle.Close.IsZero() {
+		values[SeriesATRPercent] = indicator.FromFloat(atr / candle.Close.InexactFloat64())
+	}
+	return indicator.NewValue(a.Name(), candle, values)
+}
+
+// TrueRange retorna o maior entre a amplitude do candle e as distâncias
+// da máxima e da mínima ao fechamento anterior
+func TrueRange(prev, cur model.Candle) float64 {
+	p, c := indicator.FloatPrices(prev), indicator.FloatPrices(cur)
+	return math.Max(c.High-c.Low, math.Max(math.Abs(c.High-p.Close), math.Abs(c.Low-p.Close)))
+}
@@ -0,0 +1,100 @@
+// Package bollinger implementa as Bandas de Bollinger
+package bollinger
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/shopspring/decimal"
+
+	"mms_api/internal/domain/indicator"
+	"mms_api/internal/domain/indicator/window"
+	"mms_api/internal/domain/model"
+)
+
+// SeriesStdDev é a única série persistida pelo indicador. A banda do meio é a sma de
+// igual período da tabela mms, e as bandas superior e inferior dependem de k, então
+// todas são derivadas na consulta: middle ± k·stddev.
+const SeriesStdDev = "stddev"
+
+// Parâmetros padrão das bandas
+const (
+	DefaultPeriod = 20
+	DefaultK      = 2.0
+)
+
+// Bollinger calcula o desvio padrão populacional dos fechamentos de cada janela,
+// base das Bandas de Bollinger junto com a sma de igual período persistida na tabela mms
+type Bollinger struct {
+	period int
+}
+
+// New cria o indicador de Bandas de Bollinger para a janela informada
+func New(period int) *Bollinger {
+	return &Bollinger{period: period}
+}
+
+// Name retorna o nome do indicador (ex.: bollinger20)
+func (b *Bollinger) Name() string {
+	return fmt.Sprintf("bollinger%d", b.period)
+}
+
+// Period retorna a janela do indicador, a mesma da sma usada como banda do meio
+func (b *Bollinger) Period() int {
+	return b.period
+}
+
+// Lookback retorna a quantidade de candles necessária para o primeiro valor
+func (b *Bollinger) Lookback() int {
+	return b.period
+}
+
+// Compute calcula o desvio padrão de cada janela completa de candles em uma única passada. As janelas decimais mantêm as somas exatas dos fechamentos e dos seus quadrados,
+// então a variância N·Σx² - (Σx)² não sofre cancelamento; só a raiz é calculada em float64.
+func (b *Bollinger) Compute(candles []model.Candle) []model.IndicatorValue {
+	if b.period < 1 || len(candles) < b.period {
+		return nil
+	}
+
+	closes := window.NewDecimal(b.period)
+	squares := window.NewDecimal(b.period)
+	n := decimal.NewFromInt(int64(b.period))
+
+	result := make([]model.IndicatorValue, 0, len(candles)-b.period+1)
+	for _, c := range candles {
+		closes.Push(c.Close)
+		squares.Push(c.Close.Mul(c.Close))
+
+		if !closes.Full() {
+			continue
+		}
+
+		sum := closes.Sum()
+		spread := n.Mul(squares.Sum()).Sub(sum.Mul(sum))
+		stddev := math.Sqrt(spread.InexactFloat64()) / float64(b.period)
+
+		result = append(result, indicator.NewValue(b.Name(), c, map[string]decimal.Decimal{
+			SeriesStdDev: indicator.FromFloat(stddev),
+		}))
+	}
+	return result
+}
+
+// Bands calcula as bandas para um valor persistido do indicador, a sma de igual período
+// no mesmo candle e o multiplicador k, em aritmética decimal com model.PriceScale casas
+func Bands(value model.IndicatorValue, middle decimal.Decimal, k float64) model.BollingerBand {
+	offset := value.Values[SeriesStdDev].Decimal.Mul(decimal.NewFromFloat(k)).Round(model.PriceScale)
+
+	band := model.BollingerBand{
+		Pair:       value.Pair,
+		Resolution: value.Resolution,
+		Timestamp:  value.Timestamp,
+		Middle:     middle,
+		Upper:      middle.Add(offset),
+		Lower:      middle.Sub(offset),
+	}
+	if !middle.IsZero() {
+		band.Width = band.Upper.Sub(band.Lower).DivRound(middle, model.PriceScale)
+	}
+	return band
+}
@@ -0,0 +1,15 @@
+package indicator
+
+// Config contém os parâmetros dos indicadores técnicos calculados além das médias móveis.
+// Parâmetros não informados (zero) usam os valores padrão de cada indicador.
+type Config struct {
+	BollingerPeriod  int // Janela das Bandas de Bollinger (default: 20)
+	RSIPeriod        int // Janela do RSI (default: 14)
+	MACDFast         int // Média exponencial rápida do MACD (default: 12)
+	MACDSlow         int // Média exponencial lenta do MACD (default: 26)
+	MACDSignal       int // Média exponencial da linha de sinal do MACD (default: 9)
+	VWAPPeriod       int // Janela do VWAP móvel (default: 20)
+	ATRPeriod        int // Janela do ATR (default: 14)
+	VolatilityWindow int // Janela de retornos da volatilidade histórica, em candles da resolução (default: 30)
+	RangePeriod      int // Janela das estatísticas de amplitude (default: 14)
+}
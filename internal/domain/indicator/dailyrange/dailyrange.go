@@ -0,0 +1,78 @@
+// Package dailyrange implementa estatísticas de amplitude (máxima - mínima) dos candles
+package dailyrange
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"mms_api/internal/domain/indicator"
+	"mms_api/internal/domain/model"
+)
+
+// Séries produzidas pelo indicador
+const (
+	SeriesRange          = "range"         // Amplitude do candle (High - Low)
+	SeriesRangePercent   = "range_pct"     // Amplitude relativa ao fechamento
+	SeriesAverage        = "avg_range"     // Amplitude média da janela
+	SeriesAveragePercent = "avg_range_pct" // Amplitude relativa média da janela
+	SeriesMax            = "max_range"     // Maior amplitude da janela
+)
+
+// DefaultPeriod é a janela padrão das estatísticas
+const DefaultPeriod = 14
+
+// DailyRange calcula a amplitude de cada candle e sua média e máximo na janela
+type DailyRange struct {
+	period int
+}
+
+// New cria o indicador de amplitude para a janela informada
+func New(period int) *DailyRange {
+	return &DailyRange{period: period}
+}
+
+// Name retorna o nome do indicador (ex.: range14)
+func (d *DailyRange) Name() string {
+	return fmt.Sprintf("range%d", d.period)
+}
+
+// Lookback retorna a quantidade de candles necessária para o primeiro valor
+func (d *DailyRange) Lookback() int {
+	return d.period
+}
+
+// Compute calcula as estatísticas de amplitude de cada janela completa de candles
+func (d *DailyRange) Compute(candles []model.Candle) []model.IndicatorValue {
+	var result []model.IndicatorValue
+	for i := d.period - 1; i < len(candles); i++ {
+		var sum, sumPct, max float64
+		for _, candle := range candles[i-d.period+1 : i+1] {
+			c := indicator.FloatPrices(candle)
+			r := c.High - c.Low
+			sum += r
+			sumPct += percent(r, c.Close)
+			if r > max {
+				max = r
+			}
+		}
+
+		cur := indicator.FloatPrices(candles[i])
+		result = append(result, indicator.NewValue(d.Name(), candles[i], map[string]decimal.Decimal{
+			SeriesRange:          indicator.FromFloat(cur.High - cur.Low),
+			SeriesRangePercent:   indicator.FromFloat(percent(cur.High-cur.Low, cur.Close)),
+			SeriesAverage:        indicator.FromFloat(sum / float64(d.period)),
+			SeriesAveragePercent: indicator.FromFloat(sumPct / float64(d.period)),
+			SeriesMax:            indicator.FromFloat(max),
+		}))
+	}
+	return result
+}
+
+// percent retorna a amplitude relativa ao fechamento, ou zero sem fechamento
+func percent(r, close float64) float64 {
+	if close == 0 {
+		return 0
+	}
+	return r / close
+}
@@ -0,0 +1,102 @@
+// Package ema implementa a média móvel exponencial (MME)
+package ema
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"mms_api/internal/domain/indicator"
+	"mms_api/internal/domain/model"
+)
+
+// EMA calcula a média móvel exponencial com suavização 2/(N+1),
+// semeada pela média simples dos N primeiros fechamentos
+type EMA struct {
+	period int
+}
+
+// New cria uma média móvel exponencial para a janela informada
+func New(period int) *EMA {
+	return &EMA{period: period}
+}
+
+// Name retorna o nome do indicador (ex.: ema20)
+func (e *EMA) Name() string {
+	return fmt.Sprintf("ema%d", e.period)
+}
+
+// Lookback retorna a quantidade de candles necessária para o primeiro valor
+func (e *EMA) Lookback() int {
+	return e.period
+}
+
+// Period retorna a janela da média
+func (e *EMA) Period() int {
+	return e.period
+}
+
+// AverageType identifica a média como exponencial
+func (e *EMA) AverageType() model.AverageType {
+	return model.AverageExponential
+}
+
+// Casas decimais mantidas no estado da média entre um candle e o seguinte; só o valor
+// retornado é arredondado a model.PriceScale casas
+const statePrecision = 2 * model.PriceScale
+
+// Compute calcula a média exponencial a partir do candle em que a semente fica disponível
+func (e *EMA) Compute(candles []model.Candle) []model.IndicatorValue {
+	return indicator.AverageValues(e.Name(), candles, e.Averages(candles))
+}
+
+// Averages calcula a média exponencial em aritmética decimal. A semente e o fator de suavização
+// são mantidos com statePrecision casas, de modo que o resultado é determinístico e independe
+// da representação binária dos preços.
+func (e *EMA) Averages(candles []model.Candle) []indicator.Average {
+	if len(candles) < e.period {
+		return nil
+	}
+
+	period := decimal.NewFromInt(int64(e.period))
+	alpha := decimal.NewFromInt(2).DivRound(period.Add(decimal.NewFromInt(1)), statePrecision)
+	keep := decimal.NewFromInt(1).Sub(alpha)
+
+	state := decimal.Zero
+	for _, c := range candles[:e.period] {
+		state = state.Add(c.Close)
+	}
+	state = state.DivRound(period, statePrecision)
+
+	result := make([]indicator.Average, 0, len(candles)-e.period+1)
+	result = append(result, indicator.Average{Timestamp: candles[e.period-1].Timestamp, Value: state.Round(model.PriceScale)})
+	for _, c := range candles[e.period:] {
+		state = alpha.Mul(c.Close).Add(keep.Mul(state)).Round(statePrecision)
+		result = append(result, indicator.Average{Timestamp: c.Timestamp, Value: state.Round(model.PriceScale)})
+	}
+	return result
+}
+
+// Series calcula a média exponencial de uma série de valores. O primeiro elemento
+// retornado corresponde a values[period-1], semeado pela média simples dos N primeiros valores.
+func Series(values []float64, period int) []float64 {
+	if period < 1 || len(values) < period {
+		return nil
+	}
+
+	alpha := 2.0 / float64(period+1)
+
+	var seed float64
+	for _, v := range values[:period] {
+		seed += v
+	}
+	seed /= float64(period)
+
+	result := make([]float64, 0, len(values)-period+1)
+	result = append(result, seed)
+	for _, v := range values[period:] {
+		prev := result[len(result)-1]
+		result = append(result, alpha*v+(1-alpha)*prev)
+	}
+	return result
+}
@@ -0,0 +1,173 @@
+// Package indicator define o contrato dos indicadores técnicos e o registro percorrido pelo worker
+package indicator
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"mms_api/internal/domain/model"
+)
+
+// SeriesValue é o nome da série de indicadores que produzem um único valor por candle
+const SeriesValue = "value"
+
+// Indicator define o contrato de um indicador técnico calculado a partir de candles
+type Indicator interface {
+	// Nome único do indicador, usado no armazenamento e na rota /:pair/indicators/:name
+	Name() string
+
+	// Quantidade de candles necessária para produzir o primeiro valor
+	Lookback() int
+
+	// Calcular o indicador sobre candles ordenados por timestamp, retornando um valor
+	// para cada candle a partir do qual o histórico é suficiente
+	Compute(candles []model.Candle) []model.IndicatorValue
+}
+
+// MovingAverage é implementado pelos indicadores de média móvel, persistidos na tabela mms
+type MovingAverage interface {
+	Indicator
+	AverageType() model.AverageType
+	Period() int
+
+	// Calcular a média em aritmética decimal, com model.PriceScale casas, a partir do
+	// candle em que o histórico é suficiente. Compute retorna os mesmos valores na série value.
+	Averages(candles []model.Candle) []Average
+}
+
+// Cumulative é implementado pelos indicadores acumulados desde o início da série (ex.: OBV),
+// cujo valor não é determinado apenas pelos candles de uma janela
+type Cumulative interface {
+	Indicator
+
+	// Continuar a série a partir de um valor persistido. O primeiro candle é o candle de
+	// seed, que mantém o valor persistido; os demais acumulam sobre ele.
+	ComputeFrom(seed model.IndicatorValue, candles []model.Candle) []model.IndicatorValue
+}
+
+// Average é o valor decimal de uma média móvel no candle iniciado em Timestamp
+type Average struct {
+	Timestamp time.Time
+	Value     decimal.Decimal
+}
+
+// AverageValues converte médias decimais em valores de indicador. As médias correspondem aos
+// últimos len(averages) candles da série, como retornado por MovingAverage.Averages.
+func AverageValues(name string, candles []model.Candle, averages []Average) []model.IndicatorValue {
+	offset := len(candles) - len(averages)
+
+	result := make([]model.IndicatorValue, 0, len(averages))
+	for i, avg := range averages {
+		result = append(result, NewValue(name, candles[offset+i], map[string]decimal.Decimal{
+			SeriesValue: avg.Value,
+		}))
+	}
+	return result
+}
+
+// NewValue cria o valor de um indicador para o candle informado, com todas as séries presentes
+func NewValue(name string, candle model.Candle, values map[string]decimal.Decimal) model.IndicatorValue {
+	series := make(map[string]decimal.NullDecimal, len(values))
+	for name, value := range values {
+		series[name] = decimal.NewNullDecimal(value)
+	}
+	return model.IndicatorValue{
+		Pair:       candle.Pair,
+		Resolution: candle.Resolution,
+		Indicator:  name,
+		Timestamp:  candle.Timestamp,
+		Values:     series,
+	}
+}
+
+// FromFloat converte o resultado de um cálculo em float64 para decimal com model.PriceScale
+// casas, a mesma escala da coluna persistida, para que o valor calculado seja o valor lido de
+// volta. O valor deve ser finito.
+func FromFloat(x float64) decimal.Decimal {
+	return decimal.NewFromFloat(x).Round(model.PriceScale)
+}
+
+// Prices são os preços e o volume de um candle em float64, usados pelos indicadores
+// estatísticos, que não exigem aritmética decimal exata
+type Prices struct {
+	Open, High, Low, Close, Volume float64
+}
+
+// FloatPrices converte os valores decimais do candle para float64
+func FloatPrices(c model.Candle) Prices {
+	return Prices{
+		Open:   c.Open.InexactFloat64(),
+		High:   c.High.InexactFloat64(),
+		Low:    c.Low.InexactFloat64(),
+		Close:  c.Close.InexactFloat64(),
+		Volume: c.Volume.InexactFloat64(),
+	}
+}
+
+// Registry mantém os indicadores disponíveis, na ordem em que foram registrados
+type Registry struct {
+	indicators []Indicator
+	byName     map[string]Indicator
+}
+
+// NewRegistry cria um registro com os indicadores informados
+func NewRegistry(indicators ...Indicator) (*Registry, error) {
+	r := &Registry{byName: make(map[string]Indicator)}
+	for _, ind := range indicators {
+		if err := r.Register(ind); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Register adiciona um indicador ao registro; nomes devem ser únicos
+func (r *Registry) Register(ind Indicator) error {
+	if ind.Lookback() < 1 {
+		return fmt.Errorf("indicador %s com lookback inválido: %d", ind.Name(), ind.Lookback())
+	}
+	if _, exists := r.byName[ind.Name()]; exists {
+		return fmt.Errorf("indicador já registrado: %s", ind.Name())
+	}
+
+	r.indicators = append(r.indicators, ind)
+	r.byName[ind.Name()] = ind
+	return nil
+}
+
+// Get retorna o indicador registrado com o nome informado
+func (r *Registry) Get(name string) (Indicator, bool) {
+	ind, ok := r.byName[name]
+	return ind, ok
+}
+
+// All retorna os indicadores registrados, na ordem de registro
+func (r *Registry) All() []Indicator {
+	indicators := make([]Indicator, len(r.indicators))
+	copy(indicators, r.indicators)
+	return indicators
+}
+
+// Names retorna os nomes dos indicadores registrados, em ordem alfabética
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.indicators))
+	for _, ind := range r.indicators {
+		names = append(names, ind.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Lookback retorna o maior histórico exigido entre os indicadores registrados
+func (r *Registry) Lookback() int {
+	lookback := 0
+	for _, ind := range r.indicators {
+		if ind.Lookback() > lookback {
+			lookback = ind.Lookback()
+		}
+	}
+	return lookback
+}
@@ -0,0 +1,85 @@
+// Package macd implementa o indicador MACD (Moving Average Convergence Divergence)
+package macd
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"mms_api/internal/domain/indicator"
+	"mms_api/internal/domain/indicator/ema"
+	"mms_api/internal/domain/model"
+)
+
+// Séries produzidas pelo indicador
+const (
+	SeriesMACD      = "macd"
+	SeriesSignal    = "signal"
+	SeriesHistogram = "histogram"
+)
+
+// Parâmetros clássicos do MACD
+const (
+	DefaultFast   = 12
+	DefaultSlow   = 26
+	DefaultSignal = 9
+)
+
+// MACD calcula a diferença entre as médias exponenciais rápida e lenta dos fechamentos,
+// a linha de sinal (média exponencial do MACD) e o histograma (MACD - sinal)
+type MACD struct {
+	fast   int
+	slow   int
+	signal int
+}
+
+// New cria o indicador MACD com as janelas rápida, lenta e de sinal informadas
+func New(fast, slow, signal int) *MACD {
+	return &MACD{fast: fast, slow: slow, signal: signal}
+}
+
+// Name retorna o nome do indicador (ex.: macd12_26_9)
+func (m *MACD) Name() string {
+	return fmt.Sprintf("macd%d_%d_%d", m.fast, m.slow, m.signal)
+}
+
+// Lookback retorna a quantidade de candles necessária para a primeira linha de sinal
+func (m *MACD) Lookback() int {
+	return m.slow + m.signal - 1
+}
+
+// Compute calcula MACD, sinal e histograma para cada candle com histórico suficiente
+func (m *MACD) Compute(candles []model.Candle) []model.IndicatorValue {
+	if m.fast < 1 || m.fast >= m.slow || m.signal < 1 || len(candles) < m.Lookback() {
+		return nil
+	}
+
+	closes := make([]float64, len(candles))
+	for i, c := range candles {
+		closes[i] = c.Close.InexactFloat64()
+	}
+
+	// Alinhar as duas médias a partir do primeiro candle com a média lenta disponível
+	fastEMA := ema.Series(closes, m.fast)[m.slow-m.fast:]
+	slowEMA := ema.Series(closes, m.slow)
+
+	line := make([]float64, len(slowEMA))
+	for i := range slowEMA {
+		line[i] = fastEMA[i] - slowEMA[i]
+	}
+
+	// A linha de sinal começa após 'signal' valores de MACD
+	signal := ema.Series(line, m.signal)
+
+	result := make([]model.IndicatorValue, 0, len(signal))
+	for i, sig := range signal {
+		value := line[m.signal-1+i]
+		candle := candles[m.Lookback()-1+i]
+		result = append(result, indicator.NewValue(m.Name(), candle, map[string]decimal.Decimal{
+			SeriesMACD:      indicator.FromFloat(value),
+			SeriesSignal:    indicator.FromFloat(sig),
+			SeriesHistogram: indicator.FromFloat(value - sig),
+		}))
+	}
+	return result
+}
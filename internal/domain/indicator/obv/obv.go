@@ -0,0 +1,64 @@
+// Package obv implementa o indicador On-Balance Volume (OBV)
+package obv
+
+import (
+	"github.com/shopspring/decimal"
+
+	"mms_api/internal/domain/indicator"
+	"mms_api/internal/domain/model"
+)
+
+// Name é o nome do indicador
+const Name = "obv"
+
+// OBV acumula o volume dos candles de alta e subtrai o dos candles de baixa.
+// Compute começa o acumulado em zero no primeiro candle recebido; ComputeFrom
+// continua uma série já persistida, de modo que o nível não depende do histórico
+// carregado em cada execução.
+type OBV struct{}
+
+// New cria o indicador OBV
+func New() *OBV {
+	return &OBV{}
+}
+
+// Name retorna o nome do indicador
+func (o *OBV) Name() string {
+	return Name
+}
+
+// Lookback retorna a quantidade de candles necessária para o primeiro valor
+func (o *OBV) Lookback() int {
+	return 1
+}
+
+// Compute calcula o volume acumulado para cada candle, a partir de zero
+func (o *OBV) Compute(candles []model.Candle) []model.IndicatorValue {
+	return o.accumulate(decimal.Zero, candles)
+}
+
+// ComputeFrom calcula o volume acumulado a partir do valor persistido no primeiro candle
+func (o *OBV) ComputeFrom(seed model.IndicatorValue, candles []model.Candle) []model.IndicatorValue {
+	return o.accumulate(seed.Values[indicator.SeriesValue].Decimal, candles)
+}
+
+// accumulate soma o volume de cada candle ao acumulado inicial, que é o valor do primeiro candle
+func (o *OBV) accumulate(obv decimal.Decimal, candles []model.Candle) []model.IndicatorValue {
+	result := make([]model.IndicatorValue, 0, len(candles))
+
+	for i, c := range candles {
+		if i > 0 {
+			switch prev := candles[i-1].Close; {
+			case c.Close.GreaterThan(prev):
+				obv = obv.Add(c.Volume)
+			case c.Close.LessThan(prev):
+				obv = obv.Sub(c.Volume)
+			}
+		}
+
+		result = append(result, indicator.NewValue(Name, c, map[string]decimal.Decimal{
+			indicator.SeriesValue: obv.Round(model.PriceScale),
+		}))
+	}
+	return result
+}
@@ -0,0 +1,87 @@
+// Package rsi implementa o Índice de Força Relativa (RSI) com suavização de Wilder
+package rsi
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"mms_api/internal/domain/indicator"
+	"mms_api/internal/domain/model"
+)
+
+// DefaultPeriod é a janela clássica do RSI
+const DefaultPeriod = 14
+
+// RSI calcula o Índice de Força Relativa. As médias de ganhos e perdas são semeadas
+// pela média simples das N primeiras variações e depois suavizadas por Wilder:
+// média = (média anterior * (N-1) + variação atual) / N
+type RSI struct {
+	period int
+}
+
+// New cria o indicador RSI para a janela informada
+func New(period int) *RSI {
+	return &RSI{period: period}
+}
+
+// Name retorna o nome do indicador (ex.: rsi14)
+func (r *RSI) Name() string {
+	return fmt.Sprintf("rsi%d", r.period)
+}
+
+// Lookback retorna a quantidade de candles necessária para o primeiro valor (N variações)
+func (r *RSI) Lookback() int {
+	return r.period + 1
+}
+
+// Compute calcula o RSI para cada candle com N variações anteriores disponíveis
+func (r *RSI) Compute(candles []model.Candle) []model.IndicatorValue {
+	if r.period < 1 || len(candles) < r.Lookback() {
+		return nil
+	}
+
+	var avgGain, avgLoss float64
+	for i := 1; i <= r.period; i++ {
+		gain, loss := change(candles[i-1], candles[i])
+		avgGain += gain
+		avgLoss += loss
+	}
+	avgGain /= float64(r.period)
+	avgLoss /= float64(r.period)
+
+	result := []model.IndicatorValue{r.value(candles[r.period], avgGain, avgLoss)}
+	for i := r.period + 1; i < len(candles); i++ {
+		gain, loss := change(candles[i-1], candles[i])
+		avgGain = (avgGain*float64(r.period-1) + gain) / float64(r.period)
+		avgLoss = (avgLoss*float64(r.period-1) + loss) / float64(r.period)
+		result = append(result, r.value(candles[i], avgGain, avgLoss))
+	}
+	return result
+}
+
+// value cria o valor do indicador a partir das médias de ganhos e perdas
+func (r *RSI) value(candle model.Candle, avgGain, avgLoss float64) model.IndicatorValue {
+	var rsi float64
+	switch {
+	case avgGain == 0 && avgLoss == 0:
+		rsi = 50 // Preço estável: sem força compradora nem vendedora
+	case avgLoss == 0:
+		rsi = 100
+	default:
+		rsi = 100 - 100/(1+avgGain/avgLoss)
+	}
+
+	return indicator.NewValue(r.Name(), candle, map[string]decimal.Decimal{
+		indicator.SeriesValue: indicator.FromFloat(rsi),
+	})
+}
+
+// change retorna o ganho e a perda (ambos positivos) entre dois fechamentos consecutivos
+func change(prev, cur model.Candle) (gain, loss float64) {
+	diff := cur.Close.Sub(prev.Close).InexactFloat64()
+	if diff > 0 {
+		return diff, 0
+	}
+	return 0, -diff
+}
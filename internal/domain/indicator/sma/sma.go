@@ -0,0 +1,87 @@
+// Package sma implementa a média móvel simples (MMS)
+package sma
+
+import (
+	"fmt"
+
+	"mms_api/internal/domain/indicator"
+	"mms_api/internal/domain/indicator/window"
+	"mms_api/internal/domain/model"
+)
+
+// SMA calcula a média aritmética dos fechamentos de uma janela de candles
+type SMA struct {
+	period int
+}
+
+// New cria uma média móvel simples para a janela informada
+func New(period int) *SMA {
+	return &SMA{period: period}
+}
+
+// Name retorna o nome do indicador (ex.: sma20)
+func (s *SMA) Name() string {
+	return fmt.Sprintf("sma%d", s.period)
+}
+
+// Lookback retorna a quantidade de candles necessária para o primeiro valor
+func (s *SMA) Lookback() int {
+	return s.period
+}
+
+// Period retorna a janela da média
+func (s *SMA) Period() int {
+	return s.period
+}
+
+// AverageType identifica a média como simples
+func (s *SMA) AverageType() model.AverageType {
+	return model.AverageSimple
+}
+
+// Compute calcula a média de cada janela completa de candles
+func (s *SMA) Compute(candles []model.Candle) []model.IndicatorValue {
+	return indicator.AverageValues(s.Name(), candles, s.Averages(candles))
+}
+
+// Averages calcula a média decimal de cada janela completa de candles em uma única passada,
+// mantendo a soma exata da janela
+func (s *SMA) Averages(candles []model.Candle) []indicator.Average {
+	return AveragesByPeriod(candles, s.period)[s.period]
+}
+
+// AveragesByPeriod calcula as médias simples de várias janelas em uma única passada pelos candles:
+// cada fechamento alimenta todas as janelas do window.Calculator, que mantêm as próprias somas
+// correntes. Janelas não positivas não produzem médias.
+func AveragesByPeriod(candles []model.Candle, periods ...int) map[int][]indicator.Average {
+	valid := make([]int, 0, len(periods))
+	for _, period := range periods {
+		if period >= 1 {
+			valid = append(valid, period)
+		}
+	}
+
+	calc, _ := window.NewCalculator(valid...)
+	periods = calc.Periods()
+	series := make([][]indicator.Average, len(periods))
+	for i, period := range periods {
+		if len(candles) >= period {
+			series[i] = make([]indicator.Average, 0, len(candles)-period+1)
+		}
+	}
+
+	for _, candle := range candles {
+		calc.Push(candle.Close)
+		for i, period := range periods {
+			if mean, ok := calc.Mean(period, model.PriceScale); ok {
+				series[i] = append(series[i], indicator.Average{Timestamp: candle.Timestamp, Value: mean})
+			}
+		}
+	}
+
+	result := make(map[int][]indicator.Average, len(periods))
+	for i, period := range periods {
+		result[period] = series[i]
+	}
+	return result
+}
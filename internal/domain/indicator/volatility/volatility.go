@@ -0,0 +1,96 @@
+// Package volatility implementa a volatilidade histórica a partir dos retornos logarítmicos
+package volatility
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/shopspring/decimal"
+
+	"mms_api/internal/domain/indicator"
+	"mms_api/internal/domain/model"
+)
+
+// Séries produzidas pelo indicador
+const (
+	SeriesPeriod     = "period"     // Desvio padrão dos retornos por candle
+	SeriesAnnualized = "annualized" // Desvio padrão anualizado
+)
+
+// Parâmetros padrão
+const (
+	DefaultPeriod = 30
+	// Criptomoedas negociam todos os dias, então um ano tem 365 candles diários
+	DefaultPeriodsPerYear = 365
+)
+
+// Volatility calcula o desvio padrão amostral dos retornos logarítmicos dos fechamentos
+// em uma janela de N retornos, anualizado por sqrt(candles por ano)
+type Volatility struct {
+	period         int
+	periodsPerYear float64
+}
+
+// New cria o indicador de volatilidade histórica para a janela e a quantidade
+// de candles por ano informadas
+func New(period int, periodsPerYear float64) *Volatility {
+	return &Volatility{period: period, periodsPerYear: periodsPerYear}
+}
+
+// Name retorna o nome do indicador (ex.: volatility30)
+func (v *Volatility) Name() string {
+	return fmt.Sprintf("volatility%d", v.period)
+}
+
+// Lookback retorna a quantidade de candles necessária para o primeiro valor (N retornos)
+func (v *Volatility) Lookback() int {
+	return v.period + 1
+}
+
+// Compute calcula a volatilidade para cada janela completa de retornos. Janelas com
+// fechamentos não positivos não têm retorno logarítmico definido e são omitidas.
+func (v *Volatility) Compute(candles []model.Candle) []model.IndicatorValue {
+	if v.period < 2 || len(candles) < v.Lookback() {
+		return nil
+	}
+
+	returns := make([]float64, len(candles))
+	valid := make([]bool, len(candles))
+	for i := 1; i < len(candles); i++ {
+		if prev, cur := candles[i-1].Close, candles[i].Close; prev.IsPositive() && cur.IsPositive() {
+			returns[i] = math.Log(cur.InexactFloat64() / prev.InexactFloat64())
+			valid[i] = true
+		}
+	}
+
+	var result []model.IndicatorValue
+	for i := v.period; i < len(candles); i++ {
+		window := returns[i-v.period+1 : i+1]
+
+		ok := true
+		var sum float64
+		for j, r := range window {
+			if !valid[i-v.period+1+j] {
+				ok = false
+				break
+			}
+			sum += r
+		}
+		if !ok {
+			continue
+		}
+
+		mean := sum / float64(v.period)
+		var variance float64
+		for _, r := range window {
+			variance += (r - mean) * (r - mean)
+		}
+		stddev := math.Sqrt(variance / float64(v.period-1))
+
+		result = append(result, indicator.NewValue(v.Name(), candles[i], map[string]decimal.Decimal{
+			SeriesPeriod:     indicator.FromFloat(stddev),
+			SeriesAnnualized: indicator.FromFloat(stddev * math.Sqrt(v.periodsPerYear)),
+		}))
+	}
+	return result
+}
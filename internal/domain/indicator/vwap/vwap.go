@@ -0,0 +1,67 @@
+// Package vwap implementa o preço médio ponderado por volume (VWAP) em janela móvel
+package vwap
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"mms_api/internal/domain/indicator"
+	"mms_api/internal/domain/model"
+)
+
+// Séries produzidas pelo indicador
+const (
+	SeriesVWAP = "vwap" // Preço típico ponderado por volume
+	SeriesVWMA = "vwma" // Fechamento ponderado por volume
+)
+
+// DefaultPeriod é a janela padrão do VWAP móvel
+const DefaultPeriod = 20
+
+// VWAP calcula, para uma janela de candles, o preço típico ((High + Low + Close) / 3)
+// e o fechamento ponderados pelo volume negociado
+type VWAP struct {
+	period int
+}
+
+// New cria o indicador VWAP para a janela informada
+func New(period int) *VWAP {
+	return &VWAP{period: period}
+}
+
+// Name retorna o nome do indicador (ex.: vwap20)
+func (v *VWAP) Name() string {
+	return fmt.Sprintf("vwap%d", v.period)
+}
+
+// Lookback retorna a quantidade de candles necessária para o primeiro valor
+func (v *VWAP) Lookback() int {
+	return v.period
+}
+
+// Compute calcula as médias ponderadas de cada janela completa. Janelas sem volume
+// não têm média ponderada definida e são omitidas.
+func (v *VWAP) Compute(candles []model.Candle) []model.IndicatorValue {
+	var result []model.IndicatorValue
+	for i := v.period - 1; i < len(candles); i++ {
+		var volume, typicalVolume, closeVolume float64
+		for _, candle := range candles[i-v.period+1 : i+1] {
+			c := indicator.FloatPrices(candle)
+			typical := (c.High + c.Low + c.Close) / 3
+			volume += c.Volume
+			typicalVolume += typical * c.Volume
+			closeVolume += c.Close * c.Volume
+		}
+
+		if volume == 0 {
+			continue
+		}
+
+		result = append(result, indicator.NewValue(v.Name(), candles[i], map[string]decimal.Decimal{
+			SeriesVWAP: indicator.FromFloat(typicalVolume / volume),
+			SeriesVWMA: indicator.FromFloat(closeVolume / volume),
+		}))
+	}
+	return result
+}
@@ -0,0 +1,108 @@
+// Package window implementa o cálculo incremental de médias decimais em janelas deslizantes,
+// alimentado um valor por vez com custo constante por valor
+package window
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// DecimalWindow mantém os últimos N valores decimais e a soma corrente da janela. Somas e
+// subtrações decimais são exatas, então a soma não acumula erro e não precisa ser refeita.
+type DecimalWindow struct {
+	period int
+	values []decimal.Decimal
+	next   int
+	count  int
+	sum    decimal.Decimal
+}
+
+// NewDecimal cria uma janela deslizante decimal de N valores
+func NewDecimal(period int) *DecimalWindow {
+	if period < 1 {
+		period = 1
+	}
+	return &DecimalWindow{period: period, values: make([]decimal.Decimal, period)}
+}
+
+// Push adiciona um valor à janela, descartando o mais antigo quando ela está cheia
+func (w *DecimalWindow) Push(value decimal.Decimal) {
+	if w.count == w.period {
+		w.sum = w.sum.Sub(w.values[w.next])
+	} else {
+		w.count++
+	}
+	w.values[w.next] = value
+	w.sum = w.sum.Add(value)
+	w.next = (w.next + 1) % w.period
+}
+
+// Full indica se a janela já recebeu N valores
+func (w *DecimalWindow) Full() bool {
+	return w.count == w.period
+}
+
+// Sum retorna a soma exata dos valores atualmente na janela
+func (w *DecimalWindow) Sum() decimal.Decimal {
+	return w.sum
+}
+
+// Mean retorna a média dos valores da janela arredondada a scale casas decimais,
+// disponível apenas quando ela está cheia
+func (w *DecimalWindow) Mean(scale int32) (decimal.Decimal, bool) {
+	if !w.Full() {
+		return decimal.Zero, false
+	}
+	return w.sum.DivRound(decimal.NewFromInt(int64(w.period)), scale), true
+}
+
+// Calculator alimenta várias janelas decimais com a mesma série em uma única passada, de modo
+// que cada valor é lido uma vez independentemente da quantidade de janelas
+type Calculator struct {
+	windows  []*DecimalWindow
+	byPeriod map[int]*DecimalWindow
+}
+
+// NewCalculator cria uma calculadora com uma janela por período informado; períodos repetidos são ignorados
+func NewCalculator(periods ...int) (*Calculator, error) {
+	c := &Calculator{byPeriod: make(map[int]*DecimalWindow, len(periods))}
+	for _, period := range periods {
+		if period < 1 {
+			return nil, fmt.Errorf("janela inválida: %d", period)
+		}
+		if _, exists := c.byPeriod[period]; exists {
+			continue
+		}
+		w := NewDecimal(period)
+		c.windows = append(c.windows, w)
+		c.byPeriod[period] = w
+	}
+	return c, nil
+}
+
+// Periods retorna os períodos das janelas, na ordem em que foram informados
+func (c *Calculator) Periods() []int {
+	periods := make([]int, len(c.windows))
+	for i, w := range c.windows {
+		periods[i] = w.period
+	}
+	return periods
+}
+
+// Push adiciona um valor (ex.: o fechamento de um candle) a todas as janelas
+func (c *Calculator) Push(value decimal.Decimal) {
+	for _, w := range c.windows {
+		w.Push(value)
+	}
+}
+
+// Mean retorna a média atual da janela do período informado arredondada a scale casas decimais,
+// se ela já estiver cheia
+func (c *Calculator) Mean(period int, scale int32) (decimal.Decimal, bool) {
+	w, ok := c.byPeriod[period]
+	if !ok {
+		return decimal.Zero, false
+	}
+	return w.Mean(scale)
+}
@@ -1,14 +1,24 @@
 package model
 
-import "time"
+import (
+	"time"
 
-// Candle representa um candle de mercado em um determinado período
+	"github.com/shopspring/decimal"
+)
+
+// PriceScale é a quantidade de casas decimais de preços e médias, a mesma das colunas DECIMAL(20, 8)
+const PriceScale = 8
+
+// Candle representa um candle de mercado em um determinado período. Preços e volume são decimais
+// exatos, preservando os valores recebidos do provedor.
 type Candle struct {
-	Pair      string    // Par de moedas (BRLBTC, BRLETH)
-	Timestamp time.Time // Data/hora do candle
-	Open      float64   // Preço de abertura
-	High      float64   // Preço máximo
-	Low       float64   // Preço mínimo
-	Close     float64   // Preço de fechamento
-	Volume    float64   // Volume negociado
+	Pair       string          // Par de moedas (BRLBTC, BRLETH)
+	Resolution Resolution      // Duração do candle (1h, 4h, 1d, 1w)
+	Timestamp  time.Time       // Data/hora de início do candle
+	Open       decimal.Decimal // Preço de abertura
+	High       decimal.Decimal // Preço máximo
+	Low        decimal.Decimal // Preço mínimo
+	Close      decimal.Decimal // Preço de fechamento
+	Volume     decimal.Decimal // Volume negociado
+	Source     string          // Provedor que forneceu o candle (ex.: mercadobitcoin); vazio quando desconhecido
 }
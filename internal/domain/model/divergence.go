@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// DivergenceField identifica o valor que divergiu entre dois provedores de candles
+type DivergenceField string
+
+// Campos comparados na reconciliação entre provedores
+const (
+	DivergenceClose   DivergenceField = "close"   // Preço de fechamento
+	DivergenceVolume  DivergenceField = "volume"  // Volume negociado
+	DivergenceMissing DivergenceField = "missing" // Candle presente em apenas um dos provedores
+)
+
+// Divergence é uma diferença acima da tolerância entre os candles de dois provedores para o mesmo
+// par, resolução e timestamp. Nos candles ausentes em um dos provedores, o valor desse lado é nulo.
+type Divergence struct {
+	Pair           string              // Par de moedas (BRLBTC, BRLETH)
+	Resolution     Resolution          // Resolução dos candles
+	Timestamp      time.Time           // Candle comparado
+	Field          DivergenceField     // Valor que divergiu
+	Primary        string              // Provedor principal (ex.: mercadobitcoin)
+	Secondary      string              // Provedor comparado (ex.: binance)
+	PrimaryValue   decimal.NullDecimal // Valor no provedor principal
+	SecondaryValue decimal.NullDecimal // Valor no provedor comparado
+	Difference     decimal.Decimal     // Diferença relativa ao valor principal, em percentual (zero nos candles ausentes)
+}
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// IndicatorValue representa o valor de um indicador técnico para um par em um timestamp específico
+type IndicatorValue struct {
+	Pair       string                         // Par de moedas (BRLBTC, BRLETH)
+	Resolution Resolution                     // Resolução dos candles usados no cálculo
+	Indicator  string                         // Nome do indicador (ex.: sma20, rsi14)
+	Timestamp  time.Time                      // Data do candle que originou o valor
+	Values     map[string]decimal.NullDecimal // Séries do indicador (ex.: value, upper, lower), com PriceScale casas; nulas enquanto o histórico não cobre a janela
+}
+
+// BollingerBand representa as Bandas de Bollinger de um par em um timestamp específico
+type BollingerBand struct {
+	Pair       string          // Par de moedas (BRLBTC, BRLETH)
+	Resolution Resolution      // Resolução dos candles usados no cálculo
+	Timestamp  time.Time       // Data do candle
+	Middle     decimal.Decimal // Média móvel simples da janela
+	Upper      decimal.Decimal // Média + k desvios padrão
+	Lower      decimal.Decimal // Média - k desvios padrão
+	Width      decimal.Decimal // Largura relativa das bandas: (Upper - Lower) / Middle
+}
@@ -1,29 +1,97 @@
 package model
 
-import "time"
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
-// MMS representa uma média móvel simples para um par em um timestamp específico
+	"github.com/shopspring/decimal"
+)
+
+// MMS representa uma média móvel de um par, para um tipo e uma janela, em um timestamp específico
 type MMS struct {
-	Pair      string    // Par de moedas (BRLBTC, BRLETH)
-	Timestamp time.Time // Data da MMS
-	MMS20     float64   // Média móvel simples de 20 dias
-	MMS50     float64   // Média móvel simples de 50 dias
-	MMS200    float64   // Média móvel simples de 200 dias
+	Pair       string              // Par de moedas (BRLBTC, BRLETH)
+	Resolution Resolution          // Resolução dos candles da média (1h, 4h, 1d, 1w)
+	Timestamp  time.Time           // Data da MMS
+	Type       AverageType         // Tipo da média móvel (sma, ema)
+	Period     int                 // Janela da média móvel, em candles (ex.: 20, 50, 200)
+	Value      decimal.NullDecimal // Valor da média móvel, com PriceScale casas decimais; nulo enquanto o histórico não cobre a janela
 }
 
-// Período válido para cálculo de MMS
+// AverageType identifica o tipo de média móvel
+type AverageType string
+
+// Tipos de média móvel suportados
+const (
+	AverageSimple      AverageType = "sma" // Média móvel simples
+	AverageExponential AverageType = "ema" // Média móvel exponencial
+)
+
+// Validar se o tipo de média móvel é suportado
+func IsValidAverageType(avgType AverageType) bool {
+	return avgType == AverageSimple || avgType == AverageExponential
+}
+
+// Períodos padrão para cálculo de MMS
 const (
 	Period20  = 20
 	Period50  = 50
 	Period200 = 200
 )
 
+// Limites aceitos para a janela de uma média móvel
+const (
+	MinPeriod = 2
+	MaxPeriod = 1000
+)
+
+// DefaultPeriods são as janelas calculadas quando nenhuma outra é configurada
+var DefaultPeriods = []int{Period20, Period50, Period200}
+
 // Validar se o período solicitado é válido
 func IsValidPeriod(period int) bool {
-	return period == Period20 || period == Period50 || period == Period200
+	return period >= MinPeriod && period <= MaxPeriod
+}
+
+// NormalizePeriods valida as janelas informadas e as retorna ordenadas e sem duplicatas
+func NormalizePeriods(periods []int) ([]int, error) {
+	if len(periods) == 0 {
+		return nil, fmt.Errorf("nenhum período informado")
+	}
+
+	seen := make(map[int]bool, len(periods))
+	result := make([]int, 0, len(periods))
+	for _, period := range periods {
+		if !IsValidPeriod(period) {
+			return nil, fmt.Errorf("período inválido: %d (use valores entre %d e %d)", period, MinPeriod, MaxPeriod)
+		}
+		if seen[period] {
+			continue
+		}
+		seen[period] = true
+		result = append(result, period)
+	}
+
+	sort.Ints(result)
+	return result, nil
 }
 
-// Validar se o par de moedas é válido
-func IsValidPair(pair string) bool {
-	return pair == "BRLBTC" || pair == "BRLETH"
+// ParsePeriods converte uma lista separada por vírgulas (ex.: "7,9,21") em janelas normalizadas
+func ParsePeriods(value string) ([]int, error) {
+	var periods []int
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		period, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("período inválido: %q", part)
+		}
+		periods = append(periods, period)
+	}
+
+	return NormalizePeriods(periods)
 }
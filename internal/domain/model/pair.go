@@ -0,0 +1,144 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Pair representa um par de moedas: Base é o ativo negociado e Quote a moeda de cotação
+type Pair struct {
+	Base  string // Ativo negociado (ex.: BTC)
+	Quote string // Moeda de cotação (ex.: BRL, USDT)
+}
+
+// String retorna o símbolo canônico do par, cotação seguida da base (ex.: BRLBTC, USDTMATIC)
+func (p Pair) String() string {
+	return p.Quote + p.Base
+}
+
+// RegisteredPair representa um par de moedas cadastrado no registro de pares
+type RegisteredPair struct {
+	Symbol    string    // Símbolo canônico do par (ex.: BRLBTC)
+	Pair      Pair      // Base e cotação do par
+	Enabled   bool      // Indica se o par é processado pelo worker e exposto pela API
+	CreatedAt time.Time // Data de cadastro
+	UpdatedAt time.Time // Data da última alteração
+}
+
+// DefaultPairs são os pares aceitos quando nenhum registro de pares é configurado
+var DefaultPairs = []string{"BRLBTC", "BRLETH"}
+
+// KnownQuotes são as moedas de cotação reconhecidas em símbolos compactos (ex.: BRLBTC, USDTBTC).
+// Quando mais de uma é prefixo do símbolo, vale a mais longa (USDT antes de USD).
+var KnownQuotes = []string{"BRL", "USDT", "USDC", "USD", "BTC", "ETH"}
+
+// MaxPairSymbolLength é a largura da coluna pair nas tabelas
+const MaxPairSymbolLength = 10
+
+// assetPattern aceita códigos de ativo em maiúsculas
+var assetPattern = regexp.MustCompile(`^[A-Z0-9]{2,10}$`)
+
+// NewPair cria um par a partir da base e da cotação, validando os códigos
+func NewPair(base, quote string) (Pair, error) {
+	p := Pair{
+		Base:  strings.ToUpper(strings.TrimSpace(base)),
+		Quote: strings.ToUpper(strings.TrimSpace(quote)),
+	}
+
+	if !assetPattern.MatchString(p.Base) || !assetPattern.MatchString(p.Quote) {
+		return Pair{}, fmt.Errorf("par inválido: %s/%s", base, quote)
+	}
+	if p.Base == p.Quote {
+		return Pair{}, fmt.Errorf("par inválido: base e cotação iguais (%s)", p.Base)
+	}
+	if len(p.String()) > MaxPairSymbolLength {
+		return Pair{}, fmt.Errorf("par inválido: símbolo %s excede %d caracteres", p.String(), MaxPairSymbolLength)
+	}
+	return p, nil
+}
+
+// ParsePair interpreta um par no formato canônico compacto (BRLBTC, cotação seguida da base)
+// ou no formato com separador usado pelas corretoras (BTC-BRL, BTC/BRL, BTC_BRL, base seguida da cotação)
+func ParsePair(value string) (Pair, error) {
+	symbol := strings.ToUpper(strings.TrimSpace(value))
+
+	if i := strings.IndexAny(symbol, "-/_"); i >= 0 {
+		return NewPair(symbol[:i], symbol[i+1:])
+	}
+
+	quote := ""
+	for _, q := range KnownQuotes {
+		if strings.HasPrefix(symbol, q) && len(q) > len(quote) {
+			quote = q
+		}
+	}
+	if quote == "" {
+		return Pair{}, fmt.Errorf("par inválido: %q não começa com uma moeda de cotação conhecida (%s)", value, strings.Join(KnownQuotes, ", "))
+	}
+
+	return NewPair(symbol[len(quote):], quote)
+}
+
+// NormalizePairSymbol converte um par em qualquer formato aceito por ParsePair para o símbolo canônico.
+// Valores inválidos são apenas convertidos para maiúsculas.
+func NormalizePairSymbol(value string) string {
+	p, err := ParsePair(value)
+	if err != nil {
+		return strings.ToUpper(strings.TrimSpace(value))
+	}
+	return p.String()
+}
+
+// Validar se o símbolo está no formato canônico (ex.: BRLBTC)
+func IsValidPairSymbol(symbol string) bool {
+	p, err := ParsePair(symbol)
+	return err == nil && p.String() == symbol
+}
+
+// Validar se o par faz parte dos pares padrão
+func IsDefaultPair(symbol string) bool {
+	for _, p := range DefaultPairs {
+		if p == symbol {
+			return true
+		}
+	}
+	return false
+}
+
+// SymbolMap traduz pares para os símbolos usados por um provedor de dados
+type SymbolMap struct {
+	Separator string            // Separador entre os ativos (ex.: "-" em BTC-BRL)
+	BaseFirst bool              // Base antes da cotação (BTC-BRL) ou cotação antes da base (BRLBTC)
+	Overrides map[string]string // Símbolos específicos por par canônico (ex.: BRLMATIC -> POL-BRL)
+}
+
+// Symbol retorna o símbolo do par no formato do provedor
+func (m SymbolMap) Symbol(p Pair) string {
+	if symbol, ok := m.Overrides[p.String()]; ok {
+		return symbol
+	}
+	if m.BaseFirst {
+		return p.Base + m.Separator + p.Quote
+	}
+	return p.Quote + m.Separator + p.Base
+}
+
+// WithOverrides retorna uma cópia do mapeamento com símbolos específicos adicionais.
+// As chaves podem estar em qualquer formato aceito por ParsePair.
+func (m SymbolMap) WithOverrides(overrides map[string]string) (SymbolMap, error) {
+	merged := make(map[string]string, len(m.Overrides)+len(overrides))
+	for k, v := range m.Overrides {
+		merged[k] = v
+	}
+	for k, v := range overrides {
+		p, err := ParsePair(k)
+		if err != nil {
+			return m, err
+		}
+		merged[p.String()] = v
+	}
+	m.Overrides = merged
+	return m, nil
+}
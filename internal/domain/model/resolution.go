@@ -0,0 +1,150 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Resolution identifica a duração de cada candle
+type Resolution string
+
+// Resoluções suportadas
+const (
+	Resolution1h Resolution = "1h" // Candles de uma hora
+	Resolution4h Resolution = "4h" // Candles de quatro horas
+	Resolution1d Resolution = "1d" // Candles diários
+	Resolution1w Resolution = "1w" // Candles semanais, iniciados na segunda-feira
+)
+
+// DefaultResolution é a resolução usada quando nenhuma outra é informada
+const DefaultResolution = Resolution1d
+
+// Resolutions lista as resoluções suportadas, da menor para a maior
+var Resolutions = []Resolution{Resolution1h, Resolution4h, Resolution1d, Resolution1w}
+
+// Validar se a resolução é suportada
+func IsValidResolution(r Resolution) bool {
+	for _, res := range Resolutions {
+		if res == r {
+			return true
+		}
+	}
+	return false
+}
+
+// Duration retorna a duração de um candle da resolução
+func (r Resolution) Duration() time.Duration {
+	switch r {
+	case Resolution1h:
+		return time.Hour
+	case Resolution4h:
+		return 4 * time.Hour
+	case Resolution1w:
+		return 7 * 24 * time.Hour
+	default:
+		return 24 * time.Hour
+	}
+}
+
+// PeriodsPerYear retorna a quantidade de candles da resolução em um ano, usada para anualizar indicadores
+func (r Resolution) PeriodsPerYear() float64 {
+	return float64(365*24*time.Hour) / float64(r.Duration())
+}
+
+// Truncate retorna o início do candle da resolução que contém t, em UTC
+func (r Resolution) Truncate(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch r {
+	case Resolution1h, Resolution4h:
+		hours := int(r.Duration() / time.Hour)
+		return day.Add(time.Duration(t.Hour()/hours*hours) * time.Hour)
+	case Resolution1w:
+		// Semanas começam na segunda-feira
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	default:
+		return day
+	}
+}
+
+// Next retorna o início do candle seguinte ao candle iniciado em t
+func (r Resolution) Next(t time.Time) time.Time {
+	return t.Add(r.Duration())
+}
+
+// NormalizeResolutions valida as resoluções informadas e as retorna ordenadas e sem duplicatas
+func NormalizeResolutions(resolutions []Resolution) ([]Resolution, error) {
+	if len(resolutions) == 0 {
+		return nil, fmt.Errorf("nenhuma resolução informada")
+	}
+
+	seen := make(map[Resolution]bool, len(resolutions))
+	for _, r := range resolutions {
+		if !IsValidResolution(r) {
+			return nil, fmt.Errorf("resolução inválida: %q (use %s)", r, FormatResolutions(Resolutions))
+		}
+		seen[r] = true
+	}
+
+	result := make([]Resolution, 0, len(seen))
+	for _, r := range Resolutions {
+		if seen[r] {
+			result = append(result, r)
+		}
+	}
+	return result, nil
+}
+
+// ParseResolutions converte uma lista separada por vírgulas (ex.: "1h,1d") em resoluções normalizadas
+func ParseResolutions(value string) ([]Resolution, error) {
+	var resolutions []Resolution
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		resolutions = append(resolutions, Resolution(strings.ToLower(part)))
+	}
+
+	return NormalizeResolutions(resolutions)
+}
+
+// FormatResolutions formata resoluções para mensagens (ex.: "1h, 4h, 1d, 1w")
+func FormatResolutions(resolutions []Resolution) string {
+	parts := make([]string, len(resolutions))
+	for i, r := range resolutions {
+		parts[i] = string(r)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// AggregateCandles agrupa candles ordenados de uma resolução menor em candles da resolução informada.
+// Cada grupo recebe a abertura do primeiro candle, o fechamento do último, a máxima e a mínima do
+// grupo e a soma dos volumes.
+func AggregateCandles(candles []Candle, resolution Resolution) []Candle {
+	var result []Candle
+	for _, c := range candles {
+		start := resolution.Truncate(c.Timestamp)
+
+		if n := len(result); n > 0 && result[n-1].Timestamp.Equal(start) {
+			last := &result[n-1]
+			if c.High.GreaterThan(last.High) {
+				last.High = c.High
+			}
+			if c.Low.LessThan(last.Low) {
+				last.Low = c.Low
+			}
+			last.Close = c.Close
+			last.Volume = last.Volume.Add(c.Volume)
+			continue
+		}
+
+		c.Timestamp = start
+		c.Resolution = resolution
+		result = append(result, c)
+	}
+	return result
+}
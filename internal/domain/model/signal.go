@@ -0,0 +1,75 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// SignalType identifica o tipo de evento de cruzamento
+type SignalType string
+
+// Tipos de sinal detectados
+const (
+	SignalGoldenCross     SignalType = "golden_cross"      // Média mais curta cruza a mais longa para cima
+	SignalDeathCross      SignalType = "death_cross"       // Média mais curta cruza a mais longa para baixo
+	SignalPriceCrossAbove SignalType = "price_cross_above" // Fechamento cruza a média para cima
+	SignalPriceCrossBelow SignalType = "price_cross_below" // Fechamento cruza a média para baixo
+)
+
+// SignalTypes lista os tipos de sinal suportados
+var SignalTypes = []SignalType{SignalGoldenCross, SignalDeathCross, SignalPriceCrossAbove, SignalPriceCrossBelow}
+
+// Signal representa um cruzamento detectado entre duas séries de um par em um candle.
+// Nos cruzamentos de preço, FastPeriod é 0 e FastValue é o fechamento do candle.
+type Signal struct {
+	Pair        string          // Par de moedas (BRLBTC, BRLETH)
+	Resolution  Resolution      // Resolução dos candles
+	Timestamp   time.Time       // Candle em que o cruzamento ocorreu
+	Type        SignalType      // Tipo do cruzamento
+	AverageType AverageType     // Tipo das médias comparadas (sma, ema)
+	FastPeriod  int             // Janela da série rápida (0 para o preço de fechamento)
+	SlowPeriod  int             // Janela da média lenta
+	FastValue   decimal.Decimal // Valor da série rápida no candle
+	SlowValue   decimal.Decimal // Valor da média lenta no candle
+}
+
+// IsValidSignalType verifica se o tipo de sinal é suportado
+func IsValidSignalType(signalType SignalType) bool {
+	for _, t := range SignalTypes {
+		if t == signalType {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseSignalTypes converte uma lista separada por vírgulas (ex.: "golden_cross,death_cross")
+// em tipos de sinal sem duplicatas. Uma lista vazia resulta em nenhum filtro.
+func ParseSignalTypes(value string) ([]SignalType, error) {
+	var types []SignalType
+	seen := make(map[SignalType]bool)
+	for _, part := range strings.Split(value, ",") {
+		signalType := SignalType(strings.ToLower(strings.TrimSpace(part)))
+		if signalType == "" || seen[signalType] {
+			continue
+		}
+		if !IsValidSignalType(signalType) {
+			return nil, fmt.Errorf("tipo de sinal inválido: %q", part)
+		}
+		seen[signalType] = true
+		types = append(types, signalType)
+	}
+	return types, nil
+}
+
+// FormatSignalTypes formata tipos de sinal para mensagens (ex.: "golden_cross, death_cross")
+func FormatSignalTypes(types []SignalType) string {
+	parts := make([]string, len(types))
+	for i, t := range types {
+		parts[i] = string(t)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,81 @@
+package model
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// TimeRange representa um intervalo de candles, do candle iniciado em From até o iniciado em To (inclusive)
+type TimeRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// Contains verifica se o timestamp está dentro do intervalo
+func (r TimeRange) Contains(t time.Time) bool {
+	return !t.Before(r.From) && !t.After(r.To)
+}
+
+// SplitRange divide o intervalo entre from e to em janelas consecutivas de até max candles da
+// resolução, em ordem cronológica, para provedores que limitam os candles por requisição
+func (r Resolution) SplitRange(from, to time.Time, max int) []TimeRange {
+	span := time.Duration(max) * r.Duration()
+
+	var windows []TimeRange
+	for start := from; !start.After(to); start = start.Add(span) {
+		end := start.Add(span - time.Second)
+		if end.After(to) {
+			end = to
+		}
+		windows = append(windows, TimeRange{From: start, To: end})
+	}
+	return windows
+}
+
+// ContiguousRanges agrupa timestamps de candles da resolução em intervalos contíguos, em ordem crescente.
+// Timestamps repetidos são ignorados e candles consecutivos (a uma duração de distância) formam um único intervalo.
+func (r Resolution) ContiguousRanges(timestamps []time.Time) []TimeRange {
+	if len(timestamps) == 0 {
+		return nil
+	}
+
+	sorted := make([]time.Time, len(timestamps))
+	copy(sorted, timestamps)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Before(sorted[j])
+	})
+
+	ranges := []TimeRange{{From: sorted[0], To: sorted[0]}}
+	for _, ts := range sorted[1:] {
+		current := &ranges[len(ranges)-1]
+		switch {
+		case !ts.After(current.To):
+			continue
+		case ts.Equal(r.Next(current.To)):
+			current.To = ts
+		default:
+			ranges = append(ranges, TimeRange{From: ts, To: ts})
+		}
+	}
+	return ranges
+}
+
+// FormatTimeRanges formata intervalos para mensagens (ex.: "2025-01-03 a 2025-01-05, 2025-02-01"),
+// incluindo a hora nas resoluções intradiárias
+func (r Resolution) FormatTimeRanges(ranges []TimeRange) string {
+	layout := "2006-01-02"
+	if r.Duration() < 24*time.Hour {
+		layout = "2006-01-02 15:04"
+	}
+
+	parts := make([]string, len(ranges))
+	for i, tr := range ranges {
+		if tr.From.Equal(tr.To) {
+			parts[i] = tr.From.UTC().Format(layout)
+			continue
+		}
+		parts[i] = tr.From.UTC().Format(layout) + " a " + tr.To.UTC().Format(layout)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,231 @@
+package quality
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"mms_api/internal/domain/model"
+)
+
+// Policy define o tratamento dos candles com problemas de qualidade
+type Policy string
+
+const (
+	// PolicyReject recusa a série inteira quando qualquer problema é encontrado
+	PolicyReject Policy = "reject"
+	// PolicyDrop ordena a série, remove duplicatas e descarta os candles inválidos, mantendo as lacunas
+	PolicyDrop Policy = "drop"
+	// PolicyForwardFill trata a série como PolicyDrop e preenche as lacunas internas com o fechamento anterior
+	PolicyForwardFill Policy = "forward_fill"
+)
+
+// DefaultPolicy é a política usada quando nenhuma é configurada
+const DefaultPolicy = PolicyDrop
+
+// Policies lista as políticas suportadas
+var Policies = []Policy{PolicyReject, PolicyDrop, PolicyForwardFill}
+
+// ParsePolicy converte o nome de uma política (ex.: "forward_fill") em Policy
+func ParsePolicy(value string) (Policy, error) {
+	policy := Policy(strings.ToLower(strings.TrimSpace(value)))
+	for _, p := range Policies {
+		if p == policy {
+			return policy, nil
+		}
+	}
+	return "", fmt.Errorf("política de validação inválida: %q (use reject, drop ou forward_fill)", value)
+}
+
+// IssueType identifica um problema de qualidade em um candle
+type IssueType string
+
+const (
+	IssueOutOfOrder   IssueType = "out_of_order"   // Candle anterior ao candle recebido antes dele
+	IssueDuplicate    IssueType = "duplicate"      // Mais de um candle com o mesmo timestamp
+	IssueMissing      IssueType = "missing"        // Candle ausente entre dois candles da série
+	IssueNonPositive  IssueType = "non_positive"   // Preço menor ou igual a zero, ou volume negativo
+	IssueHighBelowLow IssueType = "high_below_low" // Máxima abaixo da mínima
+)
+
+// issueTypes define a ordem e a descrição dos problemas nos relatórios
+var issueTypes = []struct {
+	issue IssueType
+	label string
+}{
+	{IssueOutOfOrder, "fora de ordem"},
+	{IssueDuplicate, "duplicados"},
+	{IssueMissing, "ausentes"},
+	{IssueNonPositive, "com valores não positivos"},
+	{IssueHighBelowLow, "com máxima abaixo da mínima"},
+}
+
+// ErrInvalidCandles indica que a série foi recusada pela política PolicyReject
+var ErrInvalidCandles = errors.New("candles inválidos")
+
+// Issue é um problema encontrado no candle iniciado em Timestamp
+type Issue struct {
+	Type      IssueType
+	Timestamp time.Time
+}
+
+// Report resume a validação de uma série de candles
+type Report struct {
+	Resolution model.Resolution
+	Policy     Policy
+	Issues     []Issue
+	Dropped    int // Candles inválidos descartados
+	Filled     int // Candles ausentes preenchidos com o fechamento anterior
+}
+
+// Clean indica se a série não tem nenhum problema
+func (r Report) Clean() bool {
+	return len(r.Issues) == 0
+}
+
+// Count retorna a quantidade de problemas de um tipo
+func (r Report) Count(issue IssueType) int {
+	count := 0
+	for _, i := range r.Issues {
+		if i.Type == issue {
+			count++
+		}
+	}
+	return count
+}
+
+// Between retorna um relatório apenas com os problemas dos candles entre from e to (inclusive)
+func (r Report) Between(from, to time.Time) Report {
+	filtered := Report{Resolution: r.Resolution, Policy: r.Policy}
+	for _, i := range r.Issues {
+		if !i.Timestamp.Before(from) && !i.Timestamp.After(to) {
+			filtered.Issues = append(filtered.Issues, i)
+		}
+	}
+	return filtered
+}
+
+// Summary descreve os problemas por tipo, com os candles afetados agrupados em intervalos
+// (ex.: "2 candle(s) ausentes: 2025-01-03 a 2025-01-04; 1 candle(s) duplicados: 2025-01-07")
+func (r Report) Summary() string {
+	var parts []string
+	for _, it := range issueTypes {
+		var timestamps []time.Time
+		for _, i := range r.Issues {
+			if i.Type == it.issue {
+				timestamps = append(timestamps, i.Timestamp)
+			}
+		}
+		if len(timestamps) == 0 {
+			continue
+		}
+		ranges := r.Resolution.FormatTimeRanges(r.Resolution.ContiguousRanges(timestamps))
+		parts = append(parts, fmt.Sprintf("%d candle(s) %s: %s", len(timestamps), it.label, ranges))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// CheckCandle verifica os valores de um candle isolado, retornando o problema encontrado
+func CheckCandle(c model.Candle) (IssueType, bool) {
+	if !c.Open.IsPositive() || !c.High.IsPositive() || !c.Low.IsPositive() || !c.Close.IsPositive() || c.Volume.IsNegative() {
+		return IssueNonPositive, false
+	}
+	if c.High.LessThan(c.Low) {
+		return IssueHighBelowLow, false
+	}
+	return "", true
+}
+
+// Validate detecta candles fora de ordem, duplicados, ausentes e com valores inválidos e aplica a política.
+// Exceto com PolicyReject, o resultado está sempre em ordem crescente e sem duplicatas (o último candle
+// recebido para um timestamp prevalece). Candles ausentes são procurados apenas entre o primeiro e o
+// último candle da série, já que o provedor pode não ter histórico antes disso.
+func Validate(candles []model.Candle, resolution model.Resolution, policy Policy) ([]model.Candle, Report, error) {
+	report := Report{Resolution: resolution, Policy: policy}
+
+	// Ordem e duplicatas são avaliadas na sequência recebida
+	byTimestamp := make(map[int64]model.Candle, len(candles))
+	var latest time.Time
+	for i, c := range candles {
+		key := c.Timestamp.Unix()
+		if _, seen := byTimestamp[key]; seen {
+			report.Issues = append(report.Issues, Issue{Type: IssueDuplicate, Timestamp: c.Timestamp})
+		} else if i > 0 && c.Timestamp.Before(latest) {
+			report.Issues = append(report.Issues, Issue{Type: IssueOutOfOrder, Timestamp: c.Timestamp})
+		}
+		if c.Timestamp.After(latest) {
+			latest = c.Timestamp
+		}
+		byTimestamp[key] = c
+	}
+
+	sorted := make([]model.Candle, 0, len(byTimestamp))
+	for _, c := range byTimestamp {
+		sorted = append(sorted, c)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+	})
+
+	valid := make([]model.Candle, 0, len(sorted))
+	for i, c := range sorted {
+		if i > 0 {
+			for t := resolution.Next(sorted[i-1].Timestamp); t.Before(c.Timestamp); t = resolution.Next(t) {
+				report.Issues = append(report.Issues, Issue{Type: IssueMissing, Timestamp: t})
+			}
+		}
+		if issue, ok := CheckCandle(c); !ok {
+			report.Issues = append(report.Issues, Issue{Type: issue, Timestamp: c.Timestamp})
+			continue
+		}
+		valid = append(valid, c)
+	}
+
+	switch policy {
+	case PolicyReject:
+		if !report.Clean() {
+			return nil, report, fmt.Errorf("%w: %s", ErrInvalidCandles, report.Summary())
+		}
+		return valid, report, nil
+	case PolicyForwardFill:
+		report.Dropped = len(sorted) - len(valid)
+		filled := forwardFill(valid, resolution)
+		report.Filled = len(filled) - len(valid)
+		return filled, report, nil
+	default:
+		report.Dropped = len(sorted) - len(valid)
+		return valid, report, nil
+	}
+}
+
+// forwardFill preenche os candles ausentes entre candles válidos com um candle sem variação no
+// fechamento anterior e volume zero
+func forwardFill(candles []model.Candle, resolution model.Resolution) []model.Candle {
+	if len(candles) == 0 {
+		return candles
+	}
+
+	filled := make([]model.Candle, 0, len(candles))
+	filled = append(filled, candles[0])
+	for _, c := range candles[1:] {
+		prev := filled[len(filled)-1]
+		for t := resolution.Next(prev.Timestamp); t.Before(c.Timestamp); t = resolution.Next(t) {
+			filled = append(filled, model.Candle{
+				Pair:       prev.Pair,
+				Resolution: prev.Resolution,
+				Timestamp:  t,
+				Open:       prev.Close,
+				High:       prev.Close,
+				Low:        prev.Close,
+				Close:      prev.Close,
+				Volume:     decimal.Zero,
+			})
+		}
+		filled = append(filled, c)
+	}
+	return filled
+}
@@ -0,0 +1,180 @@
+package reconciliation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"mms_api/internal/domain/model"
+)
+
+// Tolerance define as diferenças aceitas entre os candles de dois provedores, em percentual do valor
+// do provedor principal. Uma tolerância zero desativa a comparação do campo.
+type Tolerance struct {
+	Close  decimal.Decimal // Diferença aceita no preço de fechamento (ex.: 1 = 1%)
+	Volume decimal.Decimal // Diferença aceita no volume; exchanges diferentes têm liquidez diferente
+}
+
+// differenceScale é a quantidade de casas decimais da diferença percentual
+const differenceScale = 4
+
+var hundred = decimal.NewFromInt(100)
+
+// Report resume a reconciliação de um par em um intervalo entre dois provedores
+type Report struct {
+	Pair        string
+	Resolution  model.Resolution
+	Primary     string
+	Secondary   string
+	Compared    int // Candles presentes nos dois provedores
+	Divergences []model.Divergence
+}
+
+// Clean indica se nenhuma divergência foi encontrada
+func (r Report) Clean() bool {
+	return len(r.Divergences) == 0
+}
+
+// Count retorna a quantidade de divergências de um campo
+func (r Report) Count(field model.DivergenceField) int {
+	count := 0
+	for _, d := range r.Divergences {
+		if d.Field == field {
+			count++
+		}
+	}
+	return count
+}
+
+// Summary descreve as divergências por campo, com os candles afetados agrupados em intervalos e a
+// maior diferença encontrada (ex.: "2 candle(s) com fechamento divergente (até 3.5%): 2025-01-03 a 2025-01-04")
+func (r Report) Summary() string {
+	fields := []struct {
+		field model.DivergenceField
+		label string
+	}{
+		{model.DivergenceClose, "com fechamento divergente"},
+		{model.DivergenceVolume, "com volume divergente"},
+		{model.DivergenceMissing, "ausentes em um dos provedores"},
+	}
+
+	var parts []string
+	for _, f := range fields {
+		var timestamps []time.Time
+		largest := decimal.Zero
+		for _, d := range r.Divergences {
+			if d.Field != f.field {
+				continue
+			}
+			timestamps = append(timestamps, d.Timestamp)
+			if d.Difference.GreaterThan(largest) {
+				largest = d.Difference
+			}
+		}
+		if len(timestamps) == 0 {
+			continue
+		}
+
+		label := f.label
+		if f.field != model.DivergenceMissing {
+			label += fmt.Sprintf(" (até %s%%)", largest.String())
+		}
+		ranges := r.Resolution.FormatTimeRanges(r.Resolution.ContiguousRanges(timestamps))
+		parts = append(parts, fmt.Sprintf("%d candle(s) %s: %s", len(timestamps), label, ranges))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Compare confronta os candles de dois provedores para o mesmo par, resolução e intervalo, candle a
+// candle pelo timestamp. Fechamento e volume divergem quando a diferença relativa ao provedor principal
+// excede a tolerância; candles presentes em apenas um dos provedores também são divergências.
+func Compare(pair string, resolution model.Resolution, primary string, primaryCandles []model.Candle, secondary string, secondaryCandles []model.Candle, tolerance Tolerance) Report {
+	report := Report{Pair: pair, Resolution: resolution, Primary: primary, Secondary: secondary}
+
+	primaryByTime := byTimestamp(primaryCandles)
+	secondaryByTime := byTimestamp(secondaryCandles)
+
+	timestamps := make([]time.Time, 0, len(primaryByTime)+len(secondaryByTime))
+	for _, c := range primaryByTime {
+		timestamps = append(timestamps, c.Timestamp)
+	}
+	for key, c := range secondaryByTime {
+		if _, ok := primaryByTime[key]; !ok {
+			timestamps = append(timestamps, c.Timestamp)
+		}
+	}
+	sort.Slice(timestamps, func(i, j int) bool {
+		return timestamps[i].Before(timestamps[j])
+	})
+
+	for _, ts := range timestamps {
+		divergence := model.Divergence{Pair: pair, Resolution: resolution, Timestamp: ts, Primary: primary, Secondary: secondary}
+
+		p, inPrimary := primaryByTime[ts.Unix()]
+		s, inSecondary := secondaryByTime[ts.Unix()]
+		if !inPrimary || !inSecondary {
+			divergence.Field = model.DivergenceMissing
+			if inPrimary {
+				divergence.PrimaryValue = decimal.NewNullDecimal(p.Close)
+			} else {
+				divergence.SecondaryValue = decimal.NewNullDecimal(s.Close)
+			}
+			report.Divergences = append(report.Divergences, divergence)
+			continue
+		}
+
+		report.Compared++
+		checks := []struct {
+			field     model.DivergenceField
+			primary   decimal.Decimal
+			secondary decimal.Decimal
+			tolerance decimal.Decimal
+		}{
+			{model.DivergenceClose, p.Close, s.Close, tolerance.Close},
+			{model.DivergenceVolume, p.Volume, s.Volume, tolerance.Volume},
+		}
+		for _, check := range checks {
+			if !check.tolerance.IsPositive() {
+				continue
+			}
+			difference, diverges := relativeDifference(check.primary, check.secondary, check.tolerance)
+			if !diverges {
+				continue
+			}
+			d := divergence
+			d.Field = check.field
+			d.PrimaryValue = decimal.NewNullDecimal(check.primary)
+			d.SecondaryValue = decimal.NewNullDecimal(check.secondary)
+			d.Difference = difference
+			report.Divergences = append(report.Divergences, d)
+		}
+	}
+
+	return report
+}
+
+// relativeDifference calcula |secundário - principal| / principal em percentual e indica se ela excede a
+// tolerância. Com o valor principal zero, qualquer valor secundário diferente de zero diverge.
+func relativeDifference(primary, secondary, tolerance decimal.Decimal) (decimal.Decimal, bool) {
+	if primary.IsZero() {
+		if secondary.IsZero() {
+			return decimal.Zero, false
+		}
+		return hundred, true
+	}
+
+	difference := secondary.Sub(primary).Abs().Div(primary.Abs()).Mul(hundred).Round(differenceScale)
+	return difference, difference.GreaterThan(tolerance)
+}
+
+// byTimestamp indexa os candles pelo timestamp; com repetições, prevalece o último candle recebido
+func byTimestamp(candles []model.Candle) map[int64]model.Candle {
+	indexed := make(map[int64]model.Candle, len(candles))
+	for _, c := range candles {
+		indexed[c.Timestamp.Unix()] = c
+	}
+	return indexed
+}
@@ -0,0 +1,217 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"mms_api/pkg/logger"
+	"mms_api/pkg/monitoring"
+)
+
+// CircuitState é o estado de um circuit breaker
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"    // Chamadas liberadas
+	CircuitOpen     CircuitState = "open"      // Chamadas recusadas até o fim de OpenTimeout
+	CircuitHalfOpen CircuitState = "half_open" // Apenas chamadas de teste liberadas
+)
+
+// ErrCircuitOpen indica que a chamada foi recusada sem chegar ao provedor
+var ErrCircuitOpen = errors.New("circuito aberto")
+
+// CircuitBreakerConfig define quando o circuito abre e como ele volta a fechar
+type CircuitBreakerConfig struct {
+	FailureThreshold int           // Falhas consecutivas que abrem o circuito (0 desativa o circuit breaker)
+	OpenTimeout      time.Duration // Tempo com o circuito aberto antes das chamadas de teste
+	HalfOpenProbes   int           // Chamadas de teste simultâneas com o circuito meio aberto
+}
+
+// DefaultCircuitBreakerConfig é a configuração usada quando nenhuma é informada
+var DefaultCircuitBreakerConfig = CircuitBreakerConfig{
+	FailureThreshold: 5,
+	OpenTimeout:      time.Minute,
+	HalfOpenProbes:   1,
+}
+
+// CircuitStats é o retrato de um circuit breaker, exposto nos logs e no health check
+type CircuitStats struct {
+	Name                string       `json:"name"`
+	State               CircuitState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	Opens               int          `json:"opens"`    // Vezes em que o circuito abriu
+	Rejected            int          `json:"rejected"` // Chamadas recusadas com o circuito aberto
+	OpenedAt            *time.Time   `json:"opened_at,omitempty"`
+}
+
+// CircuitBreaker interrompe as chamadas a um provedor degradado. Após FailureThreshold falhas
+// consecutivas o circuito abre e as chamadas falham com ErrCircuitOpen; passado OpenTimeout, até
+// HalfOpenProbes chamadas de teste são liberadas: o sucesso de uma delas fecha o circuito e uma falha
+// o abre novamente. Contam como falha os erros de rede e as respostas 429 e 5xx; o cancelamento pelo
+// chamador e as demais respostas 4xx não afetam o circuito.
+type CircuitBreaker struct {
+	name   string
+	config CircuitBreakerConfig
+	logger logger.Logger
+	alerts monitoring.AlertMonitor
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	probes   int // Chamadas de teste em andamento
+	openedAt time.Time
+	opens    int
+	rejected int
+}
+
+// NewCircuitBreaker cria o circuit breaker do provedor name. Quando alerts não é nil, a abertura do
+// circuito envia um alerta "circuito_aberto".
+func NewCircuitBreaker(name string, config CircuitBreakerConfig, log logger.Logger, alerts monitoring.AlertMonitor) *CircuitBreaker {
+	if config.HalfOpenProbes < 1 {
+		config.HalfOpenProbes = 1
+	}
+	return &CircuitBreaker{
+		name:   name,
+		config: config,
+		logger: log,
+		alerts: alerts,
+		state:  CircuitClosed,
+	}
+}
+
+// Execute chama fn se o circuito permitir e registra o resultado
+func (b *CircuitBreaker) Execute(ctx context.Context, fn func(ctx context.Context) error) error {
+	if b == nil || b.config.FailureThreshold <= 0 {
+		return fn(ctx)
+	}
+
+	probe, err := b.allow()
+	if err != nil {
+		return err
+	}
+
+	err = fn(ctx)
+	b.record(probe, err, isProviderFailure(ctx, err))
+	return err
+}
+
+// State retorna o estado atual do circuito
+func (b *CircuitBreaker) State() CircuitState {
+	return b.Stats().State
+}
+
+// Stats retorna o estado e os contadores do circuito
+func (b *CircuitBreaker) Stats() CircuitStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stats := CircuitStats{
+		Name:                b.name,
+		State:               b.currentState(),
+		ConsecutiveFailures: b.failures,
+		Opens:               b.opens,
+		Rejected:            b.rejected,
+	}
+	if stats.State != CircuitClosed {
+		openedAt := b.openedAt
+		stats.OpenedAt = &openedAt
+	}
+	return stats
+}
+
+// currentState considera meio aberto o circuito aberto há mais de OpenTimeout
+func (b *CircuitBreaker) currentState() CircuitState {
+	if b.state == CircuitOpen && time.Since(b.openedAt) >= b.config.OpenTimeout {
+		return CircuitHalfOpen
+	}
+	return b.state
+}
+
+// allow decide se a chamada pode seguir, indicando se ela é uma chamada de teste
+func (b *CircuitBreaker) allow() (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state := b.currentState()
+	if state != b.state {
+		b.transition(state, nil)
+	}
+
+	switch state {
+	case CircuitClosed:
+		return false, nil
+	case CircuitHalfOpen:
+		if b.probes < b.config.HalfOpenProbes {
+			b.probes++
+			return true, nil
+		}
+	}
+
+	b.rejected++
+	retryIn := b.config.OpenTimeout - time.Since(b.openedAt)
+	if retryIn < 0 {
+		retryIn = 0
+	}
+	return false, fmt.Errorf("%w: %s (nova tentativa em %s)", ErrCircuitOpen, b.name, retryIn.Round(time.Second))
+}
+
+// record atualiza o circuito com o resultado de uma chamada
+func (b *CircuitBreaker) record(probe bool, err error, failure bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if probe {
+		b.probes--
+	}
+
+	switch {
+	case err == nil:
+		b.failures = 0
+		if b.state != CircuitClosed {
+			b.transition(CircuitClosed, nil)
+		}
+	case failure:
+		b.failures++
+		if b.state == CircuitHalfOpen || (b.state == CircuitClosed && b.failures >= b.config.FailureThreshold) {
+			b.transition(CircuitOpen, err)
+		}
+	}
+}
+
+// transition muda o estado do circuito, registrando a mudança e alertando quando ele abre
+func (b *CircuitBreaker) transition(to CircuitState, cause error) {
+	from := b.state
+	b.state = to
+
+	switch to {
+	case CircuitOpen:
+		b.openedAt = time.Now()
+		b.opens++
+		b.logger.Error("Circuito aberto", cause, "provider", b.name, "from", from, "failures", b.failures, "open_timeout", b.config.OpenTimeout)
+		if b.alerts != nil {
+			b.alerts.SendAlert("circuito_aberto", fmt.Sprintf(
+				"Circuito do provedor %s aberto após %d falha(s) consecutiva(s): %v. Novas chamadas serão recusadas por %s.",
+				b.name, b.failures, cause, b.config.OpenTimeout))
+		}
+	case CircuitHalfOpen:
+		b.logger.Info("Circuito meio aberto, liberando chamadas de teste", "provider", b.name, "probes", b.config.HalfOpenProbes)
+	case CircuitClosed:
+		b.logger.Info("Circuito fechado", "provider", b.name, "from", from)
+	}
+}
+
+// isProviderFailure indica se o erro aponta um provedor degradado: erros de rede, 429 e 5xx
+func isProviderFailure(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Retryable()
+	}
+	return !errors.Is(err, context.Canceled)
+}
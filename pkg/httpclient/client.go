@@ -0,0 +1,32 @@
+// Package httpclient reúne o cliente HTTP compartilhado pelos provedores externos e os mecanismos de
+// resiliência das requisições: novas tentativas, limite de requisições e circuit breaker
+package httpclient
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultTimeout é o tempo máximo de cada requisição do cliente compartilhado
+const DefaultTimeout = 30 * time.Second
+
+var (
+	sharedOnce   sync.Once
+	sharedClient *http.Client
+)
+
+// Shared retorna o cliente HTTP do processo, criado na primeira chamada. Todos os provedores devem
+// usá-lo (com WithRateLimit, se for o caso), para que as conexões sejam reaproveitadas.
+func Shared() *http.Client {
+	sharedOnce.Do(func() {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.MaxIdleConnsPerHost = 16
+
+		sharedClient = &http.Client{
+			Timeout:   DefaultTimeout,
+			Transport: transport,
+		}
+	})
+	return sharedClient
+}
@@ -0,0 +1,103 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimiter limita as requisições enviadas a um provedor com um token bucket: o balde começa cheio
+// com Burst fichas, é reabastecido a Rate fichas por segundo e cada requisição consome uma ficha.
+// Um mesmo limitador deve ser compartilhado por todos os clientes do provedor no processo.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // Fichas por segundo
+	burst  float64 // Capacidade do balde
+	tokens float64 // Fichas disponíveis; negativo quando há requisições aguardando
+	last   time.Time
+}
+
+// NewRateLimiter cria um limitador de rate requisições por segundo com rajadas de até burst requisições.
+// Um rate não positivo desativa o limite (retorna nil, que aceita todas as requisições).
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait aguarda uma ficha, ou o cancelamento do contexto
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	// A ficha é reservada antes da espera, para que as requisições sejam atendidas em ordem de chegada
+	l.tokens--
+	if l.tokens >= 0 {
+		l.mu.Unlock()
+		return nil
+	}
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mu.Unlock()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Devolve a ficha reservada, que não será usada
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// rateLimitedTransport aguarda o limitador antes de cada requisição, inclusive das novas tentativas
+type rateLimitedTransport struct {
+	base    http.RoundTripper
+	limiter *RateLimiter
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
+
+// WithRateLimit retorna uma cópia do cliente cujas requisições passam pelo limitador. As conexões
+// continuam compartilhadas com o cliente original.
+func WithRateLimit(client *http.Client, limiter *RateLimiter) *http.Client {
+	if limiter == nil {
+		return client
+	}
+
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	limited := *client
+	limited.Transport = &rateLimitedTransport{base: base, limiter: limiter}
+	return &limited
+}
@@ -0,0 +1,135 @@
+package httpclient
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"mms_api/pkg/logger"
+)
+
+// RetryPolicy define as novas tentativas de uma requisição HTTP: backoff exponencial com jitter para
+// erros de rede e respostas 5xx, e o tempo pedido pelo servidor em respostas 429 (Retry-After).
+// Demais respostas 4xx não são repetidas.
+type RetryPolicy struct {
+	MaxAttempts int           // Total de tentativas, incluindo a primeira (1 desativa as novas tentativas)
+	BaseDelay   time.Duration // Espera base, dobrada a cada tentativa
+	MaxDelay    time.Duration // Espera máxima entre tentativas; um Retry-After maior encerra as tentativas
+}
+
+// DefaultRetryPolicy é a política usada quando nenhuma é configurada
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    30 * time.Second,
+}
+
+// StatusError é uma resposta HTTP fora da faixa 2xx
+type StatusError struct {
+	StatusCode int
+	RetryAfter time.Duration // Espera pedida pelo servidor no cabeçalho Retry-After, se houver
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("status code inválido: %d", e.StatusCode)
+}
+
+// Retryable indica se a resposta pode ser repetida: 429 e 5xx
+func (e *StatusError) Retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// Do envia a requisição e repete as falhas temporárias conforme a política, retornando a primeira
+// resposta 2xx. A requisição não pode ter corpo, pois é reenviada a cada tentativa. O cancelamento
+// do contexto da requisição interrompe a espera entre tentativas.
+func (p RetryPolicy) Do(client *http.Client, req *http.Request, log logger.Logger) (*http.Response, error) {
+	ctx := req.Context()
+	attempts := p.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		resp, err := client.Do(req)
+		if err == nil && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return resp, nil
+		}
+
+		var retryAfter time.Duration
+		if err != nil {
+			// Falhas de rede são temporárias, a menos que o próprio contexto tenha sido cancelado
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			lastErr = err
+		} else {
+			statusErr := &StatusError{StatusCode: resp.StatusCode, RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			if !statusErr.Retryable() {
+				return nil, statusErr
+			}
+			lastErr = statusErr
+			retryAfter = statusErr.RetryAfter
+		}
+
+		if attempt >= attempts {
+			return nil, fmt.Errorf("%w (após %d tentativas)", lastErr, attempt)
+		}
+
+		delay := p.backoff(attempt)
+		if retryAfter > 0 {
+			if p.MaxDelay > 0 && retryAfter > p.MaxDelay {
+				return nil, fmt.Errorf("%w (Retry-After de %s acima da espera máxima)", lastErr, retryAfter)
+			}
+			if retryAfter > delay {
+				delay = retryAfter
+			}
+		}
+
+		log.Info("Tentando novamente requisição", "url", req.URL.String(), "attempt", attempt+1, "delay", delay, "error", lastErr)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, errors.Join(ctx.Err(), lastErr)
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff retorna a espera antes da tentativa seguinte a attempt: um valor aleatório entre zero e
+// BaseDelay·2^(attempt-1), limitado a MaxDelay ("full jitter")
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	if p.BaseDelay <= 0 {
+		return 0
+	}
+
+	ceiling := p.BaseDelay << (attempt - 1)
+	if ceiling <= 0 || (p.MaxDelay > 0 && ceiling > p.MaxDelay) {
+		ceiling = p.MaxDelay
+	}
+	return time.Duration(rand.Int63n(int64(ceiling) + 1))
+}
+
+// parseRetryAfter interpreta o cabeçalho Retry-After, em segundos ou como data HTTP
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if d := time.Until(at); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
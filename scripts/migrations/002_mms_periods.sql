@@ -0,0 +1,40 @@
+-- Convert the MMS table from one column per period to one row per (pair, timestamp, period),
+-- so new moving-average windows can be stored without a schema change
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1
+        FROM information_schema.columns
+        WHERE table_name = 'mms' AND column_name = 'mms20'
+    ) THEN
+        CREATE TABLE mms_by_period (
+            id SERIAL PRIMARY KEY,
+            pair VARCHAR(10) NOT NULL,
+            timestamp TIMESTAMP NOT NULL,
+            period INTEGER NOT NULL CHECK (period > 0),
+            value DECIMAL(20, 8) NOT NULL,
+            created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+            updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+            UNIQUE(pair, timestamp, period)
+        );
+
+        -- Copy existing averages, one row per period
+        INSERT INTO mms_by_period (pair, timestamp, period, value, created_at, updated_at)
+        SELECT m.pair, m.timestamp, p.period, p.value, m.created_at, m.updated_at
+        FROM mms m
+        CROSS JOIN LATERAL (VALUES (20, m.mms20), (50, m.mms50), (200, m.mms200)) AS p(period, value);
+
+        -- Dropping the old table also drops its indexes and trigger
+        DROP TABLE mms;
+        ALTER TABLE mms_by_period RENAME TO mms;
+        ALTER SEQUENCE mms_by_period_id_seq RENAME TO mms_id_seq;
+
+        CREATE INDEX idx_mms_pair_period_timestamp ON mms(pair, period, timestamp);
+        CREATE INDEX idx_mms_timestamp ON mms(timestamp);
+
+        CREATE TRIGGER update_mms_updated_at
+            BEFORE UPDATE ON mms
+            FOR EACH ROW
+            EXECUTE FUNCTION update_updated_at_column();
+    END IF;
+END $$;
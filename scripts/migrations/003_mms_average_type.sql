@@ -0,0 +1,17 @@
+-- Store exponential moving averages next to the simple ones, keyed by average type
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1
+        FROM information_schema.columns
+        WHERE table_name = 'mms' AND column_name = 'type'
+    ) THEN
+        ALTER TABLE mms ADD COLUMN type VARCHAR(3) NOT NULL DEFAULT 'sma' CHECK (type IN ('sma', 'ema'));
+
+        ALTER TABLE mms DROP CONSTRAINT IF EXISTS mms_by_period_pair_timestamp_period_key;
+        ALTER TABLE mms ADD CONSTRAINT mms_pair_timestamp_type_period_key UNIQUE (pair, timestamp, type, period);
+
+        DROP INDEX IF EXISTS idx_mms_pair_period_timestamp;
+        CREATE INDEX idx_mms_pair_type_period_timestamp ON mms(pair, type, period, timestamp);
+    END IF;
+END $$;
@@ -0,0 +1,22 @@
+-- Create generic technical-indicator table, one row per (pair, indicator, timestamp, series)
+CREATE TABLE IF NOT EXISTS indicator_values (
+    id SERIAL PRIMARY KEY,
+    pair VARCHAR(10) NOT NULL,
+    indicator VARCHAR(50) NOT NULL,
+    timestamp TIMESTAMP NOT NULL,
+    series VARCHAR(50) NOT NULL,
+    value DECIMAL(20, 8) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(pair, indicator, timestamp, series)
+);
+
+-- Create index for range queries by indicator
+CREATE INDEX IF NOT EXISTS idx_indicator_values_pair_indicator_timestamp ON indicator_values(pair, indicator, timestamp);
+
+-- Create trigger for automatic timestamp update
+DROP TRIGGER IF EXISTS update_indicator_values_updated_at ON indicator_values;
+CREATE TRIGGER update_indicator_values_updated_at
+    BEFORE UPDATE ON indicator_values
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
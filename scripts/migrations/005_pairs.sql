@@ -0,0 +1,19 @@
+-- Create pair registry, so pairs can be added, enabled or disabled at runtime
+CREATE TABLE IF NOT EXISTS pairs (
+    id SERIAL PRIMARY KEY,
+    symbol VARCHAR(10) NOT NULL UNIQUE,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Seed the pairs that were previously hard-coded
+INSERT INTO pairs (symbol) VALUES ('BRLBTC'), ('BRLETH')
+ON CONFLICT (symbol) DO NOTHING;
+
+-- Create trigger for automatic timestamp update
+DROP TRIGGER IF EXISTS update_pairs_updated_at ON pairs;
+CREATE TRIGGER update_pairs_updated_at
+    BEFORE UPDATE ON pairs
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,20 @@
+-- Store base and quote assets of each registered pair, so quote markets other than BRL can be tracked
+ALTER TABLE pairs ADD COLUMN IF NOT EXISTS base VARCHAR(10);
+ALTER TABLE pairs ADD COLUMN IF NOT EXISTS quote VARCHAR(10);
+
+-- Backfill existing symbols (quote followed by base), preferring the longest known quote prefix
+UPDATE pairs
+SET quote = CASE
+        WHEN symbol LIKE 'USDT%' THEN 'USDT'
+        WHEN symbol LIKE 'USDC%' THEN 'USDC'
+        WHEN symbol LIKE 'USD%' THEN 'USD'
+        ELSE LEFT(symbol, 3)
+    END
+WHERE quote IS NULL;
+
+UPDATE pairs
+SET base = SUBSTRING(symbol FROM LENGTH(quote) + 1)
+WHERE base IS NULL;
+
+ALTER TABLE pairs ALTER COLUMN base SET NOT NULL;
+ALTER TABLE pairs ALTER COLUMN quote SET NOT NULL;
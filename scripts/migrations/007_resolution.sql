@@ -0,0 +1,18 @@
+-- Make candle resolution a dimension of stored averages and indicators; existing rows are daily
+ALTER TABLE mms ADD COLUMN IF NOT EXISTS resolution VARCHAR(3) NOT NULL DEFAULT '1d';
+ALTER TABLE indicator_values ADD COLUMN IF NOT EXISTS resolution VARCHAR(3) NOT NULL DEFAULT '1d';
+
+ALTER TABLE mms DROP CONSTRAINT IF EXISTS mms_pair_timestamp_type_period_key;
+ALTER TABLE mms DROP CONSTRAINT IF EXISTS mms_pair_resolution_timestamp_type_period_key;
+ALTER TABLE mms ADD CONSTRAINT mms_pair_resolution_timestamp_type_period_key UNIQUE (pair, resolution, timestamp, type, period);
+
+ALTER TABLE indicator_values DROP CONSTRAINT IF EXISTS indicator_values_pair_indicator_timestamp_series_key;
+ALTER TABLE indicator_values DROP CONSTRAINT IF EXISTS indicator_values_pair_resolution_indicator_timestamp_series_key;
+ALTER TABLE indicator_values ADD CONSTRAINT indicator_values_pair_resolution_indicator_timestamp_series_key UNIQUE (pair, resolution, indicator, timestamp, series);
+
+-- Recreate range-query indexes with the resolution column
+DROP INDEX IF EXISTS idx_mms_pair_type_period_timestamp;
+CREATE INDEX IF NOT EXISTS idx_mms_pair_resolution_type_period_timestamp ON mms(pair, resolution, type, period, timestamp);
+
+DROP INDEX IF EXISTS idx_indicator_values_pair_indicator_timestamp;
+CREATE INDEX IF NOT EXISTS idx_indicator_values_pair_resolution_indicator_timestamp ON indicator_values(pair, resolution, indicator, timestamp);
@@ -0,0 +1,25 @@
+-- Create raw candle table, one row per (pair, resolution, timestamp), so indicators are computed from local history
+CREATE TABLE IF NOT EXISTS candles (
+    id SERIAL PRIMARY KEY,
+    pair VARCHAR(10) NOT NULL,
+    resolution VARCHAR(3) NOT NULL,
+    timestamp TIMESTAMP NOT NULL,
+    open DECIMAL(20, 8) NOT NULL,
+    high DECIMAL(20, 8) NOT NULL,
+    low DECIMAL(20, 8) NOT NULL,
+    close DECIMAL(20, 8) NOT NULL,
+    volume DECIMAL(28, 8) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(pair, resolution, timestamp)
+);
+
+-- Create index for range queries
+CREATE INDEX IF NOT EXISTS idx_candles_pair_resolution_timestamp ON candles(pair, resolution, timestamp);
+
+-- Create trigger for automatic timestamp update
+DROP TRIGGER IF EXISTS update_candles_updated_at ON candles;
+CREATE TRIGGER update_candles_updated_at
+    BEFORE UPDATE ON candles
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
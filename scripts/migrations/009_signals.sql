@@ -0,0 +1,26 @@
+-- Create crossover signal table; price crossings are stored with fast_period = 0
+CREATE TABLE IF NOT EXISTS signals (
+    id SERIAL PRIMARY KEY,
+    pair VARCHAR(10) NOT NULL,
+    resolution VARCHAR(3) NOT NULL,
+    timestamp TIMESTAMP NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    average_type VARCHAR(3) NOT NULL,
+    fast_period INTEGER NOT NULL,
+    slow_period INTEGER NOT NULL,
+    fast_value DECIMAL(20, 8) NOT NULL,
+    slow_value DECIMAL(20, 8) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(pair, resolution, timestamp, average_type, fast_period, slow_period)
+);
+
+-- Create index for range queries
+CREATE INDEX IF NOT EXISTS idx_signals_pair_resolution_timestamp ON signals(pair, resolution, timestamp);
+
+-- Create trigger for automatic timestamp update
+DROP TRIGGER IF EXISTS update_signals_updated_at ON signals;
+CREATE TRIGGER update_signals_updated_at
+    BEFORE UPDATE ON signals
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
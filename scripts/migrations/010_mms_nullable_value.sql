@@ -0,0 +1,3 @@
+-- Allow NULL averages for windows whose lookback is not yet covered by the pair's history,
+-- so shorter windows can be stored for newly listed pairs
+ALTER TABLE mms ALTER COLUMN value DROP NOT NULL;
@@ -0,0 +1,3 @@
+-- Record which candle provider served each candle, so failovers between providers can be audited.
+-- Rows stored before this migration keep a NULL source.
+ALTER TABLE candles ADD COLUMN IF NOT EXISTS source VARCHAR(32);
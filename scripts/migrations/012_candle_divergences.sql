@@ -0,0 +1,27 @@
+-- Create table of divergences found when reconciling candles across providers; the value of a
+-- candle missing from one provider is NULL and its difference is 0
+CREATE TABLE IF NOT EXISTS candle_divergences (
+    id SERIAL PRIMARY KEY,
+    pair VARCHAR(10) NOT NULL,
+    resolution VARCHAR(3) NOT NULL,
+    timestamp TIMESTAMP NOT NULL,
+    field VARCHAR(10) NOT NULL,
+    primary_provider VARCHAR(32) NOT NULL,
+    secondary_provider VARCHAR(32) NOT NULL,
+    primary_value DECIMAL(28, 8),
+    secondary_value DECIMAL(28, 8),
+    difference DECIMAL(20, 4) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(pair, resolution, timestamp, field, primary_provider, secondary_provider)
+);
+
+-- Create index for range queries
+CREATE INDEX IF NOT EXISTS idx_candle_divergences_pair_resolution_timestamp ON candle_divergences(pair, resolution, timestamp);
+
+-- Create trigger for automatic timestamp update
+DROP TRIGGER IF EXISTS update_candle_divergences_updated_at ON candle_divergences;
+CREATE TRIGGER update_candle_divergences_updated_at
+    BEFORE UPDATE ON candle_divergences
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,3 @@
+-- Widen indicator values beyond the candle volume precision (DECIMAL(28, 8)): cumulative indicators
+-- such as OBV sum the volume of the whole series and overflow DECIMAL(20, 8) on high-volume pairs
+ALTER TABLE indicator_values ALTER COLUMN value TYPE DECIMAL(38, 8);
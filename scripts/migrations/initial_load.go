@@ -3,13 +3,12 @@ package main
 import (
 	"context"
 	"log"
-	"net/http"
 	"time"
 
 	"mms_api/config"
-	"mms_api/internal/adapter/out/mercadobitcoin"
 	pgadapter "mms_api/internal/adapter/out/persistence/postgres"
 	"mms_api/internal/application/service"
+	"mms_api/internal/bootstrap"
 	pgdb "mms_api/pkg/db/postgres"
 	"mms_api/pkg/logger"
 )
@@ -33,34 +32,51 @@ func main() {
 
 	// Inicializar repositório
 	mmsRepo := pgadapter.NewMMSRepository(db, l)
+	indicatorRepo := pgadapter.NewIndicatorRepository(db, l)
+	candleRepo := pgadapter.NewCandleRepository(db, l)
+	signalRepo := pgadapter.NewSignalRepository(db, l)
+	pairRepo := pgadapter.NewPairRepository(db, l)
 
-	// Inicializar HTTP client para API de candles
-	httpClient := &http.Client{
-		Timeout: 30 * time.Second,
+	// Inicializar o provedor de candles configurado
+	candleAPI, _, err := bootstrap.NewCandleAPI(cfg, l, nil)
+	if err != nil {
+		log.Fatalf("Erro ao criar o provedor de candles: %v", err)
 	}
 
-	// Inicializar API de candles
-	candleAPI := mercadobitcoin.NewCandleAPI(cfg.MercadoBitcoinBaseURL, httpClient, l)
-
-	// Inicializar serviço
-	mmsService := service.NewMMSService(mmsRepo, candleAPI, l)
+	// Inicializar serviços
+	pairService := service.NewPairService(pairRepo, l)
+	mmsService := service.NewMMSService(mmsRepo, candleAPI, l,
+		service.WithPeriods(cfg.MMSPeriods...),
+		service.WithResolutions(cfg.Resolutions...),
+		service.WithIndicatorSet(service.NewIndicatorSet(cfg.Indicators)),
+		service.WithIndicatorRepository(indicatorRepo),
+		service.WithCandleRepository(candleRepo),
+		service.WithSignalRepository(signalRepo),
+		service.WithPairService(pairService),
+		service.WithValidationPolicy(cfg.CandleValidationPolicy),
+	)
 
-	// Calcular período para carga inicial (últimos 365 dias)
-	to := time.Now()
-	from := to.AddDate(-1, 0, 0)
-
-	// Executar carga inicial para cada par
-	pairs := []string{"BRLBTC", "BRLETH"}
+	// Executar carga inicial (últimos 365 dias) para cada par habilitado no registro e resolução configurada
 	ctx := context.Background()
+	pairs, err := pairService.EnabledPairs(ctx)
+	if err != nil {
+		log.Fatalf("Erro ao obter pares habilitados: %v", err)
+	}
 
 	for _, pair := range pairs {
-		log.Printf("Iniciando carga para %s de %s até %s", pair, from.Format("2006-01-02"), to.Format("2006-01-02"))
+		for _, resolution := range mmsService.Resolutions() {
+			// Até o último candle completo da resolução
+			to := resolution.Truncate(time.Now()).Add(-resolution.Duration())
+			from := resolution.Truncate(to.AddDate(-1, 0, 0))
 
-		if err := mmsService.CalculateAndSaveMMSForRange(ctx, pair, from, to); err != nil {
-			log.Fatalf("Erro ao calcular e salvar MMS para %s: %v", pair, err)
-		}
+			log.Printf("Iniciando carga para %s (%s) de %s até %s", pair, resolution, from.Format(time.RFC3339), to.Format(time.RFC3339))
 
-		log.Printf("Carga para %s concluída com sucesso", pair)
+			if err := mmsService.CalculateAndSaveMMSForRange(ctx, pair, resolution, from, to); err != nil {
+				log.Fatalf("Erro ao calcular e salvar MMS para %s (%s): %v", pair, resolution, err)
+			}
+
+			log.Printf("Carga para %s (%s) concluída com sucesso", pair, resolution)
+		}
 	}
 
 	log.Println("Carga inicial concluída com sucesso!")
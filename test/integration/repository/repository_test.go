@@ -11,6 +11,7 @@ import (
 	"mms_api/pkg/logger"
 
 	_ "github.com/lib/pq"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -46,20 +47,12 @@ func TestMMSRepository_Integration(t *testing.T) {
 
 		// Criar dados de teste
 		testData := []model.MMS{
-			{
-				Pair:      "BRLBTC",
-				Timestamp: now,
-				MMS20:     150000.0,
-				MMS50:     148000.0,
-				MMS200:    145000.0,
-			},
-			{
-				Pair:      "BRLBTC",
-				Timestamp: now.Add(-24 * time.Hour),
-				MMS20:     149000.0,
-				MMS50:     147000.0,
-				MMS200:    144000.0,
-			},
+			{Pair: "BRLBTC", Resolution: model.Resolution1d, Timestamp: now, Type: model.AverageSimple, Period: model.Period20, Value: decimal.NewNullDecimal(decimal.NewFromFloat(150000.0))},
+			{Pair: "BRLBTC", Resolution: model.Resolution1d, Timestamp: now, Type: model.AverageSimple, Period: model.Period50, Value: decimal.NewNullDecimal(decimal.NewFromFloat(148000.0))},
+			{Pair: "BRLBTC", Resolution: model.Resolution1d, Timestamp: now, Type: model.AverageSimple, Period: model.Period200, Value: decimal.NewNullDecimal(decimal.NewFromFloat(145000.0))},
+			{Pair: "BRLBTC", Resolution: model.Resolution1d, Timestamp: now.Add(-24 * time.Hour), Type: model.AverageSimple, Period: model.Period20, Value: decimal.NewNullDecimal(decimal.NewFromFloat(149000.0))},
+			{Pair: "BRLBTC", Resolution: model.Resolution1d, Timestamp: now.Add(-24 * time.Hour), Type: model.AverageSimple, Period: model.Period50, Value: decimal.NewNullDecimal(decimal.NewFromFloat(147000.0))},
+			{Pair: "BRLBTC", Resolution: model.Resolution1d, Timestamp: now.Add(-24 * time.Hour), Type: model.AverageSimple, Period: model.Period200, Value: decimal.NewNullDecimal(decimal.NewFromFloat(144000.0))},
 		}
 
 		// Salvar dados
@@ -69,13 +62,45 @@ func TestMMSRepository_Integration(t *testing.T) {
 		// Buscar dados
 		from := now.Add(-48 * time.Hour)
 		to := now.Add(24 * time.Hour)
-		result, err := repo.FindByPairAndTimeRange(ctx, "BRLBTC", from, to, model.Period20)
+		result, err := repo.FindByPairAndTimeRange(ctx, "BRLBTC", model.Resolution1d, from, to, model.Period20, model.AverageSimple)
 		require.NoError(t, err)
 
 		// Verificar resultados
 		assert.Len(t, result, 2)
 		assert.Equal(t, testData[0].Pair, result[0].Pair)
-		assert.Equal(t, testData[0].MMS20, result[0].MMS20)
+		assert.Equal(t, model.Period20, result[0].Period)
+		assert.True(t, testData[0].Value.Decimal.Equal(result[0].Value.Decimal))
+	})
+
+	t.Run("SaveBatch com janela sem histórico", func(t *testing.T) {
+		ctx := context.Background()
+		ts := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+		// A MMS200 fica nula até existir histórico suficiente
+		err := repo.SaveBatch(ctx, []model.MMS{
+			{Pair: "BRLSOL", Resolution: model.Resolution1d, Timestamp: ts, Type: model.AverageSimple, Period: model.Period200},
+		})
+		require.NoError(t, err)
+
+		result, err := repo.FindByPairAndTimeRange(ctx, "BRLSOL", model.Resolution1d, ts, ts, model.Period200, model.AverageSimple)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.False(t, result[0].Value.Valid)
+
+		// Um valor calculado substitui o nulo, mas um recálculo sem histórico não apaga o valor
+		value := decimal.NewNullDecimal(decimal.RequireFromString("180.5"))
+		require.NoError(t, repo.SaveBatch(ctx, []model.MMS{
+			{Pair: "BRLSOL", Resolution: model.Resolution1d, Timestamp: ts, Type: model.AverageSimple, Period: model.Period200, Value: value},
+		}))
+		require.NoError(t, repo.SaveBatch(ctx, []model.MMS{
+			{Pair: "BRLSOL", Resolution: model.Resolution1d, Timestamp: ts, Type: model.AverageSimple, Period: model.Period200},
+		}))
+
+		result, err = repo.FindByPairAndTimeRange(ctx, "BRLSOL", model.Resolution1d, ts, ts, model.Period200, model.AverageSimple)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.True(t, result[0].Value.Valid)
+		assert.Equal(t, "180.5", result[0].Value.Decimal.String())
 	})
 
 	t.Run("CheckDataCompleteness", func(t *testing.T) {
@@ -84,21 +109,11 @@ func TestMMSRepository_Integration(t *testing.T) {
 
 		// Criar dados com um gap
 		testData := []model.MMS{
-			{
-				Pair:      "BRLETH",
-				Timestamp: now,
-				MMS20:     2500.0,
-				MMS50:     2400.0,
-				MMS200:    2300.0,
-			},
+			{Pair: "BRLETH", Resolution: model.Resolution1d, Timestamp: now, Type: model.AverageSimple, Period: model.Period20, Value: decimal.NewNullDecimal(decimal.NewFromFloat(2500.0))},
+			{Pair: "BRLETH", Resolution: model.Resolution1d, Timestamp: now, Type: model.AverageSimple, Period: model.Period200, Value: decimal.NewNullDecimal(decimal.NewFromFloat(2300.0))},
 			// Gap de um dia aqui
-			{
-				Pair:      "BRLETH",
-				Timestamp: now.Add(-48 * time.Hour),
-				MMS20:     2400.0,
-				MMS50:     2300.0,
-				MMS200:    2200.0,
-			},
+			{Pair: "BRLETH", Resolution: model.Resolution1d, Timestamp: now.Add(-48 * time.Hour), Type: model.AverageSimple, Period: model.Period20, Value: decimal.NewNullDecimal(decimal.NewFromFloat(2400.0))},
+			{Pair: "BRLETH", Resolution: model.Resolution1d, Timestamp: now.Add(-48 * time.Hour), Type: model.AverageSimple, Period: model.Period200, Value: decimal.NewNullDecimal(decimal.NewFromFloat(2200.0))},
 		}
 
 		// Salvar dados
@@ -108,7 +123,7 @@ func TestMMSRepository_Integration(t *testing.T) {
 		// Verificar completude
 		from := now.Add(-48 * time.Hour)
 		to := now
-		isComplete, missingDates, err := repo.CheckDataCompleteness(ctx, "BRLETH", from, to)
+		isComplete, missingDates, err := repo.CheckDataCompleteness(ctx, "BRLETH", model.Resolution1d, from, to)
 		require.NoError(t, err)
 
 		assert.False(t, isComplete)
@@ -119,7 +134,7 @@ func TestMMSRepository_Integration(t *testing.T) {
 		ctx := context.Background()
 
 		// Buscar dados para um timeframe específico
-		result, err := repo.GetMMSByPair(ctx, "BRLBTC", "1d")
+		result, err := repo.GetMMSByPair(ctx, "BRLBTC", model.Resolution1d, "1d")
 		require.NoError(t, err)
 
 		// Verificar resultados
@@ -129,3 +144,239 @@ func TestMMSRepository_Integration(t *testing.T) {
 		}
 	})
 }
+
+func TestIndicatorRepository_Integration(t *testing.T) {
+	// Configurar banco de dados de teste
+	dbConfig := pgdb.Config{
+		Host:     "test-db",
+		Port:     "5432",
+		User:     "test_user",
+		Password: "test_password",
+		DBName:   "test_db",
+	}
+
+	db, err := pgdb.NewConnectionWithTimeout(dbConfig)
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := postgres.NewIndicatorRepository(db, logger.NewLogger("[TEST] "))
+
+	_, err = db.Exec("TRUNCATE TABLE indicator_values")
+	require.NoError(t, err)
+
+	t.Run("SaveBatch e FindByPairAndTimeRange agrupam as séries por timestamp", func(t *testing.T) {
+		ctx := context.Background()
+		now := time.Now().UTC().Truncate(time.Second)
+
+		testData := []model.IndicatorValue{
+			{Pair: "BRLBTC", Resolution: model.Resolution1d, Indicator: "bands", Timestamp: now, Values: map[string]decimal.NullDecimal{"upper": decimal.NewNullDecimal(decimal.RequireFromString("110.12345678")), "lower": decimal.NewNullDecimal(decimal.RequireFromString("89.87654322"))}},
+			{Pair: "BRLBTC", Resolution: model.Resolution1d, Indicator: "bands", Timestamp: now.Add(-24 * time.Hour), Values: map[string]decimal.NullDecimal{"upper": decimal.NewNullDecimal(decimal.NewFromInt(105)), "lower": decimal.NewNullDecimal(decimal.NewFromInt(95))}},
+		}
+
+		require.NoError(t, repo.SaveBatch(ctx, testData))
+
+		result, err := repo.FindByPairAndTimeRange(ctx, "BRLBTC", model.Resolution1d, "bands", now.Add(-48*time.Hour), now.Add(time.Hour))
+		require.NoError(t, err)
+
+		assert.Len(t, result, 2)
+		// Os valores voltam com as mesmas oito casas decimais gravadas
+		assert.Equal(t, "110.12345678", result[0].Values["upper"].Decimal.String())
+		assert.Equal(t, "89.87654322", result[0].Values["lower"].Decimal.String())
+		assert.Equal(t, "105", result[1].Values["upper"].Decimal.String())
+	})
+
+	t.Run("valores acumulados acima da precisão do volume dos candles", func(t *testing.T) {
+		ctx := context.Background()
+		now := time.Now().UTC().Truncate(time.Second)
+
+		// Um OBV de pares de alto volume passa de 1e12, o limite de DECIMAL(20, 8)
+		obv := decimal.RequireFromString("123456789012345678901.12345678")
+		require.NoError(t, repo.SaveBatch(ctx, []model.IndicatorValue{
+			{Pair: "BRLBTC", Resolution: model.Resolution1d, Indicator: "obv", Timestamp: now, Values: map[string]decimal.NullDecimal{"value": decimal.NewNullDecimal(obv)}},
+		}))
+
+		result, err := repo.FindByPairAndTimeRange(ctx, "BRLBTC", model.Resolution1d, "obv", now, now)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, obv.String(), result[0].Values["value"].Decimal.String())
+	})
+}
+
+func TestPairRepository_Integration(t *testing.T) {
+	// Configurar banco de dados de teste
+	dbConfig := pgdb.Config{
+		Host:     "test-db",
+		Port:     "5432",
+		User:     "test_user",
+		Password: "test_password",
+		DBName:   "test_db",
+	}
+
+	db, err := pgdb.NewConnectionWithTimeout(dbConfig)
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := postgres.NewPairRepository(db, logger.NewLogger("[TEST] "))
+
+	// Manter apenas os pares semeados pela migração
+	_, err = db.Exec("DELETE FROM pairs WHERE symbol NOT IN ('BRLBTC', 'BRLETH')")
+	require.NoError(t, err)
+
+	t.Run("Create, SetEnabled e FindBySymbol", func(t *testing.T) {
+		ctx := context.Background()
+
+		require.NoError(t, repo.Create(ctx, model.RegisteredPair{Symbol: "BRLSOL", Pair: model.Pair{Base: "SOL", Quote: "BRL"}, Enabled: true}))
+		require.NoError(t, repo.SetEnabled(ctx, "BRLSOL", false))
+
+		pair, err := repo.FindBySymbol(ctx, "BRLSOL")
+		require.NoError(t, err)
+		require.NotNil(t, pair)
+		assert.False(t, pair.Enabled)
+		assert.Equal(t, "SOL", pair.Pair.Base)
+
+		missing, err := repo.FindBySymbol(ctx, "BRLXYZ")
+		require.NoError(t, err)
+		assert.Nil(t, missing)
+
+		pairs, err := repo.FindAll(ctx)
+		require.NoError(t, err)
+		assert.Len(t, pairs, 3)
+		assert.Equal(t, "BRLBTC", pairs[0].Symbol)
+	})
+}
+
+func TestCandleRepository_Integration(t *testing.T) {
+	// Configurar banco de dados de teste
+	dbConfig := pgdb.Config{
+		Host:     "test-db",
+		Port:     "5432",
+		User:     "test_user",
+		Password: "test_password",
+		DBName:   "test_db",
+	}
+
+	db, err := pgdb.NewConnectionWithTimeout(dbConfig)
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := postgres.NewCandleRepository(db, logger.NewLogger("[TEST] "))
+
+	_, err = db.Exec("TRUNCATE TABLE candles")
+	require.NoError(t, err)
+
+	t.Run("SaveBatch atualiza candles existentes e FindByPairAndTimeRange ordena por timestamp", func(t *testing.T) {
+		ctx := context.Background()
+		day := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+		require.NoError(t, repo.SaveBatch(ctx, []model.Candle{
+			{Pair: "BRLBTC", Resolution: model.Resolution1d, Timestamp: day, Open: decimal.NewFromFloat(1), High: decimal.NewFromFloat(2), Low: decimal.NewFromFloat(1), Close: decimal.NewFromFloat(2), Volume: decimal.NewFromFloat(10)},
+			{Pair: "BRLBTC", Resolution: model.Resolution1d, Timestamp: day.Add(-24 * time.Hour), Open: decimal.NewFromFloat(1), High: decimal.NewFromFloat(1), Low: decimal.NewFromFloat(1), Close: decimal.NewFromFloat(1), Volume: decimal.NewFromFloat(5)},
+			{Pair: "BRLBTC", Resolution: model.Resolution1h, Timestamp: day, Open: decimal.NewFromFloat(1), High: decimal.NewFromFloat(1), Low: decimal.NewFromFloat(1), Close: decimal.NewFromFloat(1), Volume: decimal.NewFromFloat(1)},
+		}))
+		require.NoError(t, repo.SaveBatch(ctx, []model.Candle{
+			{Pair: "BRLBTC", Resolution: model.Resolution1d, Timestamp: day, Open: decimal.NewFromFloat(1), High: decimal.NewFromFloat(3), Low: decimal.NewFromFloat(1), Close: decimal.NewFromFloat(3), Volume: decimal.NewFromFloat(12), Source: "binance"},
+		}))
+
+		result, err := repo.FindByPairAndTimeRange(ctx, "BRLBTC", model.Resolution1d, day.Add(-48*time.Hour), day)
+		require.NoError(t, err)
+
+		require.Len(t, result, 2)
+		assert.True(t, result[0].Timestamp.Before(result[1].Timestamp))
+		assert.Equal(t, day, result[1].Timestamp)
+		assert.Equal(t, "3", result[1].Close.String())
+		assert.Equal(t, "12", result[1].Volume.String())
+		assert.Equal(t, "binance", result[1].Source, "o provedor que serviu o candle deve ser registrado")
+		assert.Equal(t, "", result[0].Source)
+	})
+}
+
+func TestSignalRepository_Integration(t *testing.T) {
+	// Configurar banco de dados de teste
+	dbConfig := pgdb.Config{
+		Host:     "test-db",
+		Port:     "5432",
+		User:     "test_user",
+		Password: "test_password",
+		DBName:   "test_db",
+	}
+
+	db, err := pgdb.NewConnectionWithTimeout(dbConfig)
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := postgres.NewSignalRepository(db, logger.NewLogger("[TEST] "))
+
+	_, err = db.Exec("TRUNCATE TABLE signals")
+	require.NoError(t, err)
+
+	t.Run("SaveBatch e FindByPairAndTimeRange filtram por tipo", func(t *testing.T) {
+		ctx := context.Background()
+		day := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+		require.NoError(t, repo.SaveBatch(ctx, []model.Signal{
+			{Pair: "BRLBTC", Resolution: model.Resolution1d, Timestamp: day, Type: model.SignalGoldenCross, AverageType: model.AverageSimple, FastPeriod: 50, SlowPeriod: 200, FastValue: decimal.NewFromFloat(101), SlowValue: decimal.NewFromFloat(100)},
+			{Pair: "BRLBTC", Resolution: model.Resolution1d, Timestamp: day, Type: model.SignalPriceCrossAbove, AverageType: model.AverageSimple, FastPeriod: 0, SlowPeriod: 20, FastValue: decimal.NewFromFloat(110), SlowValue: decimal.NewFromFloat(105)},
+		}))
+
+		all, err := repo.FindByPairAndTimeRange(ctx, "BRLBTC", model.Resolution1d, day.Add(-time.Hour), day.Add(time.Hour), nil)
+		require.NoError(t, err)
+		assert.Len(t, all, 2)
+
+		golden, err := repo.FindByPairAndTimeRange(ctx, "BRLBTC", model.Resolution1d, day.Add(-time.Hour), day.Add(time.Hour), []model.SignalType{model.SignalGoldenCross})
+		require.NoError(t, err)
+		require.Len(t, golden, 1)
+		assert.Equal(t, 50, golden[0].FastPeriod)
+		assert.Equal(t, 200, golden[0].SlowPeriod)
+	})
+}
+
+func TestDivergenceRepository_Integration(t *testing.T) {
+	// Configurar banco de dados de teste
+	dbConfig := pgdb.Config{
+		Host:     "test-db",
+		Port:     "5432",
+		User:     "test_user",
+		Password: "test_password",
+		DBName:   "test_db",
+	}
+
+	db, err := pgdb.NewConnectionWithTimeout(dbConfig)
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := postgres.NewDivergenceRepository(db, logger.NewLogger("[TEST] "))
+
+	_, err = db.Exec("TRUNCATE TABLE candle_divergences")
+	require.NoError(t, err)
+
+	t.Run("SaveBatch atualiza a divergência repetida e preserva valores nulos", func(t *testing.T) {
+		ctx := context.Background()
+		day := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+		closeDivergence := model.Divergence{
+			Pair: "BRLBTC", Resolution: model.Resolution1d, Timestamp: day, Field: model.DivergenceClose,
+			Primary: "mercadobitcoin", Secondary: "binance",
+			PrimaryValue:   decimal.NewNullDecimal(decimal.NewFromFloat(100)),
+			SecondaryValue: decimal.NewNullDecimal(decimal.NewFromFloat(102)),
+			Difference:     decimal.NewFromFloat(2),
+		}
+
+		require.NoError(t, repo.SaveBatch(ctx, []model.Divergence{
+			closeDivergence,
+			{Pair: "BRLBTC", Resolution: model.Resolution1d, Timestamp: day.AddDate(0, 0, 1), Field: model.DivergenceMissing,
+				Primary: "mercadobitcoin", Secondary: "binance", PrimaryValue: decimal.NewNullDecimal(decimal.NewFromFloat(101))},
+		}))
+
+		closeDivergence.SecondaryValue = decimal.NewNullDecimal(decimal.NewFromFloat(103))
+		closeDivergence.Difference = decimal.NewFromFloat(3)
+		require.NoError(t, repo.SaveBatch(ctx, []model.Divergence{closeDivergence}))
+
+		found, err := repo.FindByPairAndTimeRange(ctx, "BRLBTC", model.Resolution1d, day, day.AddDate(0, 0, 1))
+		require.NoError(t, err)
+		require.Len(t, found, 2)
+		assert.Equal(t, model.DivergenceClose, found[0].Field)
+		assert.True(t, found[0].SecondaryValue.Decimal.Equal(decimal.NewFromFloat(103)))
+		assert.True(t, found[0].Difference.Equal(decimal.NewFromFloat(3)))
+		assert.Equal(t, model.DivergenceMissing, found[1].Field)
+		assert.False(t, found[1].SecondaryValue.Valid)
+	})
+}
@@ -35,18 +35,26 @@ func ExecuteMigrations(db *sql.DB, migrationsPath string) error {
 
 // CleanupDatabase limpa todos os dados das tabelas de teste
 func CleanupDatabase(db *sql.DB) error {
-	_, err := db.Exec("TRUNCATE TABLE mms")
+	_, err := db.Exec("TRUNCATE TABLE mms, indicator_values")
 	return err
 }
 
 // CreateTestData insere dados de teste no banco de dados
 func CreateTestData(db *sql.DB) error {
 	_, err := db.Exec(`
-		INSERT INTO mms (pair, timestamp, mms20, mms50, mms200) VALUES
-		('BRLBTC', NOW(), 150000.0, 148000.0, 145000.0),
-		('BRLBTC', NOW() - INTERVAL '1 day', 149000.0, 147000.0, 144000.0),
-		('BRLETH', NOW(), 2500.0, 2400.0, 2300.0),
-		('BRLETH', NOW() - INTERVAL '1 day', 2400.0, 2300.0, 2200.0)
+		INSERT INTO mms (pair, timestamp, type, period, value) VALUES
+		('BRLBTC', NOW(), 'sma', 20, 150000.0),
+		('BRLBTC', NOW(), 'sma', 50, 148000.0),
+		('BRLBTC', NOW(), 'sma', 200, 145000.0),
+		('BRLBTC', NOW() - INTERVAL '1 day', 'sma', 20, 149000.0),
+		('BRLBTC', NOW() - INTERVAL '1 day', 'sma', 50, 147000.0),
+		('BRLBTC', NOW() - INTERVAL '1 day', 'sma', 200, 144000.0),
+		('BRLETH', NOW(), 'sma', 20, 2500.0),
+		('BRLETH', NOW(), 'sma', 50, 2400.0),
+		('BRLETH', NOW(), 'sma', 200, 2300.0),
+		('BRLETH', NOW() - INTERVAL '1 day', 'sma', 20, 2400.0),
+		('BRLETH', NOW() - INTERVAL '1 day', 'sma', 50, 2300.0),
+		('BRLETH', NOW() - INTERVAL '1 day', 'sma', 200, 2200.0)
 	`)
 	return err
 }
@@ -101,9 +101,11 @@ func TestWorkerIntegration(t *testing.T) {
 
 	// Verificar valores específicos
 	rows, err := db.Query(`
-		SELECT pair, timestamp, mms20, mms50, mms200 
-		FROM mms 
-		WHERE pair = 'BRLBTC' 
+		SELECT pair, timestamp, period, value
+		FROM mms
+		WHERE pair = 'BRLBTC'
+		AND type = 'sma'
+		AND period = 20
 		AND timestamp BETWEEN $1 AND $2
 		ORDER BY timestamp DESC 
 		LIMIT 1
@@ -115,18 +117,16 @@ func TestWorkerIntegration(t *testing.T) {
 		var (
 			pair      string
 			timestamp time.Time
-			mms20     float64
-			mms50     float64
-			mms200    float64
+			period    int
+			value     float64
 		)
-		err := rows.Scan(&pair, &timestamp, &mms20, &mms50, &mms200)
+		err := rows.Scan(&pair, &timestamp, &period, &value)
 		require.NoError(t, err)
 
 		assert.Equal(t, "BRLBTC", pair)
 		assert.NotZero(t, timestamp)
-		assert.NotZero(t, mms20)
-		assert.NotZero(t, mms50)
-		assert.NotZero(t, mms200)
+		assert.Equal(t, 20, period)
+		assert.NotZero(t, value)
 	} else {
 		t.Error("No MMS records found in the date range")
 	}
@@ -0,0 +1,208 @@
+package binance_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"mms_api/internal/adapter/out/binance"
+	"mms_api/internal/domain/model"
+	"mms_api/pkg/httpclient"
+	"mms_api/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// klinesFixture é uma resposta no formato do endpoint /api/v3/klines (BTCBRL, 1d), com as doze colunas de cada kline
+const klinesFixture = `[
+  [1735689600000, "574123.45000000", "590000.00000000", "570001.12000000", "585432.10000000", "12.34567890", 1735775999999, "7213456.12345678", 4321, "6.10000000", "3567890.12345678", "0"],
+  [1735776000000, "585432.10000000", "601234.56000000", "580000.00000000", "598765.43210000", "15.00000001", 1735862399999, "8890123.45678901", 5123, "7.20000000", "4321098.76543210", "0"]
+]`
+
+var fixtureStart = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// klineServer simula o endpoint de klines: gera um kline diário por dia a partir de fixtureStart e
+// devolve os iniciados entre startTime e endTime, limitados a limit, registrando as consultas recebidas
+func klineServer(t *testing.T, days int) (*httptest.Server, *[]url.Values) {
+	var queries []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v3/klines", r.URL.Path)
+		query := r.URL.Query()
+		queries = append(queries, query)
+
+		startTime, _ := strconv.ParseInt(query.Get("startTime"), 10, 64)
+		endTime, _ := strconv.ParseInt(query.Get("endTime"), 10, 64)
+		limit, _ := strconv.Atoi(query.Get("limit"))
+
+		klines := [][]interface{}{}
+		for i := 0; i < days && len(klines) < limit; i++ {
+			open := fixtureStart.AddDate(0, 0, i)
+			if open.UnixMilli() < startTime || open.UnixMilli() > endTime {
+				continue
+			}
+			price := strconv.Itoa(100 + i)
+			klines = append(klines, []interface{}{
+				open.UnixMilli(), price, price, price, price, "1.5",
+				open.AddDate(0, 0, 1).UnixMilli() - 1, "150", 10, "0.7", "70", "0",
+			})
+		}
+		json.NewEncoder(w).Encode(klines)
+	}))
+	t.Cleanup(server.Close)
+	return server, &queries
+}
+
+func TestCandleAPI_Klines(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Write([]byte(klinesFixture))
+	}))
+	defer server.Close()
+
+	api := binance.NewCandleAPI(server.URL, server.Client(), logger.NewLogger("[TEST] "))
+
+	from, to := fixtureStart, fixtureStart.AddDate(0, 0, 1)
+	candles, err := api.GetCandles(context.Background(), "BRLBTC", model.Resolution1d, from, to)
+	require.NoError(t, err)
+
+	assert.Equal(t, "BTCBRL", query.Get("symbol"))
+	assert.Equal(t, "1d", query.Get("interval"))
+	assert.Equal(t, "1735689600000", query.Get("startTime"), "os horários são enviados em milissegundos")
+	assert.Equal(t, "1735776000000", query.Get("endTime"))
+	assert.Equal(t, "1000", query.Get("limit"))
+
+	require.Len(t, candles, 2)
+	assert.Equal(t, "BRLBTC", candles[0].Pair)
+	assert.Equal(t, model.Resolution1d, candles[0].Resolution)
+	assert.True(t, candles[0].Timestamp.Equal(fixtureStart))
+	assert.True(t, candles[1].Timestamp.Equal(fixtureStart.AddDate(0, 0, 1)))
+	assert.Equal(t, time.UTC, candles[0].Timestamp.Location())
+
+	// Os decimais em texto são preservados dígito a dígito até a oitava casa
+	assert.Equal(t, "574123.45", candles[0].Open.String())
+	assert.Equal(t, "590000", candles[0].High.String())
+	assert.Equal(t, "570001.12", candles[0].Low.String())
+	assert.Equal(t, "585432.1", candles[0].Close.String())
+	assert.Equal(t, "12.3456789", candles[0].Volume.String())
+	assert.Equal(t, "15.00000001", candles[1].Volume.String())
+}
+
+func TestCandleAPI_SymbolMapping(t *testing.T) {
+	tests := []struct {
+		name      string
+		pair      string
+		overrides map[string]string
+		want      string
+	}{
+		{name: "par BRL", pair: "BRLBTC", want: "BTCBRL"},
+		{name: "cotação USDT", pair: "USDTETH", want: "ETHUSDT"},
+		{name: "formato com separador", pair: "BTC-USDT", want: "BTCUSDT"},
+		{name: "símbolo específico", pair: "BRLMATIC", overrides: map[string]string{"BRLMATIC": "POLBRL"}, want: "POLBRL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, queries := klineServer(t, 1)
+			api := binance.NewCandleAPI(server.URL, server.Client(), logger.NewLogger("[TEST] "),
+				binance.WithSymbolOverrides(tt.overrides),
+			)
+
+			_, err := api.GetCandles(context.Background(), tt.pair, model.Resolution1d, fixtureStart, fixtureStart)
+			require.NoError(t, err)
+			require.Len(t, *queries, 1)
+			assert.Equal(t, tt.want, (*queries)[0].Get("symbol"))
+		})
+	}
+}
+
+func TestCandleAPI_Intervals(t *testing.T) {
+	for _, resolution := range model.Resolutions {
+		t.Run(string(resolution), func(t *testing.T) {
+			server, queries := klineServer(t, 0)
+			api := binance.NewCandleAPI(server.URL, server.Client(), logger.NewLogger("[TEST] "))
+
+			_, err := api.GetCandles(context.Background(), "BRLBTC", resolution, fixtureStart, fixtureStart.AddDate(0, 0, 7))
+			require.NoError(t, err)
+			assert.Equal(t, string(resolution), (*queries)[0].Get("interval"), "todas as resoluções têm intervalo nativo")
+		})
+	}
+}
+
+func TestCandleAPI_Pagination(t *testing.T) {
+	server, queries := klineServer(t, 25)
+	api := binance.NewCandleAPI(server.URL, server.Client(), logger.NewLogger("[TEST] "),
+		binance.WithMaxCandlesPerRequest(10),
+	)
+
+	candles, err := api.GetCandles(context.Background(), "BRLBTC", model.Resolution1d, fixtureStart, fixtureStart.AddDate(0, 0, 24))
+	require.NoError(t, err)
+
+	assert.Len(t, *queries, 3)
+	require.Len(t, candles, 25)
+	for i, c := range candles {
+		assert.True(t, c.Timestamp.Equal(fixtureStart.AddDate(0, 0, i)), "candle %d fora de ordem ou repetido", i)
+	}
+}
+
+func TestCandleAPI_MalformedResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+	}{
+		{name: "objeto em vez de lista", response: `{"code": -1121, "msg": "Invalid symbol."}`},
+		{name: "kline com colunas faltando", response: `[[1735689600000, "1", "2", "0.5"]]`},
+		{name: "horário em texto", response: `[["1735689600000", "1", "2", "0.5", "1.5", "10"]]`},
+		{name: "preço numérico", response: `[[1735689600000, 1.5, "2", "0.5", "1.5", "10"]]`},
+		{name: "preço não numérico", response: `[[1735689600000, "abc", "2", "0.5", "1.5", "10"]]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			api := binance.NewCandleAPI(server.URL, server.Client(), logger.NewLogger("[TEST] "))
+
+			candles, err := api.GetCandles(context.Background(), "BRLBTC", model.Resolution1d, fixtureStart, fixtureStart)
+			assert.Error(t, err)
+			assert.Nil(t, candles)
+		})
+	}
+}
+
+func TestCandleAPI_Errors(t *testing.T) {
+	t.Run("par inválido", func(t *testing.T) {
+		api := binance.NewCandleAPI("http://localhost", nil, logger.NewLogger("[TEST] "))
+
+		_, err := api.GetCandles(context.Background(), "XYZBTC", model.Resolution1d, fixtureStart, fixtureStart)
+		assert.Error(t, err)
+	})
+
+	t.Run("símbolo desconhecido não deve ser repetido", func(t *testing.T) {
+		var requests int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code": -1121, "msg": "Invalid symbol."}`))
+		}))
+		defer server.Close()
+
+		api := binance.NewCandleAPI(server.URL, server.Client(), logger.NewLogger("[TEST] "))
+
+		_, err := api.GetCandles(context.Background(), "BRLBTC", model.Resolution1d, fixtureStart, fixtureStart)
+		var statusErr *httpclient.StatusError
+		require.ErrorAs(t, err, &statusErr)
+		assert.Equal(t, http.StatusBadRequest, statusErr.StatusCode)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+	})
+}
@@ -0,0 +1,63 @@
+package crossover_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"mms_api/internal/domain/crossover"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// series cria timestamps diários e uma série indexada pelo timestamp Unix; NaN representa ausência de valor
+func series(start time.Time, values ...float64) ([]time.Time, map[int64]decimal.Decimal) {
+	timestamps := make([]time.Time, len(values))
+	result := make(map[int64]decimal.Decimal)
+	for i, v := range values {
+		timestamps[i] = start.AddDate(0, 0, i)
+		if !math.IsNaN(v) {
+			result[timestamps[i].Unix()] = decimal.NewFromFloat(v)
+		}
+	}
+	return timestamps, result
+}
+
+func TestDetect(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("deve detectar cruzamentos para cima e para baixo", func(t *testing.T) {
+		timestamps, fast := series(start, 1, 2, 5, 6, 3)
+		_, slow := series(start, 3, 3, 4, 4, 4)
+
+		crossings := crossover.Detect(timestamps, fast, slow)
+
+		require.Len(t, crossings, 2)
+		assert.Equal(t, start.AddDate(0, 0, 2), crossings[0].Timestamp)
+		assert.Equal(t, crossover.Up, crossings[0].Direction)
+		assert.Equal(t, "5", crossings[0].Fast.String())
+		assert.Equal(t, "4", crossings[0].Slow.String())
+		assert.Equal(t, start.AddDate(0, 0, 4), crossings[1].Timestamp)
+		assert.Equal(t, crossover.Down, crossings[1].Direction)
+	})
+
+	t.Run("não deve gerar evento quando as séries apenas se tocam", func(t *testing.T) {
+		timestamps, fast := series(start, 5, 4, 5)
+		_, slow := series(start, 4, 4, 4)
+
+		assert.Empty(t, crossover.Detect(timestamps, fast, slow))
+	})
+
+	t.Run("deve ignorar candles sem valor e não sinalizar o primeiro ponto", func(t *testing.T) {
+		timestamps, fast := series(start, 1, 5, 6)
+		_, slow := series(start, math.NaN(), 4, 7)
+
+		crossings := crossover.Detect(timestamps, fast, slow)
+
+		require.Len(t, crossings, 1)
+		assert.Equal(t, crossover.Down, crossings[0].Direction)
+		assert.Equal(t, start.AddDate(0, 0, 2), crossings[0].Timestamp)
+	})
+}
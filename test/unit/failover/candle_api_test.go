@@ -0,0 +1,171 @@
+package failover_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"mms_api/internal/adapter/out/failover"
+	"mms_api/internal/adapter/out/mock"
+	"mms_api/internal/application/port/out"
+	"mms_api/internal/domain/model"
+	"mms_api/pkg/httpclient"
+	"mms_api/pkg/logger"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	from = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to   = from.AddDate(0, 0, 4) // Cinco candles diários esperados
+)
+
+// provider cria um provedor que responde com os candles diários dos dias informados, ou com err,
+// contando as consultas recebidas
+func provider(name string, calls *int, err error, days ...int) out.CandleProvider {
+	return out.CandleProvider{Name: name, API: &mock.MockCandleAPI{
+		GetCandlesFunc: func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) ([]model.Candle, error) {
+			*calls++
+			if err != nil {
+				return nil, err
+			}
+			var candles []model.Candle
+			for _, d := range days {
+				price := decimal.NewFromInt(int64(100 + d))
+				candles = append(candles, model.Candle{
+					Pair: pair, Resolution: resolution, Timestamp: from.AddDate(0, 0, d),
+					Open: price, High: price, Low: price, Close: price, Volume: decimal.NewFromInt(1),
+				})
+			}
+			return candles, nil
+		},
+	}}
+}
+
+func sources(candles []model.Candle) map[string]int {
+	count := make(map[string]int)
+	for _, c := range candles {
+		count[c.Source]++
+	}
+	return count
+}
+
+func TestCandleAPI_GetCandles(t *testing.T) {
+	log := logger.NewLogger("[TEST] ")
+	complete := []int{0, 1, 2, 3, 4}
+
+	t.Run("deve usar apenas o provedor principal quando a resposta é completa", func(t *testing.T) {
+		var primaryCalls, secondaryCalls int
+		api := failover.NewCandleAPI([]out.CandleProvider{
+			provider("mercadobitcoin", &primaryCalls, nil, complete...),
+			provider("binance", &secondaryCalls, nil, complete...),
+		}, log)
+
+		candles, err := api.GetCandles(context.Background(), "BRLBTC", model.Resolution1d, from, to)
+		require.NoError(t, err)
+		assert.Len(t, candles, 5)
+		assert.Equal(t, map[string]int{"mercadobitcoin": 5}, sources(candles))
+		assert.Equal(t, 1, primaryCalls)
+		assert.Equal(t, 0, secondaryCalls)
+	})
+
+	t.Run("deve recorrer ao provedor seguinte quando o principal falha", func(t *testing.T) {
+		var primaryCalls, secondaryCalls int
+		api := failover.NewCandleAPI([]out.CandleProvider{
+			provider("mercadobitcoin", &primaryCalls, httpclient.ErrCircuitOpen),
+			provider("binance", &secondaryCalls, nil, complete...),
+		}, log)
+
+		candles, err := api.GetCandles(context.Background(), "BRLBTC", model.Resolution1d, from, to)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"binance": 5}, sources(candles))
+		assert.Equal(t, 1, secondaryCalls)
+	})
+
+	t.Run("deve recorrer ao provedor seguinte quando a resposta está incompleta", func(t *testing.T) {
+		var primaryCalls, secondaryCalls int
+		api := failover.NewCandleAPI([]out.CandleProvider{
+			provider("mercadobitcoin", &primaryCalls, nil, 0, 1, 2, 3),
+			provider("binance", &secondaryCalls, nil, complete...),
+		}, log)
+
+		candles, err := api.GetCandles(context.Background(), "BRLBTC", model.Resolution1d, from, to)
+		require.NoError(t, err)
+		assert.Len(t, candles, 5)
+		assert.Equal(t, map[string]int{"binance": 5}, sources(candles))
+	})
+
+	t.Run("deve manter a resposta mais completa quando nenhuma está completa", func(t *testing.T) {
+		var primaryCalls, secondaryCalls, tertiaryCalls int
+		api := failover.NewCandleAPI([]out.CandleProvider{
+			provider("mercadobitcoin", &primaryCalls, nil, 3, 4),
+			provider("binance", &secondaryCalls, errors.New("timeout")),
+			provider("outro", &tertiaryCalls, nil, 4),
+		}, log)
+
+		candles, err := api.GetCandles(context.Background(), "BRLBTC", model.Resolution1d, from, to)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"mercadobitcoin": 2}, sources(candles))
+		assert.Equal(t, 1, tertiaryCalls, "todos os provedores devem ser consultados")
+	})
+
+	t.Run("repetições e candles fora do intervalo não completam a resposta", func(t *testing.T) {
+		var primaryCalls, secondaryCalls int
+		api := failover.NewCandleAPI([]out.CandleProvider{
+			provider("mercadobitcoin", &primaryCalls, nil, 0, 1, 1, 2, 3, 5),
+			provider("binance", &secondaryCalls, nil, complete...),
+		}, log)
+
+		candles, err := api.GetCandles(context.Background(), "BRLBTC", model.Resolution1d, from, to)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"binance": 5}, sources(candles))
+	})
+
+	t.Run("deve falhar com os erros de todos os provedores", func(t *testing.T) {
+		var primaryCalls, secondaryCalls int
+		api := failover.NewCandleAPI([]out.CandleProvider{
+			provider("mercadobitcoin", &primaryCalls, httpclient.ErrCircuitOpen),
+			provider("binance", &secondaryCalls, &httpclient.StatusError{StatusCode: 503}),
+		}, log)
+
+		candles, err := api.GetCandles(context.Background(), "BRLBTC", model.Resolution1d, from, to)
+		assert.Nil(t, candles)
+		assert.ErrorIs(t, err, httpclient.ErrCircuitOpen)
+		var statusErr *httpclient.StatusError
+		assert.ErrorAs(t, err, &statusErr)
+		assert.Contains(t, err.Error(), "mercadobitcoin")
+		assert.Contains(t, err.Error(), "binance")
+	})
+
+	t.Run("o cancelamento do contexto interrompe a cadeia", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var primaryCalls, secondaryCalls int
+		api := failover.NewCandleAPI([]out.CandleProvider{
+			provider("mercadobitcoin", &primaryCalls, context.Canceled),
+			provider("binance", &secondaryCalls, nil, complete...),
+		}, log)
+
+		_, err := api.GetCandles(ctx, "BRLBTC", model.Resolution1d, from, to)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 0, secondaryCalls)
+	})
+
+	t.Run("intervalo sem candle completo da resolução", func(t *testing.T) {
+		var primaryCalls, secondaryCalls int
+		api := failover.NewCandleAPI([]out.CandleProvider{
+			provider("mercadobitcoin", &primaryCalls, nil),
+			provider("binance", &secondaryCalls, nil),
+		}, log)
+
+		// Nenhum candle semanal começa entre quarta e sexta-feira
+		candles, err := api.GetCandles(context.Background(), "BRLBTC", model.Resolution1w, from, from.AddDate(0, 0, 2))
+		require.NoError(t, err)
+		assert.Empty(t, candles)
+		assert.Equal(t, 0, secondaryCalls)
+	})
+}
@@ -0,0 +1,178 @@
+package file_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"mms_api/config"
+	"mms_api/internal/adapter/out/file"
+	"mms_api/internal/bootstrap"
+	"mms_api/internal/domain/model"
+	"mms_api/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	log  = logger.NewLogger("[TEST] ")
+	from = time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	to   = time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
+)
+
+// writeFile grava o conteúdo no arquivo name de um diretório temporário, retornando o diretório
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	return dir
+}
+
+func timestamps(candles []model.Candle) []time.Time {
+	result := make([]time.Time, len(candles))
+	for i, c := range candles {
+		result[i] = c.Timestamp
+	}
+	return result
+}
+
+func TestCandleAPI_GetCandles_CSV(t *testing.T) {
+	t.Run("deve ler as colunas pelo cabeçalho e filtrar o intervalo", func(t *testing.T) {
+		dir := writeFile(t, "BRLBTC_1d.csv", "volume,close,low,high,open,timestamp\n"+
+			"10,100,90,110,95,2025-01-01\n"+
+			"12.5,101.123456789,91,111,96,2025-01-03\n"+
+			"11,99,89,109,94,2025-01-02\n"+
+			"9,98,88,108,93,2025-01-04\n")
+		api := file.NewCandleAPI(dir, log)
+
+		candles, err := api.GetCandles(context.Background(), "BRLBTC", model.Resolution1d, from, to)
+		require.NoError(t, err)
+		require.Len(t, candles, 2)
+		assert.Equal(t, []time.Time{to, from}, timestamps(candles), "a ordem do arquivo deve ser preservada")
+
+		c := candles[0]
+		assert.Equal(t, "BRLBTC", c.Pair)
+		assert.Equal(t, model.Resolution1d, c.Resolution)
+		assert.Equal(t, "96", c.Open.String())
+		assert.Equal(t, "111", c.High.String())
+		assert.Equal(t, "91", c.Low.String())
+		assert.Equal(t, "101.12345679", c.Close.String(), "valores arredondados às casas persistidas")
+		assert.Equal(t, "12.5", c.Volume.String())
+	})
+
+	t.Run("deve aplicar o mapeamento de colunas, o layout e o fuso", func(t *testing.T) {
+		dir := writeFile(t, "BRLETH_1h.csv", "Date,O,H,L,C,Vol,Trades\n"+
+			"02/01/2025 21:00,1,2,0.5,1.5,100,7\n")
+		columns, err := file.ParseColumns(map[string]string{
+			"timestamp": "date", "open": "O", "high": "H", "low": "L", "close": "C", "volume": "Vol",
+		})
+		require.NoError(t, err)
+		saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+		require.NoError(t, err)
+
+		api := file.NewCandleAPI(dir, log,
+			file.WithColumns(columns),
+			file.WithTimeLayout("02/01/2006 15:04"),
+			file.WithLocation(saoPaulo),
+		)
+
+		// O par em qualquer formato aceito localiza o arquivo pelo símbolo canônico
+		candles, err := api.GetCandles(context.Background(), "ETH-BRL", model.Resolution1h, from, to)
+		require.NoError(t, err)
+		require.Len(t, candles, 1)
+		assert.Equal(t, time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC), candles[0].Timestamp)
+		assert.Equal(t, time.UTC, candles[0].Timestamp.Location())
+	})
+
+	t.Run("deve falhar com a linha do valor inválido", func(t *testing.T) {
+		dir := writeFile(t, "BRLBTC_1d.csv", "timestamp,open,high,low,close,volume\n"+
+			"2025-01-02,1,2,0.5,1.5,100\n"+
+			"2025-01-03,1,2,0.5,abc,100\n")
+		api := file.NewCandleAPI(dir, log)
+
+		_, err := api.GetCandles(context.Background(), "BRLBTC", model.Resolution1d, from, to)
+		assert.ErrorContains(t, err, "linha 3")
+		assert.ErrorContains(t, err, "close")
+	})
+
+	t.Run("deve falhar quando falta uma coluna no cabeçalho", func(t *testing.T) {
+		dir := writeFile(t, "BRLBTC_1d.csv", "timestamp,open,high,low,close\n")
+		api := file.NewCandleAPI(dir, log)
+
+		_, err := api.GetCandles(context.Background(), "BRLBTC", model.Resolution1d, from, to)
+		assert.ErrorContains(t, err, `coluna "volume" ausente`)
+	})
+
+	t.Run("deve falhar quando o arquivo não existe", func(t *testing.T) {
+		api := file.NewCandleAPI(t.TempDir(), log)
+
+		_, err := api.GetCandles(context.Background(), "BRLBTC", model.Resolution1d, from, to)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestCandleAPI_GetCandles_JSONL(t *testing.T) {
+	dir := writeFile(t, "BRLBTC_1d.jsonl", `{"t": 1735689600000, "open": 1, "high": 2, "low": 0.5, "close": 1.5, "volume": 100}`+"\n"+
+		"\n"+
+		`{"t": 1735776000000, "open": "512345.67000000", "high": 2, "low": 0.5, "close": 0.1, "volume": 100}`+"\n"+
+		`{"t": "2025-01-03T00:00:00Z", "open": 1, "high": 2, "low": 0.5, "close": 1.5, "volume": 100}`+"\n")
+	columns, err := file.ParseColumns(map[string]string{"timestamp": "t"})
+	require.NoError(t, err)
+	api := file.NewCandleAPI(dir, log, file.WithFormat(file.FormatJSONL), file.WithColumns(columns))
+
+	candles, err := api.GetCandles(context.Background(), "BRLBTC", model.Resolution1d, from, to)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{from, to}, timestamps(candles))
+	assert.Equal(t, "512345.67", candles[0].Open.String())
+	assert.Equal(t, "0.1", candles[0].Close.String(), "números lidos sem ponto flutuante")
+
+	t.Run("chave ausente", func(t *testing.T) {
+		dir := writeFile(t, "BRLBTC_1d.jsonl", `{"timestamp": 1735776000, "open": 1, "high": 2, "low": 0.5, "close": 1.5}`+"\n")
+		api := file.NewCandleAPI(dir, log, file.WithFormat(file.FormatJSONL))
+
+		_, err := api.GetCandles(context.Background(), "BRLBTC", model.Resolution1d, from, to)
+		assert.ErrorContains(t, err, "linha 1")
+		assert.ErrorContains(t, err, `chave "volume" ausente`)
+	})
+}
+
+func TestParseColumnsAndFormat(t *testing.T) {
+	_, err := file.ParseColumns(map[string]string{"price": "close_price"})
+	assert.Error(t, err)
+
+	_, err = file.ParseColumns(map[string]string{"close": " "})
+	assert.Error(t, err)
+
+	format, err := file.ParseFormat("JSONL")
+	require.NoError(t, err)
+	assert.Equal(t, file.FormatJSONL, format)
+
+	_, err = file.ParseFormat("parquet")
+	assert.Error(t, err)
+}
+
+func TestNewCandleAPI_FileProvider(t *testing.T) {
+	dir := writeFile(t, "BRLBTC_1d.csv", "timestamp,open,high,low,close,volume\n2025-01-02,1,2,0.5,1.5,100\n")
+	cfg := &config.Config{
+		CandleProviders:    []string{config.ProviderFile},
+		CandleFileDir:      dir,
+		CandleFileFormat:   "csv",
+		CandleFileTimezone: "UTC",
+	}
+
+	api, breakers, err := bootstrap.NewCandleAPI(cfg, log, nil)
+	require.NoError(t, err)
+	assert.Empty(t, breakers, "arquivos locais não têm circuit breaker")
+
+	candles, err := api.GetCandles(context.Background(), "BRLBTC", model.Resolution1d, from, to)
+	require.NoError(t, err)
+	require.Len(t, candles, 1)
+	assert.Equal(t, config.ProviderFile, candles[0].Source)
+
+	cfg.CandleFileTimezone = "America/Atlantida"
+	_, _, err = bootstrap.NewCandleAPI(cfg, log, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,74 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpAdapter "mms_api/internal/adapter/in/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubHandlers responde 200 em todas as rotas, registrando se o handler de pares foi chamado
+type stubHandlers struct {
+	pairCalls int
+}
+
+func (h *stubHandlers) GetMMSByPair(c *gin.Context)       { c.Status(http.StatusOK) }
+func (h *stubHandlers) GetIndicatorByPair(c *gin.Context) { c.Status(http.StatusOK) }
+func (h *stubHandlers) GetBollingerBands(c *gin.Context)  { c.Status(http.StatusOK) }
+func (h *stubHandlers) GetSignalsByPair(c *gin.Context)   { c.Status(http.StatusOK) }
+func (h *stubHandlers) ListPairs(c *gin.Context)          { h.pairCalls++; c.Status(http.StatusOK) }
+func (h *stubHandlers) CreatePair(c *gin.Context)         { h.pairCalls++; c.Status(http.StatusOK) }
+func (h *stubHandlers) UpdatePair(c *gin.Context)         { h.pairCalls++; c.Status(http.StatusOK) }
+
+func serve(adminToken, method, path, token string) (*httptest.ResponseRecorder, *stubHandlers) {
+	gin.SetMode(gin.TestMode)
+	h := &stubHandlers{}
+	engine := httpAdapter.NewRouter(h, h, h, h, adminToken).SetupRoutes()
+
+	req := httptest.NewRequest(method, path, nil)
+	if token != "" {
+		req.Header.Set("X-Admin-Token", token)
+	}
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	return w, h
+}
+
+func TestRouter_AdminRoutes(t *testing.T) {
+	t.Run("sem ADMIN_TOKEN as rotas de administração ficam desativadas", func(t *testing.T) {
+		for _, method := range []string{http.MethodGet, http.MethodPost} {
+			w, h := serve("", method, "/api/v1/admin/pairs", "")
+			assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+			assert.Equal(t, 0, h.pairCalls)
+		}
+
+		w, h := serve("", http.MethodPatch, "/api/v1/admin/pairs/BRLBTC", "qualquer")
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, 0, h.pairCalls)
+	})
+
+	t.Run("token ausente ou incorreto é recusado", func(t *testing.T) {
+		w, h := serve("segredo", http.MethodPost, "/api/v1/admin/pairs", "")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, 0, h.pairCalls)
+
+		w, h = serve("segredo", http.MethodPost, "/api/v1/admin/pairs", "errado")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, 0, h.pairCalls)
+	})
+
+	t.Run("token correto chega ao handler", func(t *testing.T) {
+		w, h := serve("segredo", http.MethodPost, "/api/v1/admin/pairs", "segredo")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 1, h.pairCalls)
+	})
+
+	t.Run("rotas públicas não exigem token", func(t *testing.T) {
+		w, _ := serve("", http.MethodGet, "/api/v1/BRLBTC/mms", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
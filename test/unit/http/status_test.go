@@ -0,0 +1,74 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	httpAdapter "mms_api/internal/adapter/in/http"
+	"mms_api/pkg/httpclient"
+	"mms_api/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func get(engine *gin.Engine, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	return w
+}
+
+func TestStatusRouter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	config := httpclient.CircuitBreakerConfig{FailureThreshold: 2, OpenTimeout: time.Hour, HalfOpenProbes: 1}
+	primary := httpclient.NewCircuitBreaker("mercadobitcoin", config, logger.NewLogger("[TEST] "), nil)
+	secondary := httpclient.NewCircuitBreaker("binance", config, logger.NewLogger("[TEST] "), nil)
+
+	// Duas falhas consecutivas abrem o circuito do provedor principal
+	for i := 0; i < 2; i++ {
+		primary.Execute(context.Background(), func(ctx context.Context) error {
+			return &httpclient.StatusError{StatusCode: http.StatusServiceUnavailable}
+		})
+	}
+
+	engine := httpAdapter.NewStatusRouter(primary, secondary).SetupRoutes()
+
+	t.Run("health deve informar os circuitos e o status degradado", func(t *testing.T) {
+		w := get(engine, "/health")
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var body struct {
+			Status   string                    `json:"status"`
+			Circuits []httpclient.CircuitStats `json:"circuits"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, "degraded", body.Status)
+		require.Len(t, body.Circuits, 2)
+		assert.Equal(t, httpclient.CircuitOpen, body.Circuits[0].State)
+		assert.Equal(t, httpclient.CircuitClosed, body.Circuits[1].State)
+	})
+
+	t.Run("metrics deve expor o estado no formato do Prometheus", func(t *testing.T) {
+		w := get(engine, "/metrics")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "text/plain")
+
+		body := w.Body.String()
+		assert.Contains(t, body, "# TYPE mms_circuit_breaker_state gauge\n")
+		assert.Contains(t, body, `mms_circuit_breaker_state{provider="mercadobitcoin",state="open"} 1`)
+		assert.Contains(t, body, `mms_circuit_breaker_state{provider="mercadobitcoin",state="closed"} 0`)
+		assert.Contains(t, body, `mms_circuit_breaker_state{provider="binance",state="closed"} 1`)
+		assert.Contains(t, body, `mms_circuit_breaker_consecutive_failures{provider="mercadobitcoin"} 2`)
+		assert.Contains(t, body, `mms_circuit_breaker_opens_total{provider="mercadobitcoin"} 1`)
+	})
+
+	t.Run("sem provedores com circuit breaker o worker fica saudável", func(t *testing.T) {
+		w := get(httpAdapter.NewStatusRouter().SetupRoutes(), "/health")
+		assert.Contains(t, w.Body.String(), `"status":"healthy"`)
+	})
+}
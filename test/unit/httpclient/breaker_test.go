@@ -0,0 +1,159 @@
+package httpclient_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"mms_api/pkg/httpclient"
+	"mms_api/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// alertRecorder registra os alertas enviados
+type alertRecorder struct {
+	mu     sync.Mutex
+	alerts []string
+}
+
+func (r *alertRecorder) SendAlert(alertType string, message string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.alerts = append(r.alerts, alertType)
+}
+
+var (
+	errUnavailable = &httpclient.StatusError{StatusCode: http.StatusServiceUnavailable}
+	errBadRequest  = &httpclient.StatusError{StatusCode: http.StatusBadRequest}
+)
+
+func call(b *httpclient.CircuitBreaker, result error) (called bool, err error) {
+	err = b.Execute(context.Background(), func(ctx context.Context) error {
+		called = true
+		return result
+	})
+	return called, err
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	config := httpclient.CircuitBreakerConfig{FailureThreshold: 3, OpenTimeout: 50 * time.Millisecond, HalfOpenProbes: 1}
+
+	t.Run("deve abrir após as falhas consecutivas e alertar", func(t *testing.T) {
+		alerts := &alertRecorder{}
+		b := httpclient.NewCircuitBreaker("mercadobitcoin", config, logger.NewLogger("[TEST] "), alerts)
+
+		for i := 0; i < 3; i++ {
+			call(b, errUnavailable)
+		}
+		assert.Equal(t, httpclient.CircuitOpen, b.State())
+		assert.Equal(t, []string{"circuito_aberto"}, alerts.alerts)
+
+		called, err := call(b, nil)
+		assert.False(t, called, "com o circuito aberto o provedor não deve ser chamado")
+		assert.True(t, errors.Is(err, httpclient.ErrCircuitOpen))
+
+		stats := b.Stats()
+		assert.Equal(t, 1, stats.Opens)
+		assert.Equal(t, 1, stats.Rejected)
+		assert.NotNil(t, stats.OpenedAt)
+	})
+
+	t.Run("um sucesso deve zerar as falhas consecutivas", func(t *testing.T) {
+		b := httpclient.NewCircuitBreaker("mercadobitcoin", config, logger.NewLogger("[TEST] "), nil)
+
+		call(b, errUnavailable)
+		call(b, errUnavailable)
+		call(b, nil)
+		call(b, errUnavailable)
+		call(b, errUnavailable)
+		assert.Equal(t, httpclient.CircuitClosed, b.State())
+	})
+
+	t.Run("não deve contar respostas 4xx nem o cancelamento pelo chamador", func(t *testing.T) {
+		b := httpclient.NewCircuitBreaker("mercadobitcoin", config, logger.NewLogger("[TEST] "), nil)
+
+		for i := 0; i < 5; i++ {
+			call(b, errBadRequest)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		for i := 0; i < 5; i++ {
+			b.Execute(ctx, func(ctx context.Context) error { return ctx.Err() })
+		}
+		assert.Equal(t, httpclient.CircuitClosed, b.State())
+		assert.Equal(t, 0, b.Stats().ConsecutiveFailures)
+	})
+
+	t.Run("deve fechar quando a chamada de teste funciona", func(t *testing.T) {
+		b := httpclient.NewCircuitBreaker("mercadobitcoin", config, logger.NewLogger("[TEST] "), nil)
+		for i := 0; i < 3; i++ {
+			call(b, errUnavailable)
+		}
+
+		time.Sleep(config.OpenTimeout)
+		assert.Equal(t, httpclient.CircuitHalfOpen, b.State())
+
+		called, err := call(b, nil)
+		require.NoError(t, err)
+		assert.True(t, called)
+		assert.Equal(t, httpclient.CircuitClosed, b.State())
+	})
+
+	t.Run("deve reabrir quando a chamada de teste falha", func(t *testing.T) {
+		alerts := &alertRecorder{}
+		b := httpclient.NewCircuitBreaker("mercadobitcoin", config, logger.NewLogger("[TEST] "), alerts)
+		for i := 0; i < 3; i++ {
+			call(b, errUnavailable)
+		}
+
+		time.Sleep(config.OpenTimeout)
+		called, _ := call(b, errUnavailable)
+		assert.True(t, called)
+		assert.Equal(t, httpclient.CircuitOpen, b.State())
+		assert.Equal(t, 2, b.Stats().Opens)
+		assert.Len(t, alerts.alerts, 2)
+	})
+
+	t.Run("deve liberar apenas as chamadas de teste configuradas", func(t *testing.T) {
+		b := httpclient.NewCircuitBreaker("mercadobitcoin", config, logger.NewLogger("[TEST] "), nil)
+		for i := 0; i < 3; i++ {
+			call(b, errUnavailable)
+		}
+		time.Sleep(config.OpenTimeout)
+
+		release := make(chan struct{})
+		probing := make(chan struct{})
+		done := make(chan error)
+		go func() {
+			done <- b.Execute(context.Background(), func(ctx context.Context) error {
+				close(probing)
+				<-release
+				return nil
+			})
+		}()
+		<-probing
+
+		called, err := call(b, nil)
+		assert.False(t, called, "a segunda chamada deve esperar o resultado da chamada de teste")
+		assert.True(t, errors.Is(err, httpclient.ErrCircuitOpen))
+
+		close(release)
+		require.NoError(t, <-done)
+		assert.Equal(t, httpclient.CircuitClosed, b.State())
+	})
+
+	t.Run("um limite zero deve desativar o circuito", func(t *testing.T) {
+		b := httpclient.NewCircuitBreaker("mercadobitcoin", httpclient.CircuitBreakerConfig{}, logger.NewLogger("[TEST] "), nil)
+		for i := 0; i < 10; i++ {
+			call(b, errUnavailable)
+		}
+		called, _ := call(b, nil)
+		assert.True(t, called)
+		assert.Equal(t, httpclient.CircuitClosed, b.State())
+	})
+}
@@ -0,0 +1,58 @@
+package httpclient_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"mms_api/pkg/httpclient"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	t.Run("deve liberar a rajada e então espaçar as requisições", func(t *testing.T) {
+		limiter := httpclient.NewRateLimiter(20, 2)
+
+		began := time.Now()
+		for i := 0; i < 4; i++ {
+			require.NoError(t, limiter.Wait(context.Background()))
+		}
+		// Duas fichas da rajada e duas reabastecidas a 20 por segundo (50ms cada)
+		assert.GreaterOrEqual(t, time.Since(began), 90*time.Millisecond)
+	})
+
+	t.Run("deve interromper a espera quando o contexto é cancelado", func(t *testing.T) {
+		limiter := httpclient.NewRateLimiter(0.1, 1)
+		require.NoError(t, limiter.Wait(context.Background()))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		assert.True(t, errors.Is(limiter.Wait(ctx), context.DeadlineExceeded))
+	})
+
+	t.Run("um limite zero deve liberar todas as requisições", func(t *testing.T) {
+		limiter := httpclient.NewRateLimiter(0, 0)
+		assert.Nil(t, limiter)
+		assert.NoError(t, limiter.Wait(context.Background()))
+	})
+
+	t.Run("deve limitar as requisições de um cliente", func(t *testing.T) {
+		server, requests := sequenceServer(t, nil, http.StatusOK)
+		client := httpclient.WithRateLimit(server.Client(), httpclient.NewRateLimiter(0.1, 1))
+
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		_, err = client.Do(req)
+		assert.Error(t, err)
+		assert.Equal(t, int32(1), *requests, "a segunda requisição não deve chegar ao servidor")
+	})
+}
@@ -0,0 +1,130 @@
+package httpclient_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"mms_api/pkg/httpclient"
+	"mms_api/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sequenceServer responde cada requisição com o próximo status da sequência, repetindo o último
+func sequenceServer(t *testing.T, header http.Header, statuses ...int) (*httptest.Server, *int32) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&requests, 1))
+		status := statuses[len(statuses)-1]
+		if n <= len(statuses) {
+			status = statuses[n-1]
+		}
+		for key, values := range header {
+			w.Header()[key] = values
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func get(t *testing.T, ctx context.Context, policy httpclient.RetryPolicy, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	return policy.Do(http.DefaultClient, req, logger.NewLogger("[TEST] "))
+}
+
+var fast = httpclient.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}
+
+func TestRetryPolicy_Do(t *testing.T) {
+	t.Run("deve repetir respostas 5xx até obter sucesso", func(t *testing.T) {
+		server, requests := sequenceServer(t, nil, http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK)
+
+		resp, err := get(t, context.Background(), fast, server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, int32(3), atomic.LoadInt32(requests))
+	})
+
+	t.Run("não deve repetir respostas 4xx", func(t *testing.T) {
+		server, requests := sequenceServer(t, nil, http.StatusBadRequest)
+
+		_, err := get(t, context.Background(), fast, server.URL)
+		var statusErr *httpclient.StatusError
+		require.True(t, errors.As(err, &statusErr))
+		assert.Equal(t, http.StatusBadRequest, statusErr.StatusCode)
+		assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+	})
+
+	t.Run("deve desistir após o número máximo de tentativas", func(t *testing.T) {
+		server, requests := sequenceServer(t, nil, http.StatusInternalServerError)
+
+		_, err := get(t, context.Background(), fast, server.URL)
+		var statusErr *httpclient.StatusError
+		require.True(t, errors.As(err, &statusErr))
+		assert.Equal(t, http.StatusInternalServerError, statusErr.StatusCode)
+		assert.Contains(t, err.Error(), "após 3 tentativas")
+		assert.Equal(t, int32(3), atomic.LoadInt32(requests))
+	})
+
+	t.Run("deve esperar o Retry-After de uma resposta 429", func(t *testing.T) {
+		server, requests := sequenceServer(t, http.Header{"Retry-After": {"1"}}, http.StatusTooManyRequests, http.StatusOK)
+		policy := httpclient.RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Second}
+
+		began := time.Now()
+		resp, err := get(t, context.Background(), policy, server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.GreaterOrEqual(t, time.Since(began), time.Second)
+		assert.Equal(t, int32(2), atomic.LoadInt32(requests))
+	})
+
+	t.Run("deve desistir quando o Retry-After excede a espera máxima", func(t *testing.T) {
+		server, requests := sequenceServer(t, http.Header{"Retry-After": {"120"}}, http.StatusTooManyRequests, http.StatusOK)
+
+		_, err := get(t, context.Background(), fast, server.URL)
+		var statusErr *httpclient.StatusError
+		require.True(t, errors.As(err, &statusErr))
+		assert.Equal(t, 120*time.Second, statusErr.RetryAfter)
+		assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+	})
+
+	t.Run("deve repetir falhas de rede", func(t *testing.T) {
+		var requests int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&requests, 1) == 1 {
+				conn, _, err := w.(http.Hijacker).Hijack()
+				require.NoError(t, err)
+				conn.Close()
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		resp, err := get(t, context.Background(), fast, server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("deve interromper a espera quando o contexto é cancelado", func(t *testing.T) {
+		server, requests := sequenceServer(t, http.Header{"Retry-After": {"5"}}, http.StatusTooManyRequests)
+		policy := httpclient.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Second}
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		began := time.Now()
+		_, err := get(t, ctx, policy, server.URL)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.Less(t, time.Since(began), time.Second)
+		assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+	})
+}
@@ -0,0 +1,297 @@
+package indicator_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"mms_api/internal/domain/indicator"
+	"mms_api/internal/domain/indicator/atr"
+	"mms_api/internal/domain/indicator/bollinger"
+	"mms_api/internal/domain/indicator/dailyrange"
+	"mms_api/internal/domain/indicator/ema"
+	"mms_api/internal/domain/indicator/macd"
+	"mms_api/internal/domain/indicator/obv"
+	"mms_api/internal/domain/indicator/rsi"
+	"mms_api/internal/domain/indicator/sma"
+	"mms_api/internal/domain/indicator/volatility"
+	"mms_api/internal/domain/indicator/vwap"
+	"mms_api/internal/domain/model"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// candlesFromCloses gera candles diários a partir dos fechamentos informados
+func candlesFromCloses(closes ...float64) []model.Candle {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	candles := make([]model.Candle, len(closes))
+	for i, c := range closes {
+		candles[i] = model.Candle{
+			Pair:      "BRLBTC",
+			Timestamp: start.AddDate(0, 0, i),
+			Open:      decimal.NewFromFloat(c),
+			High:      decimal.NewFromFloat(c),
+			Low:       decimal.NewFromFloat(c),
+			Close:     decimal.NewFromFloat(c),
+			Volume:    decimal.NewFromFloat(1),
+		}
+	}
+	return candles
+}
+
+func TestRegistry(t *testing.T) {
+	t.Run("deve registrar indicadores e calcular o maior lookback", func(t *testing.T) {
+		registry, err := indicator.NewRegistry(sma.New(50), ema.New(20), sma.New(200))
+		require.NoError(t, err)
+
+		assert.Equal(t, 200, registry.Lookback())
+		assert.Equal(t, []string{"ema20", "sma200", "sma50"}, registry.Names())
+		assert.Len(t, registry.All(), 3)
+
+		ind, ok := registry.Get("ema20")
+		assert.True(t, ok)
+		assert.Equal(t, 20, ind.Lookback())
+
+		_, ok = registry.Get("rsi14")
+		assert.False(t, ok)
+	})
+
+	t.Run("deve rejeitar nomes duplicados", func(t *testing.T) {
+		_, err := indicator.NewRegistry(sma.New(20), sma.New(20))
+		assert.Error(t, err)
+	})
+
+	t.Run("deve rejeitar lookback inválido", func(t *testing.T) {
+		registry, err := indicator.NewRegistry()
+		require.NoError(t, err)
+		assert.Error(t, registry.Register(sma.New(0)))
+	})
+}
+
+func TestSMA(t *testing.T) {
+	values := sma.New(3).Compute(candlesFromCloses(1, 2, 3, 4, 5))
+
+	require.Len(t, values, 3)
+	assert.Equal(t, "sma3", values[0].Indicator)
+	assert.Equal(t, "BRLBTC", values[0].Pair)
+	assert.Equal(t, time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC), values[0].Timestamp)
+	assert.Equal(t, "2", values[0].Values[indicator.SeriesValue].Decimal.String())
+	assert.Equal(t, "4", values[2].Values[indicator.SeriesValue].Decimal.String())
+
+	t.Run("deve calcular médias decimais exatas", func(t *testing.T) {
+		candles := candlesFromCloses(0, 0, 0)
+		for i, v := range []string{"0.1", "0.2", "0.30000001"} {
+			candles[i].Close = decimal.RequireFromString(v)
+		}
+
+		averages := sma.New(2).Averages(candles)
+
+		require.Len(t, averages, 2)
+		assert.Equal(t, candles[1].Timestamp, averages[0].Timestamp)
+		assert.Equal(t, "0.15", averages[0].Value.String())
+		assert.Equal(t, "0.25000001", averages[1].Value.String())
+	})
+}
+
+func TestEMA(t *testing.T) {
+	t.Run("deve semear com a média simples e suavizar por 2/(N+1)", func(t *testing.T) {
+		values := ema.New(3).Compute(candlesFromCloses(2, 4, 6, 10, 20, 8))
+
+		require.Len(t, values, 4)
+		assert.Equal(t, "4", values[0].Values[indicator.SeriesValue].Decimal.String())
+		assert.Equal(t, "7", values[1].Values[indicator.SeriesValue].Decimal.String())
+		assert.Equal(t, "13.5", values[2].Values[indicator.SeriesValue].Decimal.String())
+		assert.Equal(t, "10.75", values[3].Values[indicator.SeriesValue].Decimal.String())
+	})
+
+	t.Run("deve arredondar a saída em oito casas decimais", func(t *testing.T) {
+		averages := ema.New(2).Averages(candlesFromCloses(1, 2, 2))
+
+		require.Len(t, averages, 2)
+		assert.Equal(t, "1.5", averages[0].Value.String())
+		assert.Equal(t, "1.83333333", averages[1].Value.String()) // 1.5 + (2 - 1.5) * 2/3
+	})
+
+	t.Run("deve retornar vazio sem histórico suficiente", func(t *testing.T) {
+		assert.Empty(t, ema.New(10).Compute(candlesFromCloses(1, 2, 3)))
+	})
+}
+
+func TestBollinger(t *testing.T) {
+	// Janela 2, 4, 4, 4, 5, 5, 7, 9: média 5 e desvio padrão populacional 2
+	values := bollinger.New(8).Compute(candlesFromCloses(2, 4, 4, 4, 5, 5, 7, 9))
+
+	require.Len(t, values, 1)
+	assert.Equal(t, "bollinger8", values[0].Indicator)
+	assert.Equal(t, "2", values[0].Values[bollinger.SeriesStdDev].Decimal.String())
+	assert.NotContains(t, values[0].Values, "middle", "a banda do meio é a sma persistida na tabela mms")
+
+	band := bollinger.Bands(values[0], decimal.NewFromInt(5), 1.5)
+	assert.Equal(t, "5", band.Middle.String())
+	assert.Equal(t, "8", band.Upper.String())
+	assert.Equal(t, "2", band.Lower.String())
+	assert.Equal(t, "1.2", band.Width.String())
+
+	t.Run("o desvio padrão deve acompanhar as janelas da sma de igual período", func(t *testing.T) {
+		candles := benchmarkCandles(500)
+
+		values := bollinger.New(20).Compute(candles)
+		averages := sma.New(20).Averages(candles)
+
+		require.Len(t, values, len(averages))
+		for i, v := range values {
+			assert.Equal(t, averages[i].Timestamp, v.Timestamp)
+		}
+
+		// Desvio padrão conferido contra o cálculo direto da última janela
+		last := candles[len(candles)-20:]
+		mean := averages[len(averages)-1].Value.InexactFloat64()
+		var variance float64
+		for _, c := range last {
+			d := c.Close.InexactFloat64() - mean
+			variance += d * d
+		}
+		expected := math.Sqrt(variance / 20)
+		assert.InEpsilon(t, expected, values[len(values)-1].Values[bollinger.SeriesStdDev].Decimal.InexactFloat64(), 1e-6)
+	})
+
+	t.Run("deve retornar vazio sem histórico suficiente", func(t *testing.T) {
+		assert.Empty(t, bollinger.New(20).Compute(candlesFromCloses(1, 2, 3)))
+	})
+}
+
+func TestRSI(t *testing.T) {
+	t.Run("deve semear com médias simples e suavizar por Wilder", func(t *testing.T) {
+		values := rsi.New(2).Compute(candlesFromCloses(1, 2, 1, 2))
+
+		require.Len(t, values, 2)
+		assert.Equal(t, "rsi2", values[0].Indicator)
+		assert.Equal(t, time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC), values[0].Timestamp)
+		assert.Equal(t, "50", values[0].Values[indicator.SeriesValue].Decimal.String())
+		// Ganho médio 0.75 e perda média 0.25: RS = 3
+		assert.Equal(t, "75", values[1].Values[indicator.SeriesValue].Decimal.String())
+	})
+
+	t.Run("deve retornar 100 sem perdas", func(t *testing.T) {
+		values := rsi.New(3).Compute(candlesFromCloses(1, 2, 3, 4, 5))
+		require.Len(t, values, 2)
+		assert.Equal(t, "100", values[1].Values[indicator.SeriesValue].Decimal.String())
+	})
+}
+
+func TestMACD(t *testing.T) {
+	// Preço linear: MME(2) e MME(3) convergem para uma diferença constante de 0.5
+	values := macd.New(2, 3, 2).Compute(candlesFromCloses(1, 2, 3, 4, 5))
+
+	require.Len(t, values, 2)
+	assert.Equal(t, "macd2_3_2", values[0].Indicator)
+	assert.Equal(t, time.Date(2025, 1, 4, 0, 0, 0, 0, time.UTC), values[0].Timestamp)
+	for _, v := range values {
+		assert.Equal(t, "0.5", v.Values[macd.SeriesMACD].Decimal.String())
+		assert.Equal(t, "0.5", v.Values[macd.SeriesSignal].Decimal.String())
+		assert.Equal(t, "0", v.Values[macd.SeriesHistogram].Decimal.String())
+	}
+	assert.Equal(t, 4, macd.New(2, 3, 2).Lookback())
+}
+
+func TestVWAP(t *testing.T) {
+	candles := []model.Candle{
+		{Pair: "BRLBTC", High: decimal.NewFromFloat(12), Low: decimal.NewFromFloat(9), Close: decimal.NewFromFloat(9), Volume: decimal.NewFromFloat(1)},   // preço típico 10
+		{Pair: "BRLBTC", High: decimal.NewFromFloat(22), Low: decimal.NewFromFloat(18), Close: decimal.NewFromFloat(20), Volume: decimal.NewFromFloat(3)}, // preço típico 20
+		{Pair: "BRLBTC", High: decimal.NewFromFloat(31), Low: decimal.NewFromFloat(29), Close: decimal.NewFromFloat(30), Volume: decimal.NewFromFloat(0)}, // sem volume
+	}
+
+	values := vwap.New(2).Compute(candles)
+
+	require.Len(t, values, 2)
+	assert.Equal(t, "17.5", values[0].Values[vwap.SeriesVWAP].Decimal.String())  // (10*1 + 20*3) / 4
+	assert.Equal(t, "17.25", values[0].Values[vwap.SeriesVWMA].Decimal.String()) // (9*1 + 20*3) / 4
+	assert.Equal(t, "20", values[1].Values[vwap.SeriesVWAP].Decimal.String())
+
+	t.Run("deve omitir janelas sem volume", func(t *testing.T) {
+		assert.Empty(t, vwap.New(1).Compute(candles[2:]))
+	})
+}
+
+func TestOBV(t *testing.T) {
+	candles := candlesFromCloses(10, 11, 11, 9, 12)
+	for i := range candles {
+		candles[i].Volume = decimal.NewFromInt(int64(i + 1))
+	}
+
+	values := obv.New().Compute(candles)
+
+	require.Len(t, values, 5)
+	expected := []string{"0", "2", "2", "-2", "3"}
+	for i, v := range values {
+		assert.Equal(t, expected[i], v.Values[indicator.SeriesValue].Decimal.String())
+	}
+
+	t.Run("deve continuar a série a partir do valor persistido", func(t *testing.T) {
+		seed := values[2]
+		seed.Values = map[string]decimal.NullDecimal{indicator.SeriesValue: decimal.NewNullDecimal(decimal.NewFromInt(100))}
+
+		continued := obv.New().ComputeFrom(seed, candles[2:])
+
+		require.Len(t, continued, 3)
+		expected := []string{"100", "96", "101"}
+		for i, v := range continued {
+			assert.Equal(t, candles[2+i].Timestamp, v.Timestamp)
+			assert.Equal(t, expected[i], v.Values[indicator.SeriesValue].Decimal.String())
+		}
+	})
+}
+
+func TestATR(t *testing.T) {
+	candles := []model.Candle{
+		{Pair: "BRLBTC", High: decimal.NewFromFloat(10), Low: decimal.NewFromFloat(8), Close: decimal.NewFromFloat(9)},
+		{Pair: "BRLBTC", High: decimal.NewFromFloat(11), Low: decimal.NewFromFloat(9), Close: decimal.NewFromFloat(10)},  // TR = max(2, 2, 0) = 2
+		{Pair: "BRLBTC", High: decimal.NewFromFloat(14), Low: decimal.NewFromFloat(12), Close: decimal.NewFromFloat(13)}, // TR = max(2, 4, 2) = 4 (gap de alta)
+		{Pair: "BRLBTC", High: decimal.NewFromFloat(13), Low: decimal.NewFromFloat(11), Close: decimal.NewFromFloat(12)}, // TR = max(2, 0, 2) = 2
+	}
+
+	assert.Equal(t, 4.0, atr.TrueRange(candles[1], candles[2]))
+
+	values := atr.New(2).Compute(candles)
+
+	require.Len(t, values, 2)
+	assert.Equal(t, "atr2", values[0].Indicator)
+	assert.Equal(t, "3", values[0].Values[atr.SeriesATR].Decimal.String())   // (2 + 4) / 2
+	assert.Equal(t, "2.5", values[1].Values[atr.SeriesATR].Decimal.String()) // (3 * 1 + 2) / 2
+	assert.Equal(t, "0.20833333", values[1].Values[atr.SeriesATRPercent].Decimal.String())
+}
+
+func TestVolatility(t *testing.T) {
+	t.Run("deve anualizar o desvio padrão dos retornos logarítmicos", func(t *testing.T) {
+		// Retornos alternados de +ln(2) e -ln(2): desvio padrão amostral = ln(2) * sqrt(4/3)
+		values := volatility.New(4, 365).Compute(candlesFromCloses(1, 2, 1, 2, 1))
+
+		require.Len(t, values, 1)
+		expected := math.Ln2 * math.Sqrt(4.0/3.0)
+		assert.Equal(t, indicator.FromFloat(expected).String(), values[0].Values[volatility.SeriesPeriod].Decimal.String())
+		assert.Equal(t, indicator.FromFloat(expected*math.Sqrt(365)).String(), values[0].Values[volatility.SeriesAnnualized].Decimal.String())
+	})
+
+	t.Run("deve omitir janelas com fechamento não positivo", func(t *testing.T) {
+		assert.Empty(t, volatility.New(2, 365).Compute(candlesFromCloses(1, 0, 1)))
+	})
+}
+
+func TestDailyRange(t *testing.T) {
+	candles := []model.Candle{
+		{Pair: "BRLBTC", High: decimal.NewFromFloat(12), Low: decimal.NewFromFloat(8), Close: decimal.NewFromFloat(10)},  // amplitude 4 (40%)
+		{Pair: "BRLBTC", High: decimal.NewFromFloat(21), Low: decimal.NewFromFloat(19), Close: decimal.NewFromFloat(20)}, // amplitude 2 (10%)
+	}
+
+	values := dailyrange.New(2).Compute(candles)
+
+	require.Len(t, values, 1)
+	assert.Equal(t, "range2", values[0].Indicator)
+	assert.Equal(t, "2", values[0].Values[dailyrange.SeriesRange].Decimal.String())
+	assert.Equal(t, "0.1", values[0].Values[dailyrange.SeriesRangePercent].Decimal.String())
+	assert.Equal(t, "3", values[0].Values[dailyrange.SeriesAverage].Decimal.String())
+	assert.Equal(t, "0.25", values[0].Values[dailyrange.SeriesAveragePercent].Decimal.String())
+	assert.Equal(t, "4", values[0].Values[dailyrange.SeriesMax].Decimal.String())
+}
@@ -0,0 +1,179 @@
+package indicator_test
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"mms_api/internal/domain/indicator"
+	"mms_api/internal/domain/indicator/sma"
+	"mms_api/internal/domain/indicator/window"
+	"mms_api/internal/domain/model"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// randomWalk gera n fechamentos em passeio aleatório, com semente fixa para reprodutibilidade
+func randomWalk(n int) []float64 {
+	rng := rand.New(rand.NewSource(42))
+	values := make([]float64, n)
+	price := 300000.0
+	for i := range values {
+		price *= 1 + (rng.Float64()-0.5)*0.04
+		values[i] = price
+	}
+	return values
+}
+
+func TestDecimalWindow(t *testing.T) {
+	t.Run("deve manter a soma exata sem acumular erro", func(t *testing.T) {
+		w := window.NewDecimal(2)
+		w.Push(decimal.RequireFromString("0.1"))
+		_, ok := w.Mean(model.PriceScale)
+		assert.False(t, ok)
+
+		w.Push(decimal.RequireFromString("0.2"))
+		mean, ok := w.Mean(model.PriceScale)
+		require.True(t, ok)
+		assert.Equal(t, "0.15", mean.String())
+
+		// Um milhão de deslocamentos não deve introduzir nenhum resíduo
+		for i := 0; i < 1000000; i++ {
+			w.Push(decimal.RequireFromString("0.1"))
+		}
+		mean, _ = w.Mean(model.PriceScale)
+		assert.Equal(t, "0.1", mean.String())
+	})
+
+	t.Run("deve arredondar a média na escala pedida", func(t *testing.T) {
+		w := window.NewDecimal(3)
+		for _, v := range []string{"100", "200", "200"} {
+			w.Push(decimal.RequireFromString(v))
+		}
+		mean, ok := w.Mean(model.PriceScale)
+		require.True(t, ok)
+		assert.Equal(t, "166.66666667", mean.String())
+	})
+}
+
+func TestCalculator(t *testing.T) {
+	t.Run("deve alimentar várias janelas em uma passada", func(t *testing.T) {
+		calc, err := window.NewCalculator(2, 3, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []int{2, 3}, calc.Periods())
+
+		for _, v := range []int64{1, 2, 3, 4} {
+			calc.Push(decimal.NewFromInt(v))
+		}
+
+		mean, ok := calc.Mean(2, model.PriceScale)
+		require.True(t, ok)
+		assert.Equal(t, "3.5", mean.String())
+		mean, ok = calc.Mean(3, model.PriceScale)
+		require.True(t, ok)
+		assert.Equal(t, "3", mean.String())
+		_, ok = calc.Mean(5, model.PriceScale)
+		assert.False(t, ok)
+
+		_, err = window.NewCalculator(0)
+		assert.Error(t, err)
+	})
+
+	t.Run("as médias de várias janelas coincidem com as de cada SMA", func(t *testing.T) {
+		candles := benchmarkCandles(2 * 365)
+		periods := []int{7, 20, 50, 200}
+
+		byPeriod := sma.AveragesByPeriod(candles, periods...)
+		for _, period := range periods {
+			assert.Equal(t, naiveAverages(candles, period), byPeriod[period], "janela %d", period)
+		}
+		assert.Empty(t, sma.AveragesByPeriod(candles[:10], 20)[20])
+	})
+
+	t.Run("deve coincidir com a soma da janela inteira em séries longas", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip("série longa")
+		}
+		candles := benchmarkCandles(2 * 365 * 24)
+
+		byPeriod := sma.AveragesByPeriod(candles, benchmarkPeriods...)
+		for _, period := range benchmarkPeriods {
+			want := naiveAverages(candles, period)
+			require.Len(t, byPeriod[period], len(want))
+			for i := range want {
+				if !byPeriod[period][i].Value.Equal(want[i].Value) {
+					t.Fatalf("janela %d diverge no índice %d: %s != %s", period, i, byPeriod[period][i].Value, want[i].Value)
+				}
+			}
+		}
+	})
+}
+
+// naiveAverages soma a janela inteira de fechamentos para cada candle (O(n·N)), em aritmética decimal
+func naiveAverages(candles []model.Candle, period int) []indicator.Average {
+	var result []indicator.Average
+	for i := period - 1; i < len(candles); i++ {
+		sum := decimal.Zero
+		for _, c := range candles[i-period+1 : i+1] {
+			sum = sum.Add(c.Close)
+		}
+		result = append(result, indicator.Average{
+			Timestamp: candles[i].Timestamp,
+			Value:     sum.DivRound(decimal.NewFromInt(int64(period)), model.PriceScale),
+		})
+	}
+	return result
+}
+
+// benchmarkCandles gera n candles de uma hora com fechamentos em passeio aleatório
+func benchmarkCandles(n int) []model.Candle {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	candles := make([]model.Candle, n)
+	for i, v := range randomWalk(n) {
+		candles[i] = model.Candle{Pair: "BRLBTC", Timestamp: start.Add(time.Duration(i) * time.Hour), Close: decimal.NewFromFloat(v).Round(model.PriceScale)}
+	}
+	return candles
+}
+
+// Conjuntos de dados dos benchmarks: cinco anos de candles diários e dois anos de candles de uma hora
+var benchmarkDatasets = []struct {
+	name string
+	size int
+}{
+	{"daily_5y", 5 * 365},
+	{"hourly_2y", 2 * 365 * 24},
+}
+
+var benchmarkPeriods = []int{model.Period20, model.Period50, model.Period200}
+
+// BenchmarkMovingAverages compara, em aritmética decimal, a soma da janela inteira a cada candle, uma
+// passada por janela e o cálculo de todas as janelas juntas pelo window.Calculator, usado pelo serviço
+func BenchmarkMovingAverages(b *testing.B) {
+	for _, ds := range benchmarkDatasets {
+		candles := benchmarkCandles(ds.size)
+
+		b.Run(ds.name+"/naive", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, period := range benchmarkPeriods {
+					naiveAverages(candles, period)
+				}
+			}
+		})
+
+		b.Run(ds.name+"/per_window", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, period := range benchmarkPeriods {
+					sma.New(period).Averages(candles)
+				}
+			}
+		})
+
+		b.Run(ds.name+"/calculator", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sma.AveragesByPeriod(candles, benchmarkPeriods...)
+			}
+		})
+	}
+}
@@ -0,0 +1,313 @@
+package mercadobitcoin_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"mms_api/internal/adapter/out/mercadobitcoin"
+	"mms_api/internal/domain/model"
+	"mms_api/pkg/httpclient"
+	"mms_api/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCandleAPI_SymbolMapping(t *testing.T) {
+	tests := []struct {
+		name      string
+		pair      string
+		overrides map[string]string
+		want      string
+	}{
+		{name: "par BRL", pair: "BRLBTC", want: "BTC-BRL"},
+		{name: "cotação USDC", pair: "USDCBTC", want: "BTC-USDC"},
+		{name: "base de 5 letras", pair: "BRLMATIC", want: "MATIC-BRL"},
+		{name: "símbolo específico", pair: "BRLMATIC", overrides: map[string]string{"BRLMATIC": "POL-BRL"}, want: "POL-BRL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var symbol string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				symbol = r.URL.Query().Get("symbol")
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"t": []int64{1620000000},
+					"o": []string{"1"}, "c": []string{"2"}, "h": []string{"3"}, "l": []string{"0.5"}, "v": []string{"10"},
+				})
+			}))
+			defer server.Close()
+
+			api := mercadobitcoin.NewCandleAPI(server.URL, server.Client(), logger.NewLogger("[TEST] "),
+				mercadobitcoin.WithSymbolOverrides(tt.overrides),
+			)
+
+			candles, err := api.GetCandles(context.Background(), tt.pair, model.Resolution1d, time.Unix(1620000000, 0), time.Unix(1620086400, 0))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, symbol)
+			require.Len(t, candles, 1)
+			assert.Equal(t, tt.pair, candles[0].Pair)
+		})
+	}
+}
+
+func TestCandleAPI_InvalidPair(t *testing.T) {
+	api := mercadobitcoin.NewCandleAPI("http://localhost", nil, logger.NewLogger("[TEST] "))
+
+	_, err := api.GetCandles(context.Background(), "XYZBTC", model.Resolution1d, time.Now().AddDate(0, 0, -1), time.Now())
+	assert.Error(t, err)
+}
+
+func TestCandleAPI_AggregatedResolution(t *testing.T) {
+	start := time.Date(2025, 5, 15, 0, 0, 0, 0, time.UTC)
+
+	var resolution string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resolution = r.URL.Query().Get("resolution")
+
+		// Oito candles de uma hora: dois candles de quatro horas
+		var ts []int64
+		var o, c, h, l, v []string
+		for i := 0; i < 8; i++ {
+			ts = append(ts, start.Add(time.Duration(i)*time.Hour).Unix())
+			o = append(o, "100")
+			c = append(c, "101")
+			h = append(h, "102")
+			l = append(l, "99")
+			v = append(v, "1.5")
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"t": ts, "o": o, "c": c, "h": h, "l": l, "v": v})
+	}))
+	defer server.Close()
+
+	api := mercadobitcoin.NewCandleAPI(server.URL, server.Client(), logger.NewLogger("[TEST] "))
+
+	candles, err := api.GetCandles(context.Background(), "BRLBTC", model.Resolution4h, start, start.Add(4*time.Hour))
+	require.NoError(t, err)
+
+	assert.Equal(t, "1h", resolution)
+	require.Len(t, candles, 2)
+	assert.Equal(t, model.Resolution4h, candles[0].Resolution)
+	assert.True(t, candles[1].Timestamp.Equal(start.Add(4*time.Hour)))
+	assert.Equal(t, "6", candles[0].Volume.String())
+}
+
+func TestCandleAPI_DecimalPrices(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"t": []int64{1620000000},
+			"o": []string{"312345.12345678"}, "c": []string{"0.123456789"}, "h": []string{"312400.1"},
+			"l": []string{"0.00000001"}, "v": []string{"1234.5"},
+		})
+	}))
+	defer server.Close()
+
+	api := mercadobitcoin.NewCandleAPI(server.URL, server.Client(), logger.NewLogger("[TEST] "))
+
+	candles, err := api.GetCandles(context.Background(), "BRLBTC", model.Resolution1d, time.Unix(1620000000, 0), time.Unix(1620086400, 0))
+	require.NoError(t, err)
+	require.Len(t, candles, 1)
+
+	// Os preços são preservados dígito a dígito até a oitava casa decimal
+	assert.Equal(t, "312345.12345678", candles[0].Open.String())
+	assert.Equal(t, "0.12345679", candles[0].Close.String())
+	assert.Equal(t, "312400.1", candles[0].High.String())
+	assert.Equal(t, "0.00000001", candles[0].Low.String())
+	assert.Equal(t, "1234.5", candles[0].Volume.String())
+}
+
+func TestCandleAPI_MalformedResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		response map[string]interface{}
+	}{
+		{
+			name: "colunas com tamanhos diferentes",
+			response: map[string]interface{}{
+				"t": []int64{1620000000, 1620086400},
+				"o": []string{"1", "2"}, "c": []string{"2"}, "h": []string{"3", "3"}, "l": []string{"0.5", "0.5"}, "v": []string{"10", "10"},
+			},
+		},
+		{
+			name: "valor não numérico",
+			response: map[string]interface{}{
+				"t": []int64{1620000000},
+				"o": []string{"1"}, "c": []string{"2"}, "h": []string{"abc"}, "l": []string{"0.5"}, "v": []string{"10"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(tt.response)
+			}))
+			defer server.Close()
+
+			api := mercadobitcoin.NewCandleAPI(server.URL, server.Client(), logger.NewLogger("[TEST] "))
+
+			candles, err := api.GetCandles(context.Background(), "BRLBTC", model.Resolution1d, time.Unix(1620000000, 0), time.Unix(1620086400, 0))
+			assert.Error(t, err)
+			assert.Nil(t, candles)
+		})
+	}
+}
+
+// dailyServer responde com um candle diário por dia do intervalo pedido, incluindo também o dia anterior
+// a 'from' para simular janelas vizinhas que se sobrepõem
+func dailyServer(t *testing.T, handle func(from, to int64)) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		from, _ := strconv.ParseInt(r.URL.Query().Get("from"), 10, 64)
+		to, _ := strconv.ParseInt(r.URL.Query().Get("to"), 10, 64)
+		if handle != nil {
+			handle(from, to)
+		}
+
+		var ts []int64
+		var prices []string
+		for d := from - 86400; d <= to; d += 86400 {
+			ts = append(ts, d)
+			prices = append(prices, "100")
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"t": ts, "o": prices, "c": prices, "h": prices, "l": prices, "v": prices})
+	}))
+}
+
+func TestCandleAPI_ChunkedFetch(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 34) // 35 candles
+
+	var mu sync.Mutex
+	var windows [][2]int64
+	var inFlight, maxInFlight int32
+	server := dailyServer(t, func(from, to int64) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		windows = append(windows, [2]int64{from, to})
+		mu.Unlock()
+	})
+	defer server.Close()
+
+	api := mercadobitcoin.NewCandleAPI(server.URL, server.Client(), logger.NewLogger("[TEST] "),
+		mercadobitcoin.WithMaxCandlesPerRequest(10),
+		mercadobitcoin.WithConcurrency(2),
+	)
+
+	candles, err := api.GetCandles(context.Background(), "BRLBTC", model.Resolution1d, start, end)
+	require.NoError(t, err)
+
+	// Quatro janelas de até 10 candles, no máximo duas ao mesmo tempo
+	assert.Len(t, windows, 4)
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(2))
+	for _, w := range windows {
+		assert.LessOrEqual(t, (w[1]-w[0])/86400+1, int64(10))
+	}
+
+	// As janelas são unidas em ordem, sem os candles repetidos nas bordas
+	require.Len(t, candles, 36) // o dia anterior a 'start' também é devolvido pelo servidor
+	for i := 1; i < len(candles); i++ {
+		assert.True(t, candles[i].Timestamp.After(candles[i-1].Timestamp))
+	}
+	assert.True(t, candles[len(candles)-1].Timestamp.Equal(end))
+}
+
+func TestCandleAPI_ChunkedFetchErrors(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 99)
+
+	t.Run("deve falhar quando uma janela falha", func(t *testing.T) {
+		failing := start.AddDate(0, 0, 50).Unix()
+		var requests int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			from, _ := strconv.ParseInt(r.URL.Query().Get("from"), 10, 64)
+			if from == failing {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"t": []int64{}, "o": []string{}, "c": []string{}, "h": []string{}, "l": []string{}, "v": []string{}})
+		}))
+		defer server.Close()
+
+		api := mercadobitcoin.NewCandleAPI(server.URL, server.Client(), logger.NewLogger("[TEST] "),
+			mercadobitcoin.WithMaxCandlesPerRequest(10),
+			mercadobitcoin.WithConcurrency(1),
+			mercadobitcoin.WithRetryPolicy(httpclient.RetryPolicy{MaxAttempts: 1}),
+		)
+
+		candles, err := api.GetCandles(context.Background(), "BRLBTC", model.Resolution1d, start, end)
+		assert.Error(t, err)
+		assert.Nil(t, candles)
+		assert.Equal(t, int32(6), atomic.LoadInt32(&requests), "as janelas seguintes à falha não devem ser buscadas")
+	})
+
+	t.Run("deve respeitar o cancelamento do contexto", func(t *testing.T) {
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-release:
+			}
+		}))
+		defer server.Close()
+		defer close(release)
+
+		api := mercadobitcoin.NewCandleAPI(server.URL, server.Client(), logger.NewLogger("[TEST] "),
+			mercadobitcoin.WithMaxCandlesPerRequest(10),
+		)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		began := time.Now()
+		_, err := api.GetCandles(ctx, "BRLBTC", model.Resolution1d, start, end)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(began), time.Second)
+	})
+}
+
+func TestCandleAPI_CircuitBreaker(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	log := logger.NewLogger("[TEST] ")
+	breaker := httpclient.NewCircuitBreaker("mercadobitcoin", httpclient.CircuitBreakerConfig{FailureThreshold: 2, OpenTimeout: time.Minute}, log, nil)
+	api := mercadobitcoin.NewCandleAPI(server.URL, server.Client(), log,
+		mercadobitcoin.WithRetryPolicy(httpclient.RetryPolicy{MaxAttempts: 1}),
+		mercadobitcoin.WithCircuitBreaker(breaker),
+	)
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 2; i++ {
+		_, err := api.GetCandles(context.Background(), "BRLBTC", model.Resolution1d, from, from.AddDate(0, 0, 5))
+		assert.Error(t, err)
+	}
+	assert.Equal(t, httpclient.CircuitOpen, breaker.State())
+
+	_, err := api.GetCandles(context.Background(), "BRLBTC", model.Resolution1d, from, from.AddDate(0, 0, 5))
+	assert.ErrorIs(t, err, httpclient.ErrCircuitOpen)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests), "com o circuito aberto nenhuma requisição deve ser enviada")
+
+	_, err = api.GetCandles(context.Background(), "INVALIDO", model.Resolution1d, from, from.AddDate(0, 0, 5))
+	assert.NotErrorIs(t, err, httpclient.ErrCircuitOpen, "erros de validação não passam pelo circuito")
+}
@@ -22,10 +22,10 @@ func (m *MockAlertMonitor) SendAlert(alertType string, message string) {
 // MockMMSRepository é um mock do repositório MMS para testes
 type MockMMSRepository struct {
 	SaveBatchFunc             func(ctx context.Context, mms []model.MMS) error
-	FindByPairAndRangeFunc    func(ctx context.Context, pair string, from, to time.Time, period int) ([]model.MMS, error)
-	CheckDataCompletenessFunc func(ctx context.Context, pair string, from, to time.Time) (bool, []time.Time, error)
-	GetLastTimestampFunc      func(ctx context.Context, pair string) (time.Time, error)
-	GetMMSByPairFunc          func(ctx context.Context, pair string, timeframe string) ([]model.MMS, error)
+	FindByPairAndRangeFunc    func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time, period int, avgType model.AverageType) ([]model.MMS, error)
+	CheckDataCompletenessFunc func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) (bool, []time.Time, error)
+	GetLastTimestampFunc      func(ctx context.Context, pair string, resolution model.Resolution) (time.Time, error)
+	GetMMSByPairFunc          func(ctx context.Context, pair string, resolution model.Resolution, timeframe string) ([]model.MMS, error)
 	SaveMMSFunc               func(ctx context.Context, mms model.MMS) error
 }
 
@@ -36,30 +36,30 @@ func (m *MockMMSRepository) SaveBatch(ctx context.Context, mms []model.MMS) erro
 	return nil
 }
 
-func (m *MockMMSRepository) FindByPairAndTimeRange(ctx context.Context, pair string, from, to time.Time, period int) ([]model.MMS, error) {
+func (m *MockMMSRepository) FindByPairAndTimeRange(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time, period int, avgType model.AverageType) ([]model.MMS, error) {
 	if m.FindByPairAndRangeFunc != nil {
-		return m.FindByPairAndRangeFunc(ctx, pair, from, to, period)
+		return m.FindByPairAndRangeFunc(ctx, pair, resolution, from, to, period, avgType)
 	}
 	return nil, nil
 }
 
-func (m *MockMMSRepository) CheckDataCompleteness(ctx context.Context, pair string, from, to time.Time) (bool, []time.Time, error) {
+func (m *MockMMSRepository) CheckDataCompleteness(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) (bool, []time.Time, error) {
 	if m.CheckDataCompletenessFunc != nil {
-		return m.CheckDataCompletenessFunc(ctx, pair, from, to)
+		return m.CheckDataCompletenessFunc(ctx, pair, resolution, from, to)
 	}
 	return true, nil, nil
 }
 
-func (m *MockMMSRepository) GetLastTimestamp(ctx context.Context, pair string) (time.Time, error) {
+func (m *MockMMSRepository) GetLastTimestamp(ctx context.Context, pair string, resolution model.Resolution) (time.Time, error) {
 	if m.GetLastTimestampFunc != nil {
-		return m.GetLastTimestampFunc(ctx, pair)
+		return m.GetLastTimestampFunc(ctx, pair, resolution)
 	}
 	return time.Time{}, nil
 }
 
-func (m *MockMMSRepository) GetMMSByPair(ctx context.Context, pair string, timeframe string) ([]model.MMS, error) {
+func (m *MockMMSRepository) GetMMSByPair(ctx context.Context, pair string, resolution model.Resolution, timeframe string) ([]model.MMS, error) {
 	if m.GetMMSByPairFunc != nil {
-		return m.GetMMSByPairFunc(ctx, pair, timeframe)
+		return m.GetMMSByPairFunc(ctx, pair, resolution, timeframe)
 	}
 	return nil, nil
 }
@@ -73,12 +73,12 @@ func (m *MockMMSRepository) SaveMMS(ctx context.Context, mms model.MMS) error {
 
 // MockCandleAPI é um mock da API de candles para testes
 type MockCandleAPI struct {
-	GetCandlesFunc func(ctx context.Context, pair string, from, to time.Time) ([]model.Candle, error)
+	GetCandlesFunc func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) ([]model.Candle, error)
 }
 
-func (m *MockCandleAPI) GetCandles(ctx context.Context, pair string, from, to time.Time) ([]model.Candle, error) {
+func (m *MockCandleAPI) GetCandles(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) ([]model.Candle, error) {
 	if m.GetCandlesFunc != nil {
-		return m.GetCandlesFunc(ctx, pair, from, to)
+		return m.GetCandlesFunc(ctx, pair, resolution, from, to)
 	}
 	return nil, nil
 }
@@ -1,13 +1,16 @@
 package model_test
 
 import (
+	"reflect"
 	"testing"
 	"time"
 
 	"mms_api/internal/domain/model"
+
+	"github.com/shopspring/decimal"
 )
 
-func TestIsValidPair(t *testing.T) {
+func TestIsDefaultPair(t *testing.T) {
 	tests := []struct {
 		name string
 		pair string
@@ -32,13 +35,104 @@ func TestIsValidPair(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := model.IsValidPair(tt.pair); got != tt.want {
-				t.Errorf("IsValidPair() = %v, want %v", got, tt.want)
+			if got := model.IsDefaultPair(tt.pair); got != tt.want {
+				t.Errorf("IsDefaultPair() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsValidPairSymbol(t *testing.T) {
+	tests := []struct {
+		name   string
+		symbol string
+		want   bool
+	}{
+		{name: "deve aceitar BRLBTC", symbol: "BRLBTC", want: true},
+		{name: "deve aceitar símbolo com base de 4 letras", symbol: "BRLMATIC", want: true},
+		{name: "deve rejeitar minúsculas", symbol: "brlbtc", want: false},
+		{name: "deve rejeitar separadores", symbol: "BTC-BRL", want: false},
+		{name: "deve rejeitar símbolo curto", symbol: "BTC", want: false},
+		{name: "deve aceitar cotação USDC", symbol: "USDCBTC", want: true},
+		{name: "deve rejeitar símbolo maior que a coluna", symbol: "BRLABCDEFGH", want: false},
+		{name: "deve rejeitar cotação desconhecida", symbol: "XYZBTC", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := model.IsValidPairSymbol(tt.symbol); got != tt.want {
+				t.Errorf("IsValidPairSymbol(%q) = %v, want %v", tt.symbol, got, tt.want)
 			}
 		})
 	}
 }
 
+func TestParsePair(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    model.Pair
+		wantErr bool
+	}{
+		{name: "símbolo canônico BRL", value: "BRLBTC", want: model.Pair{Base: "BTC", Quote: "BRL"}},
+		{name: "base de 5 letras", value: "BRLMATIC", want: model.Pair{Base: "MATIC", Quote: "BRL"}},
+		{name: "cotação de 4 letras", value: "USDCBTC", want: model.Pair{Base: "BTC", Quote: "USDC"}},
+		{name: "prefixo mais longo vence", value: "USDTETH", want: model.Pair{Base: "ETH", Quote: "USDT"}},
+		{name: "formato com hífen", value: "btc-usdt", want: model.Pair{Base: "BTC", Quote: "USDT"}},
+		{name: "formato com barra", value: "ETH/BRL", want: model.Pair{Base: "ETH", Quote: "BRL"}},
+		{name: "cotação desconhecida", value: "XYZBTC", wantErr: true},
+		{name: "base vazia", value: "BTC-", wantErr: true},
+		{name: "base igual à cotação", value: "BRL-BRL", wantErr: true},
+		{name: "símbolo longo demais", value: "LONGTOKEN-USDT", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := model.ParsePair(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParsePair(%q) esperava erro, obteve %+v", tt.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePair(%q) erro inesperado: %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParsePair(%q) = %+v, want %+v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPairFormatting(t *testing.T) {
+	p := model.Pair{Base: "MATIC", Quote: "USDT"}
+	if got := p.String(); got != "USDTMATIC" {
+		t.Errorf("String() = %q, want USDTMATIC", got)
+	}
+
+	dashed := model.SymbolMap{Separator: "-", BaseFirst: true}
+	if got := dashed.Symbol(p); got != "MATIC-USDT" {
+		t.Errorf("Symbol() = %q, want MATIC-USDT", got)
+	}
+
+	compact := model.SymbolMap{BaseFirst: true}
+	if got := compact.Symbol(p); got != "MATICUSDT" {
+		t.Errorf("Symbol() = %q, want MATICUSDT", got)
+	}
+
+	overridden, err := dashed.WithOverrides(map[string]string{"MATIC-BRL": "POL-BRL"})
+	if err != nil {
+		t.Fatalf("WithOverrides() erro inesperado: %v", err)
+	}
+	if got := overridden.Symbol(model.Pair{Base: "MATIC", Quote: "BRL"}); got != "POL-BRL" {
+		t.Errorf("Symbol() = %q, want POL-BRL", got)
+	}
+	if got := overridden.Symbol(p); got != "MATIC-USDT" {
+		t.Errorf("Symbol() = %q, want MATIC-USDT", got)
+	}
+}
+
 func TestIsValidPeriod(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -61,8 +155,18 @@ func TestIsValidPeriod(t *testing.T) {
 			want:   true,
 		},
 		{
-			name:   "deve retornar false para período inválido",
-			period: 30,
+			name:   "deve retornar true para janela personalizada",
+			period: 21,
+			want:   true,
+		},
+		{
+			name:   "deve retornar false para período abaixo do mínimo",
+			period: 1,
+			want:   false,
+		},
+		{
+			name:   "deve retornar false para período acima do máximo",
+			period: model.MaxPeriod + 1,
 			want:   false,
 		},
 	}
@@ -76,14 +180,55 @@ func TestIsValidPeriod(t *testing.T) {
 	}
 }
 
+func TestParsePeriods(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []int
+		wantErr bool
+	}{
+		{
+			name:  "deve ordenar e remover duplicatas",
+			value: "200, 7,21,9,7",
+			want:  []int{7, 9, 21, 200},
+		},
+		{
+			name:    "deve retornar erro para valor não numérico",
+			value:   "20,abc",
+			wantErr: true,
+		},
+		{
+			name:    "deve retornar erro para período fora dos limites",
+			value:   "0,20",
+			wantErr: true,
+		},
+		{
+			name:    "deve retornar erro para lista vazia",
+			value:   " , ",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := model.ParsePeriods(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePeriods() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) && !tt.wantErr {
+				t.Errorf("ParsePeriods() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestMMS(t *testing.T) {
 	now := time.Now()
 	mms := model.MMS{
 		Pair:      "BRLBTC",
 		Timestamp: now,
-		MMS20:     50000.0,
-		MMS50:     48000.0,
-		MMS200:    45000.0,
+		Period:    model.Period20,
+		Value:     decimal.NewNullDecimal(decimal.NewFromFloat(50000.0)),
 	}
 
 	t.Run("deve criar MMS com valores corretos", func(t *testing.T) {
@@ -93,8 +238,11 @@ func TestMMS(t *testing.T) {
 		if !mms.Timestamp.Equal(now) {
 			t.Errorf("Timestamp = %v, want %v", mms.Timestamp, now)
 		}
-		if mms.MMS20 != 50000.0 {
-			t.Errorf("MMS20 = %v, want %v", mms.MMS20, 50000.0)
+		if mms.Period != model.Period20 {
+			t.Errorf("Period = %v, want %v", mms.Period, model.Period20)
+		}
+		if !mms.Value.Decimal.Equal(decimal.NewFromInt(50000)) {
+			t.Errorf("Value = %v, want %v", mms.Value, 50000.0)
 		}
 	})
 }
@@ -104,11 +252,11 @@ func TestCandle(t *testing.T) {
 	candle := model.Candle{
 		Pair:      "BRLBTC",
 		Timestamp: now,
-		Open:      45000.0,
-		High:      46000.0,
-		Low:       44000.0,
-		Close:     45500.0,
-		Volume:    1.5,
+		Open:      decimal.NewFromFloat(45000.0),
+		High:      decimal.NewFromFloat(46000.0),
+		Low:       decimal.NewFromFloat(44000.0),
+		Close:     decimal.NewFromFloat(45500.0),
+		Volume:    decimal.NewFromFloat(1.5),
 	}
 
 	t.Run("deve criar Candle com valores corretos", func(t *testing.T) {
@@ -118,11 +266,140 @@ func TestCandle(t *testing.T) {
 		if !candle.Timestamp.Equal(now) {
 			t.Errorf("Timestamp = %v, want %v", candle.Timestamp, now)
 		}
-		if candle.Open != 45000.0 {
+		if !candle.Open.Equal(decimal.NewFromInt(45000)) {
 			t.Errorf("Open = %v, want %v", candle.Open, 45000.0)
 		}
-		if candle.High <= candle.Low {
+		if candle.High.LessThanOrEqual(candle.Low) {
 			t.Errorf("High (%v) deve ser maior que Low (%v)", candle.High, candle.Low)
 		}
 	})
 }
+
+func TestResolution(t *testing.T) {
+	ts := time.Date(2025, 5, 15, 13, 45, 0, 0, time.UTC) // quinta-feira
+
+	tests := []struct {
+		resolution model.Resolution
+		truncated  time.Time
+		duration   time.Duration
+	}{
+		{model.Resolution1h, time.Date(2025, 5, 15, 13, 0, 0, 0, time.UTC), time.Hour},
+		{model.Resolution4h, time.Date(2025, 5, 15, 12, 0, 0, 0, time.UTC), 4 * time.Hour},
+		{model.Resolution1d, time.Date(2025, 5, 15, 0, 0, 0, 0, time.UTC), 24 * time.Hour},
+		{model.Resolution1w, time.Date(2025, 5, 12, 0, 0, 0, 0, time.UTC), 7 * 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.resolution), func(t *testing.T) {
+			if got := tt.resolution.Truncate(ts); !got.Equal(tt.truncated) {
+				t.Errorf("Truncate() = %v, want %v", got, tt.truncated)
+			}
+			if got := tt.resolution.Duration(); got != tt.duration {
+				t.Errorf("Duration() = %v, want %v", got, tt.duration)
+			}
+		})
+	}
+
+	if got := model.Resolution1d.PeriodsPerYear(); got != 365 {
+		t.Errorf("PeriodsPerYear() = %v, want 365", got)
+	}
+}
+
+func TestParseResolutions(t *testing.T) {
+	got, err := model.ParseResolutions("1d, 1H,1w,1d")
+	if err != nil {
+		t.Fatalf("ParseResolutions() erro inesperado: %v", err)
+	}
+	want := []model.Resolution{model.Resolution1h, model.Resolution1d, model.Resolution1w}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseResolutions() = %v, want %v", got, want)
+	}
+
+	if _, err := model.ParseResolutions("15m"); err == nil {
+		t.Error("ParseResolutions() deveria rejeitar resolução não suportada")
+	}
+}
+
+func TestAggregateCandles(t *testing.T) {
+	start := time.Date(2025, 5, 15, 0, 0, 0, 0, time.UTC)
+	var hourly []model.Candle
+	for i := 0; i < 8; i++ {
+		price := float64(100 + i)
+		hourly = append(hourly, model.Candle{
+			Pair:       "BRLBTC",
+			Resolution: model.Resolution1h,
+			Timestamp:  start.Add(time.Duration(i) * time.Hour),
+			Open:       decimal.NewFromFloat(price),
+			High:       decimal.NewFromFloat(price + 1),
+			Low:        decimal.NewFromFloat(price - 1),
+			Close:      decimal.NewFromFloat(price + 0.5),
+			Volume:     decimal.NewFromFloat(1),
+		})
+	}
+
+	got := model.AggregateCandles(hourly, model.Resolution4h)
+	if len(got) != 2 {
+		t.Fatalf("AggregateCandles() retornou %d candles, want 2", len(got))
+	}
+
+	first := got[0]
+	want := model.Candle{
+		Pair:       "BRLBTC",
+		Resolution: model.Resolution4h,
+		Timestamp:  start,
+		Open:       decimal.NewFromFloat(100),
+		High:       decimal.NewFromFloat(104),
+		Low:        decimal.NewFromFloat(99),
+		Close:      decimal.NewFromFloat(103.5),
+		Volume:     decimal.NewFromFloat(4),
+	}
+	if first.Pair != want.Pair || first.Resolution != want.Resolution || !first.Timestamp.Equal(want.Timestamp) ||
+		!first.Open.Equal(want.Open) || !first.High.Equal(want.High) || !first.Low.Equal(want.Low) ||
+		!first.Close.Equal(want.Close) || !first.Volume.Equal(want.Volume) {
+		t.Errorf("AggregateCandles()[0] = %+v, want %+v", first, want)
+	}
+	if !got[1].Timestamp.Equal(start.Add(4 * time.Hour)) {
+		t.Errorf("AggregateCandles()[1].Timestamp = %v", got[1].Timestamp)
+	}
+}
+
+func TestParseSignalTypes(t *testing.T) {
+	got, err := model.ParseSignalTypes("golden_cross, DEATH_CROSS,golden_cross")
+	if err != nil {
+		t.Fatalf("ParseSignalTypes() erro inesperado: %v", err)
+	}
+	want := []model.SignalType{model.SignalGoldenCross, model.SignalDeathCross}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseSignalTypes() = %v, want %v", got, want)
+	}
+
+	if got, err := model.ParseSignalTypes(""); err != nil || len(got) != 0 {
+		t.Errorf("ParseSignalTypes(\"\") = %v, %v; esperado nenhum filtro", got, err)
+	}
+
+	if _, err := model.ParseSignalTypes("bull_cross"); err == nil {
+		t.Error("ParseSignalTypes() deveria rejeitar tipo não suportado")
+	}
+}
+
+func TestContiguousRanges(t *testing.T) {
+	hour := func(h int) time.Time { return time.Date(2025, 1, 1, h, 0, 0, 0, time.UTC) }
+
+	got := model.Resolution1h.ContiguousRanges([]time.Time{hour(5), hour(1), hour(2), hour(2), hour(3), hour(7), hour(8)})
+	want := []model.TimeRange{
+		{From: hour(1), To: hour(3)},
+		{From: hour(5), To: hour(5)},
+		{From: hour(7), To: hour(8)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ContiguousRanges() = %v, want %v", got, want)
+	}
+
+	if formatted := model.Resolution1h.FormatTimeRanges(got[:2]); formatted != "2025-01-01 01:00 a 2025-01-01 03:00, 2025-01-01 05:00" {
+		t.Errorf("FormatTimeRanges() = %q", formatted)
+	}
+
+	if ranges := model.Resolution1d.ContiguousRanges(nil); ranges != nil {
+		t.Errorf("ContiguousRanges(nil) = %v, want nil", ranges)
+	}
+}
@@ -0,0 +1,126 @@
+package quality_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"mms_api/internal/domain/model"
+	"mms_api/internal/domain/quality"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var start = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// day cria um candle diário válido no dia start+offset, com fechamento close
+func day(offset int, close float64) model.Candle {
+	c := decimal.NewFromFloat(close)
+	return model.Candle{
+		Pair:       "BRLBTC",
+		Resolution: model.Resolution1d,
+		Timestamp:  start.AddDate(0, 0, offset),
+		Open:       c,
+		High:       c.Add(decimal.NewFromInt(1)),
+		Low:        c.Sub(decimal.NewFromInt(1)),
+		Close:      c,
+		Volume:     decimal.NewFromInt(10),
+	}
+}
+
+// problematic reúne um candle de cada problema: o dia 2 repetido, o dia 1 fora de ordem,
+// o dia 4 ausente, o dia 5 com preço zero e o dia 6 com máxima abaixo da mínima
+func problematic() []model.Candle {
+	zero := day(5, 50)
+	zero.Open = decimal.Zero
+	inverted := day(6, 60)
+	inverted.High, inverted.Low = inverted.Low, inverted.High
+	return []model.Candle{day(0, 10), day(2, 20), day(1, 15), day(2, 21), day(3, 30), zero, inverted, day(7, 70)}
+}
+
+func timestamps(candles []model.Candle) []int {
+	days := make([]int, len(candles))
+	for i, c := range candles {
+		days[i] = int(c.Timestamp.Sub(start).Hours() / 24)
+	}
+	return days
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("não deve alterar uma série correta", func(t *testing.T) {
+		candles := []model.Candle{day(0, 10), day(1, 11), day(2, 12)}
+
+		for _, policy := range quality.Policies {
+			got, report, err := quality.Validate(candles, model.Resolution1d, policy)
+			require.NoError(t, err)
+			assert.True(t, report.Clean())
+			assert.Equal(t, candles, got)
+		}
+	})
+
+	t.Run("deve detectar cada tipo de problema", func(t *testing.T) {
+		_, report, _ := quality.Validate(problematic(), model.Resolution1d, quality.PolicyDrop)
+
+		assert.Equal(t, 1, report.Count(quality.IssueOutOfOrder))
+		assert.Equal(t, 1, report.Count(quality.IssueDuplicate))
+		assert.Equal(t, 1, report.Count(quality.IssueMissing))
+		assert.Equal(t, 1, report.Count(quality.IssueNonPositive))
+		assert.Equal(t, 1, report.Count(quality.IssueHighBelowLow))
+		assert.Equal(t, "1 candle(s) fora de ordem: 2025-01-02; 1 candle(s) duplicados: 2025-01-03; "+
+			"1 candle(s) ausentes: 2025-01-05; 1 candle(s) com valores não positivos: 2025-01-06; "+
+			"1 candle(s) com máxima abaixo da mínima: 2025-01-07", report.Summary())
+	})
+
+	t.Run("reject deve recusar a série", func(t *testing.T) {
+		got, report, err := quality.Validate(problematic(), model.Resolution1d, quality.PolicyReject)
+
+		assert.True(t, errors.Is(err, quality.ErrInvalidCandles))
+		assert.Nil(t, got)
+		assert.False(t, report.Clean())
+	})
+
+	t.Run("drop deve ordenar, deduplicar e descartar os inválidos", func(t *testing.T) {
+		got, report, err := quality.Validate(problematic(), model.Resolution1d, quality.PolicyDrop)
+
+		require.NoError(t, err)
+		assert.Equal(t, []int{0, 1, 2, 3, 7}, timestamps(got))
+		assert.Equal(t, "21", got[2].Close.String(), "o último candle recebido para o timestamp prevalece")
+		assert.Equal(t, 2, report.Dropped)
+		assert.Equal(t, 0, report.Filled)
+	})
+
+	t.Run("forward_fill deve preencher as lacunas com o fechamento anterior", func(t *testing.T) {
+		got, report, err := quality.Validate(problematic(), model.Resolution1d, quality.PolicyForwardFill)
+
+		require.NoError(t, err)
+		assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7}, timestamps(got))
+		assert.Equal(t, 2, report.Dropped)
+		assert.Equal(t, 3, report.Filled)
+		for _, c := range got[4:7] {
+			assert.Equal(t, "30", c.Open.String())
+			assert.Equal(t, "30", c.High.String())
+			assert.Equal(t, "30", c.Low.String())
+			assert.Equal(t, "30", c.Close.String())
+			assert.True(t, c.Volume.IsZero())
+		}
+	})
+
+	t.Run("deve restringir o relatório a um intervalo", func(t *testing.T) {
+		_, report, _ := quality.Validate(problematic(), model.Resolution1d, quality.PolicyDrop)
+
+		recent := report.Between(start.AddDate(0, 0, 5), start.AddDate(0, 0, 7))
+		assert.Equal(t, 2, len(recent.Issues))
+		assert.True(t, report.Between(start.AddDate(0, 0, 8), start.AddDate(0, 0, 9)).Clean())
+	})
+}
+
+func TestParsePolicy(t *testing.T) {
+	policy, err := quality.ParsePolicy(" Forward_Fill ")
+	require.NoError(t, err)
+	assert.Equal(t, quality.PolicyForwardFill, policy)
+
+	_, err = quality.ParsePolicy("ignore")
+	assert.Error(t, err)
+}
@@ -0,0 +1,100 @@
+package reconciliation_test
+
+import (
+	"testing"
+	"time"
+
+	"mms_api/internal/domain/model"
+	"mms_api/internal/domain/reconciliation"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func day(d int) time.Time {
+	return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC)
+}
+
+func candle(d int, close, volume string) model.Candle {
+	return model.Candle{
+		Pair: "BRLBTC", Resolution: model.Resolution1d, Timestamp: day(d),
+		Close: decimal.RequireFromString(close), Volume: decimal.RequireFromString(volume),
+	}
+}
+
+var tolerance = reconciliation.Tolerance{Close: decimal.NewFromInt(1), Volume: decimal.NewFromInt(50)}
+
+func TestCompare(t *testing.T) {
+	t.Run("candles dentro da tolerância não divergem", func(t *testing.T) {
+		report := reconciliation.Compare("BRLBTC", model.Resolution1d,
+			"mercadobitcoin", []model.Candle{candle(1, "100", "10"), candle(2, "200", "10")},
+			"binance", []model.Candle{candle(2, "201", "14"), candle(1, "99.5", "6")},
+			tolerance)
+
+		assert.True(t, report.Clean())
+		assert.Equal(t, 2, report.Compared)
+		assert.Empty(t, report.Summary())
+	})
+
+	t.Run("fechamento e volume acima da tolerância divergem", func(t *testing.T) {
+		report := reconciliation.Compare("BRLBTC", model.Resolution1d,
+			"mercadobitcoin", []model.Candle{candle(1, "100", "10"), candle(2, "200", "10")},
+			"binance", []model.Candle{candle(1, "103.5", "10"), candle(2, "200", "16")},
+			tolerance)
+
+		require.Len(t, report.Divergences, 2)
+		assert.Equal(t, 2, report.Compared)
+
+		closeDiv := report.Divergences[0]
+		assert.Equal(t, model.DivergenceClose, closeDiv.Field)
+		assert.Equal(t, day(1), closeDiv.Timestamp)
+		assert.Equal(t, "mercadobitcoin", closeDiv.Primary)
+		assert.Equal(t, "binance", closeDiv.Secondary)
+		assert.True(t, closeDiv.PrimaryValue.Decimal.Equal(decimal.NewFromInt(100)))
+		assert.True(t, closeDiv.SecondaryValue.Decimal.Equal(decimal.RequireFromString("103.5")))
+		assert.Equal(t, "3.5", closeDiv.Difference.String())
+
+		volumeDiv := report.Divergences[1]
+		assert.Equal(t, model.DivergenceVolume, volumeDiv.Field)
+		assert.Equal(t, "60", volumeDiv.Difference.String())
+
+		assert.Equal(t, "1 candle(s) com fechamento divergente (até 3.5%): 2025-01-01; "+
+			"1 candle(s) com volume divergente (até 60%): 2025-01-02", report.Summary())
+	})
+
+	t.Run("candles presentes em apenas um provedor divergem", func(t *testing.T) {
+		report := reconciliation.Compare("BRLBTC", model.Resolution1d,
+			"mercadobitcoin", []model.Candle{candle(1, "100", "10"), candle(2, "100", "10"), candle(3, "100", "10")},
+			"binance", []model.Candle{candle(1, "100", "10"), candle(4, "100", "10")},
+			tolerance)
+
+		assert.Equal(t, 1, report.Compared)
+		assert.Equal(t, 3, report.Count(model.DivergenceMissing))
+		assert.True(t, report.Divergences[0].PrimaryValue.Valid)
+		assert.False(t, report.Divergences[0].SecondaryValue.Valid)
+		assert.False(t, report.Divergences[2].PrimaryValue.Valid)
+		assert.True(t, report.Divergences[2].SecondaryValue.Valid)
+		assert.Equal(t, "3 candle(s) ausentes em um dos provedores: 2025-01-02 a 2025-01-04", report.Summary())
+	})
+
+	t.Run("tolerância zero desativa a comparação do campo", func(t *testing.T) {
+		report := reconciliation.Compare("BRLBTC", model.Resolution1d,
+			"mercadobitcoin", []model.Candle{candle(1, "100", "10")},
+			"binance", []model.Candle{candle(1, "100", "1000")},
+			reconciliation.Tolerance{Close: decimal.NewFromInt(1)})
+
+		assert.True(t, report.Clean())
+	})
+
+	t.Run("valor principal zero diverge de qualquer valor secundário", func(t *testing.T) {
+		report := reconciliation.Compare("BRLBTC", model.Resolution1d,
+			"mercadobitcoin", []model.Candle{candle(1, "100", "0"), candle(2, "100", "0")},
+			"binance", []model.Candle{candle(1, "100", "0"), candle(2, "100", "5")},
+			tolerance)
+
+		require.Len(t, report.Divergences, 1)
+		assert.Equal(t, day(2), report.Divergences[0].Timestamp)
+		assert.Equal(t, "100", report.Divergences[0].Difference.String())
+	})
+}
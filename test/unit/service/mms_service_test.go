@@ -3,17 +3,29 @@ package service_test
 import (
 	"context"
 	"errors"
+	"strconv"
 	"testing"
 	"time"
 
 	"mms_api/internal/adapter/out/mock"
 	"mms_api/internal/application/service"
+	"mms_api/internal/domain/indicator"
+	"mms_api/internal/domain/indicator/obv"
 	"mms_api/internal/domain/model"
+	"mms_api/internal/domain/quality"
 	"mms_api/pkg/logger"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+// flatCandle cria um candle sem variação, com abertura, máxima, mínima e fechamento iguais a price
+func flatCandle(pair string, resolution model.Resolution, ts time.Time, price float64) model.Candle {
+	p := decimal.NewFromFloat(price)
+	return model.Candle{Pair: pair, Resolution: resolution, Timestamp: ts, Open: p, High: p, Low: p, Close: p}
+}
+
 func TestCalculateAndSaveMMSForRange(t *testing.T) {
 	t.Parallel() // Paralelizar teste
 	now := time.Now()
@@ -34,16 +46,16 @@ func TestCalculateAndSaveMMSForRange(t *testing.T) {
 			to:   now,
 			setupMock: func(repo *mock.MockMMSRepository, api *mock.MockCandleAPI) {
 				// Mock para retornar candles
-				api.GetCandlesFunc = func(ctx context.Context, pair string, from, to time.Time) ([]model.Candle, error) {
+				api.GetCandlesFunc = func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) ([]model.Candle, error) {
 					candles := make([]model.Candle, 250) // Dados suficientes para calcular MMS
 					for i := range candles {
 						candles[i] = model.Candle{
 							Pair:      pair,
 							Timestamp: from.AddDate(0, 0, i),
-							Open:      45000.0,
-							High:      46000.0,
-							Low:       44000.0,
-							Close:     45500.0,
+							Open:      decimal.NewFromFloat(45000.0),
+							High:      decimal.NewFromFloat(46000.0),
+							Low:       decimal.NewFromFloat(44000.0),
+							Close:     decimal.NewFromFloat(45500.0),
 						}
 					}
 					return candles, nil
@@ -72,7 +84,7 @@ func TestCalculateAndSaveMMSForRange(t *testing.T) {
 			from: now.AddDate(0, 0, -1),
 			to:   now,
 			setupMock: func(repo *mock.MockMMSRepository, api *mock.MockCandleAPI) {
-				api.GetCandlesFunc = func(ctx context.Context, pair string, from, to time.Time) ([]model.Candle, error) {
+				api.GetCandlesFunc = func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) ([]model.Candle, error) {
 					return nil, errors.New("erro ao obter candles")
 				}
 			},
@@ -94,7 +106,7 @@ func TestCalculateAndSaveMMSForRange(t *testing.T) {
 			svc := service.NewMMSService(repo, api, log)
 
 			// Act
-			err := svc.CalculateAndSaveMMSForRange(ctx, tt.pair, tt.from, tt.to)
+			err := svc.CalculateAndSaveMMSForRange(ctx, tt.pair, model.Resolution1d, tt.from, tt.to)
 
 			// Assert
 			if (err != nil) != tt.wantErr {
@@ -125,14 +137,14 @@ func TestGetMMSByPairAndRange(t *testing.T) {
 			to:     now,
 			period: model.Period20,
 			setupMock: func(repo *mock.MockMMSRepository) {
-				repo.FindByPairAndRangeFunc = func(ctx context.Context, pair string, from, to time.Time, period int) ([]model.MMS, error) {
+				repo.FindByPairAndRangeFunc = func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time, period int, avgType model.AverageType) ([]model.MMS, error) {
 					return []model.MMS{
 						{
 							Pair:      pair,
 							Timestamp: now,
-							MMS20:     50000.0,
-							MMS50:     49000.0,
-							MMS200:    48000.0,
+							Type:      avgType,
+							Period:    period,
+							Value:     decimal.NewNullDecimal(decimal.NewFromFloat(50000.0)),
 						},
 					}, nil
 				}
@@ -175,7 +187,7 @@ func TestGetMMSByPairAndRange(t *testing.T) {
 
 			svc := service.NewMMSService(repo, candleAPI, log)
 
-			result, err := svc.GetMMSByPairAndRange(ctx, tt.pair, tt.from, tt.to, tt.period)
+			result, err := svc.GetMMSByPairAndRange(ctx, tt.pair, model.Resolution1d, tt.from, tt.to, tt.period, model.AverageSimple)
 			if tt.wantErr {
 				assert.Error(t, err)
 				return
@@ -187,6 +199,774 @@ func TestGetMMSByPairAndRange(t *testing.T) {
 	}
 }
 
+func TestCalculateAndSaveMMSForRange_ConfiguredPeriods(t *testing.T) {
+	t.Parallel() // Paralelizar teste
+	ctx := context.Background()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 2)
+
+	repo := &mock.MockMMSRepository{}
+	api := &mock.MockCandleAPI{}
+	log := logger.NewLogger("[TEST] ")
+
+	// Candles com fechamento igual ao índice (1, 2, 3, ...) facilitam o cálculo esperado
+	api.GetCandlesFunc = func(ctx context.Context, pair string, resolution model.Resolution, historicalFrom, to time.Time) ([]model.Candle, error) {
+		var candles []model.Candle
+		for d := historicalFrom; !d.After(to); d = d.AddDate(0, 0, 1) {
+			candles = append(candles, flatCandle(pair, "", d, float64(len(candles)+1)))
+		}
+		return candles, nil
+	}
+
+	var saved []model.MMS
+	repo.SaveBatchFunc = func(ctx context.Context, mms []model.MMS) error {
+		saved = mms
+		return nil
+	}
+
+	svc := service.NewMMSService(repo, api, log, service.WithPeriods(21, 7, 9))
+	assert.Equal(t, []int{7, 9, 21}, svc.Periods())
+
+	err := svc.CalculateAndSaveMMSForRange(ctx, "BRLBTC", model.Resolution1d, from, to)
+	assert.NoError(t, err)
+
+	// 3 dias x 3 janelas, para MMS e MME
+	assert.Len(t, saved, 18)
+
+	// O histórico começa 21 dias antes de 'from', então 'from' é o candle 22
+	assert.Equal(t, from, saved[0].Timestamp)
+	assert.Equal(t, model.AverageSimple, saved[0].Type)
+	assert.Equal(t, 7, saved[0].Period)
+	assert.Equal(t, "19", saved[0].Value.Decimal.String()) // média de 16..22
+	assert.Equal(t, 21, saved[6].Period)
+	assert.Equal(t, from, saved[6].Timestamp)
+	assert.Equal(t, "12", saved[6].Value.Decimal.String()) // média de 2..22
+}
+
+func TestCalculateAndSaveMMSForRange_EMA(t *testing.T) {
+	t.Parallel() // Paralelizar teste
+	ctx := context.Background()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 2)
+	closes := []float64{2, 4, 6, 10, 20, 8}
+
+	repo := &mock.MockMMSRepository{}
+	api := &mock.MockCandleAPI{}
+	api.GetCandlesFunc = func(ctx context.Context, pair string, resolution model.Resolution, historicalFrom, to time.Time) ([]model.Candle, error) {
+		candles := make([]model.Candle, len(closes))
+		for i, c := range closes {
+			candles[i] = flatCandle(pair, "", historicalFrom.AddDate(0, 0, i), c)
+		}
+		return candles, nil
+	}
+
+	var emas []model.MMS
+	repo.SaveBatchFunc = func(ctx context.Context, mms []model.MMS) error {
+		for _, m := range mms {
+			if m.Type == model.AverageExponential {
+				emas = append(emas, m)
+			}
+		}
+		return nil
+	}
+
+	svc := service.NewMMSService(repo, api, logger.NewLogger("[TEST] "), service.WithPeriods(3))
+	assert.NoError(t, svc.CalculateAndSaveMMSForRange(ctx, "BRLBTC", model.Resolution1d, from, to))
+
+	// Semente = MMS(2, 4, 6) = 4 e alpha = 2/(3+1) = 0.5
+	assert.Len(t, emas, 3)
+	assert.Equal(t, from, emas[0].Timestamp)
+	assert.Equal(t, "7", emas[0].Value.Decimal.String())
+	assert.Equal(t, "13.5", emas[1].Value.Decimal.String())
+	assert.Equal(t, "10.75", emas[2].Value.Decimal.String())
+}
+
+func TestCalculateAndSaveMMSForRange_Resolutions(t *testing.T) {
+	t.Parallel() // Paralelizar teste
+	ctx := context.Background()
+	from := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	to := from.Add(3 * time.Hour)
+
+	repo := &mock.MockMMSRepository{}
+	api := &mock.MockCandleAPI{}
+
+	var requested model.Resolution
+	var requestedFrom time.Time
+	api.GetCandlesFunc = func(ctx context.Context, pair string, resolution model.Resolution, historicalFrom, to time.Time) ([]model.Candle, error) {
+		requested, requestedFrom = resolution, historicalFrom
+		var candles []model.Candle
+		for ts := historicalFrom; !ts.After(to); ts = ts.Add(time.Hour) {
+			candles = append(candles, flatCandle(pair, resolution, ts, 100))
+		}
+		return candles, nil
+	}
+
+	var saved []model.MMS
+	repo.SaveBatchFunc = func(ctx context.Context, mms []model.MMS) error {
+		saved = append(saved, mms...)
+		return nil
+	}
+
+	svc := service.NewMMSService(repo, api, logger.NewLogger("[TEST] "),
+		service.WithPeriods(5),
+		service.WithResolutions(model.Resolution1h, model.Resolution1d),
+	)
+	assert.Equal(t, []model.Resolution{model.Resolution1h, model.Resolution1d}, svc.Resolutions())
+
+	assert.NoError(t, svc.CalculateAndSaveMMSForRange(ctx, "BRLBTC", model.Resolution1h, from, to))
+
+	// O histórico é contado em candles da resolução
+	assert.Equal(t, model.Resolution1h, requested)
+	assert.Equal(t, from.Add(-5*time.Hour), requestedFrom)
+
+	// Uma MMS e uma MME por candle de uma hora do intervalo
+	assert.Len(t, saved, 8)
+	for _, m := range saved {
+		assert.Equal(t, model.Resolution1h, m.Resolution)
+	}
+
+	// Resoluções não configuradas são rejeitadas
+	err := svc.CalculateAndSaveMMSForRange(ctx, "BRLBTC", model.Resolution4h, from, to)
+	assert.EqualError(t, err, "resolução inválida")
+}
+
+func TestCalculateAndSaveMMSForRange_LocalCandles(t *testing.T) {
+	t.Parallel() // Paralelizar teste
+	ctx := context.Background()
+	day := 24 * time.Hour
+	from := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	to := from.Add(2 * day)
+
+	// Histórico local cobre do início do lookback até o primeiro dia do intervalo
+	candleRepo := &mock.MockCandleRepository{}
+	for ts := from.Add(-4 * day); !ts.After(from); ts = ts.Add(day) {
+		candleRepo.Candles = append(candleRepo.Candles, flatCandle("BRLBTC", model.Resolution1d, ts, 100))
+	}
+
+	type call struct{ from, to time.Time }
+	var calls []call
+	api := &mock.MockCandleAPI{
+		GetCandlesFunc: func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) ([]model.Candle, error) {
+			calls = append(calls, call{from, to})
+			var candles []model.Candle
+			for ts := from; !ts.After(to); ts = ts.Add(day) {
+				candles = append(candles, flatCandle(pair, resolution, ts, 200))
+			}
+			return candles, nil
+		},
+	}
+
+	var saved []model.MMS
+	repo := &mock.MockMMSRepository{
+		SaveBatchFunc: func(ctx context.Context, mms []model.MMS) error {
+			saved = append(saved, mms...)
+			return nil
+		},
+	}
+
+	svc := service.NewMMSService(repo, api, logger.NewLogger("[TEST] "),
+		service.WithPeriods(3),
+		service.WithCandleRepository(candleRepo),
+	)
+
+	assert.NoError(t, svc.CalculateAndSaveMMSForRange(ctx, "BRLBTC", model.Resolution1d, from, to))
+
+	// Só o trecho final ausente é buscado no provedor, e ele passa a ficar armazenado
+	assert.Equal(t, []call{{from.Add(day), to}}, calls)
+	assert.Len(t, candleRepo.Candles, 7)
+
+	// A MMS3 do último dia usa os dois candles buscados e um local
+	var last model.MMS
+	for _, m := range saved {
+		if m.Type == model.AverageSimple && m.Timestamp.Equal(to) {
+			last = m
+		}
+	}
+	assert.Equal(t, "166.66666667", last.Value.Decimal.String()) // 500/3 arredondado a 8 casas
+
+	// Com o histórico completo, o recálculo não depende do provedor
+	calls = nil
+	api.GetCandlesFunc = func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) ([]model.Candle, error) {
+		return nil, errors.New("provedor indisponível")
+	}
+	assert.NoError(t, svc.CalculateAndSaveMMSForRange(ctx, "BRLBTC", model.Resolution1d, from, to))
+	assert.Empty(t, calls)
+}
+
+func TestCalculateAndSaveMMSForRange_InteriorGaps(t *testing.T) {
+	t.Parallel() // Paralelizar teste
+	ctx := context.Background()
+	day := 24 * time.Hour
+	from := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	to := from.Add(2 * day)
+
+	// Histórico local cobre todo o intervalo, exceto dois candles no meio (ex.: descartados pela validação)
+	candleRepo := &mock.MockCandleRepository{}
+	for ts := from.Add(-4 * day); !ts.After(to); ts = ts.Add(day) {
+		if ts.Equal(from.Add(-day)) || ts.Equal(from.Add(day)) {
+			continue
+		}
+		candleRepo.Candles = append(candleRepo.Candles, flatCandle("BRLBTC", model.Resolution1d, ts, 100))
+	}
+
+	type call struct{ from, to time.Time }
+	var calls []call
+	api := &mock.MockCandleAPI{
+		GetCandlesFunc: func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) ([]model.Candle, error) {
+			calls = append(calls, call{from, to})
+			var candles []model.Candle
+			for ts := from; !ts.After(to); ts = ts.Add(day) {
+				candles = append(candles, flatCandle(pair, resolution, ts, 200))
+			}
+			return candles, nil
+		},
+	}
+
+	var saved []model.MMS
+	repo := &mock.MockMMSRepository{
+		SaveBatchFunc: func(ctx context.Context, mms []model.MMS) error {
+			saved = append(saved, mms...)
+			return nil
+		},
+	}
+
+	svc := service.NewMMSService(repo, api, logger.NewLogger("[TEST] "),
+		service.WithPeriods(3),
+		service.WithCandleRepository(candleRepo),
+	)
+
+	assert.NoError(t, svc.CalculateAndSaveMMSForRange(ctx, "BRLBTC", model.Resolution1d, from, to))
+
+	// Cada buraco interno é buscado no provedor e passa a ficar armazenado
+	assert.Equal(t, []call{{from.Add(-day), from.Add(-day)}, {from.Add(day), from.Add(day)}}, calls)
+	assert.Len(t, candleRepo.Candles, 7)
+
+	// A MMS3 do último dia usa o candle buscado entre dois locais, na ordem cronológica
+	var last model.MMS
+	for _, m := range saved {
+		if m.Type == model.AverageSimple && m.Timestamp.Equal(to) {
+			last = m
+		}
+	}
+	assert.Equal(t, "133.33333333", last.Value.Decimal.String()) // 400/3 arredondado a 8 casas
+}
+
+func TestCalculateAndSaveMMSForRange_Signals(t *testing.T) {
+	t.Parallel() // Paralelizar teste
+	ctx := context.Background()
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	closes := []float64{10, 10, 10, 12, 6, 5, 4, 9, 12, 13}
+	from := start.AddDate(0, 0, 4)
+	to := start.AddDate(0, 0, len(closes)-1)
+
+	api := &mock.MockCandleAPI{
+		GetCandlesFunc: func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) ([]model.Candle, error) {
+			var candles []model.Candle
+			for i, c := range closes {
+				ts := start.AddDate(0, 0, i)
+				if !ts.Before(from) && !ts.After(to) {
+					candles = append(candles, flatCandle(pair, resolution, ts, c))
+				}
+			}
+			return candles, nil
+		},
+	}
+
+	var saved []model.Signal
+	signalRepo := &mock.MockSignalRepository{
+		SaveBatchFunc: func(ctx context.Context, signals []model.Signal) error {
+			saved = append(saved, signals...)
+			return nil
+		},
+	}
+
+	svc := service.NewMMSService(&mock.MockMMSRepository{}, api, logger.NewLogger("[TEST] "),
+		service.WithPeriods(2, 3),
+		service.WithSignalRepository(signalRepo),
+	)
+	assert.NoError(t, svc.CalculateAndSaveMMSForRange(ctx, "BRLBTC", model.Resolution1d, from, to))
+
+	// Sinais das médias simples: a MMS2 cruza a MMS3 para baixo no dia 4 e para cima no dia 7,
+	// e o fechamento faz o mesmo em relação à MMS3
+	type event struct {
+		day        int
+		signalType model.SignalType
+		fast, slow int
+	}
+	var got []event
+	for _, s := range saved {
+		assert.False(t, s.Timestamp.Before(from), "sinal anterior ao intervalo")
+		if s.AverageType == model.AverageSimple && s.SlowPeriod == 3 {
+			got = append(got, event{int(s.Timestamp.Sub(start).Hours() / 24), s.Type, s.FastPeriod, s.SlowPeriod})
+		}
+	}
+	assert.Equal(t, []event{
+		{4, model.SignalDeathCross, 2, 3},
+		{4, model.SignalPriceCrossBelow, 0, 3},
+		{7, model.SignalGoldenCross, 2, 3},
+		{7, model.SignalPriceCrossAbove, 0, 3},
+	}, got)
+}
+
+func TestGetSignalsByPairAndRange(t *testing.T) {
+	t.Parallel() // Paralelizar teste
+	ctx := context.Background()
+
+	var requested []model.SignalType
+	signalRepo := &mock.MockSignalRepository{
+		FindByPairAndRangeFunc: func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time, types []model.SignalType) ([]model.Signal, error) {
+			requested = types
+			return []model.Signal{{Pair: pair, Type: model.SignalGoldenCross}}, nil
+		},
+	}
+
+	svc := service.NewMMSService(&mock.MockMMSRepository{}, &mock.MockCandleAPI{}, logger.NewLogger("[TEST] "),
+		service.WithSignalRepository(signalRepo),
+	)
+
+	result, err := svc.GetSignalsByPairAndRange(ctx, "BRLBTC", model.Resolution1d, time.Now().AddDate(0, -1, 0), time.Now(), []model.SignalType{model.SignalGoldenCross})
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, []model.SignalType{model.SignalGoldenCross}, requested)
+
+	_, err = svc.GetSignalsByPairAndRange(ctx, "BRLBTC", model.Resolution1d, time.Now(), time.Now(), []model.SignalType{"bull_cross"})
+	assert.EqualError(t, err, "tipo de sinal inválido")
+
+	_, err = svc.GetSignalsByPairAndRange(ctx, "INVALID", model.Resolution1d, time.Now(), time.Now(), nil)
+	assert.EqualError(t, err, "par inválido")
+
+	// Sem repositório de sinais configurado
+	svc = service.NewMMSService(&mock.MockMMSRepository{}, &mock.MockCandleAPI{}, logger.NewLogger("[TEST] "))
+	_, err = svc.GetSignalsByPairAndRange(ctx, "BRLBTC", model.Resolution1d, time.Now(), time.Now(), nil)
+	assert.EqualError(t, err, "repositório de sinais não configurado")
+}
+
+func TestGetMMSByPairAndRange_ConfiguredPeriods(t *testing.T) {
+	t.Parallel() // Paralelizar teste
+	ctx := context.Background()
+	now := time.Now()
+
+	repo := &mock.MockMMSRepository{}
+	repo.FindByPairAndRangeFunc = func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time, period int, avgType model.AverageType) ([]model.MMS, error) {
+		return []model.MMS{{Pair: pair, Timestamp: now, Type: avgType, Period: period, Value: decimal.NewNullDecimal(decimal.NewFromFloat(1.0))}}, nil
+	}
+
+	svc := service.NewMMSService(repo, &mock.MockCandleAPI{}, logger.NewLogger("[TEST] "), service.WithPeriods(9, 100))
+
+	result, err := svc.GetMMSByPairAndRange(ctx, "BRLBTC", model.Resolution1d, now.AddDate(0, 0, -10), now, 100, model.AverageSimple)
+	assert.NoError(t, err)
+	assert.Equal(t, 100, result[0].Period)
+
+	_, err = svc.GetMMSByPairAndRange(ctx, "BRLBTC", model.Resolution1d, now.AddDate(0, 0, -10), now, model.Period20, model.AverageSimple)
+	assert.Error(t, err, "janelas não configuradas devem ser rejeitadas")
+
+	_, err = svc.GetMMSByPairAndRange(ctx, "BRLBTC", model.Resolution1d, now.AddDate(0, 0, -10), now, 100, model.AverageType("wma"))
+	assert.Error(t, err, "tipos de média desconhecidos devem ser rejeitados")
+}
+
+func TestCalculateAndSaveMMSForRange_PartialHistory(t *testing.T) {
+	t.Parallel() // Paralelizar teste
+	ctx := context.Background()
+	listed := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := listed.AddDate(0, 0, 3)
+
+	// Par recém-listado: só há quatro candles, menos que a janela de 5
+	api := &mock.MockCandleAPI{
+		GetCandlesFunc: func(ctx context.Context, pair string, resolution model.Resolution, historicalFrom, to time.Time) ([]model.Candle, error) {
+			var candles []model.Candle
+			for d := listed; !d.After(to); d = d.AddDate(0, 0, 1) {
+				candles = append(candles, flatCandle(pair, resolution, d, float64(len(candles)+1)))
+			}
+			return candles, nil
+		},
+	}
+
+	var saved []model.MMS
+	repo := &mock.MockMMSRepository{
+		SaveBatchFunc: func(ctx context.Context, mms []model.MMS) error {
+			saved = mms
+			return nil
+		},
+	}
+
+	svc := service.NewMMSService(repo, api, logger.NewLogger("[TEST] "), service.WithPeriods(2, 5))
+
+	err := svc.CalculateAndSaveMMSForRange(ctx, "BRLBTC", model.Resolution1d, listed, to)
+	require.NoError(t, err)
+
+	// Todos os dias têm uma linha por média; só as janelas cobertas pelo histórico têm valor
+	values := make(map[string][]string)
+	for _, m := range saved {
+		key := string(m.Type) + strconv.Itoa(m.Period)
+		value := "null"
+		if m.Value.Valid {
+			value = m.Value.Decimal.String()
+		}
+		values[key] = append(values[key], value)
+	}
+	assert.Equal(t, []string{"null", "1.5", "2.5", "3.5"}, values["sma2"])
+	assert.Equal(t, []string{"null", "null", "null", "null"}, values["sma5"])
+	assert.Equal(t, []string{"null", "null", "null", "null"}, values["ema5"])
+}
+
+func TestCalculateAndSaveMMSForRange_LookbackInCandles(t *testing.T) {
+	t.Parallel() // Paralelizar teste
+	ctx := context.Background()
+	from := time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC)
+	listed := from.AddDate(0, 0, -8)
+
+	// A exchange só tem candles em dias alternados antes de 'from', a partir da listagem
+	type call struct{ from, to time.Time }
+	newAPI := func(calls *[]call) *mock.MockCandleAPI {
+		return &mock.MockCandleAPI{
+			GetCandlesFunc: func(ctx context.Context, pair string, resolution model.Resolution, start, end time.Time) ([]model.Candle, error) {
+				*calls = append(*calls, call{start, end})
+				var candles []model.Candle
+				for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+					offset := int(d.Sub(from).Hours() / 24)
+					if d.Before(listed) || (offset < 0 && offset%2 != 0) {
+						continue
+					}
+					candles = append(candles, flatCandle(pair, resolution, d, float64(100+offset)))
+				}
+				return candles, nil
+			},
+		}
+	}
+
+	t.Run("deve recuar até reunir o lookback em candles", func(t *testing.T) {
+		var calls []call
+		var saved []model.MMS
+		repo := &mock.MockMMSRepository{SaveBatchFunc: func(ctx context.Context, mms []model.MMS) error {
+			saved = mms
+			return nil
+		}}
+
+		svc := service.NewMMSService(repo, newAPI(&calls), logger.NewLogger("[TEST] "), service.WithPeriods(5))
+		require.NoError(t, svc.CalculateAndSaveMMSForRange(ctx, "BRLBTC", model.Resolution1d, from, from))
+
+		// Cinco dias de calendário trazem só dois candles; faltam dois, depois um (com o trecho dobrado)
+		assert.Equal(t, []call{
+			{from.AddDate(0, 0, -5), from},
+			{from.AddDate(0, 0, -7), from.AddDate(0, 0, -6)},
+			{from.AddDate(0, 0, -9), from.AddDate(0, 0, -8)},
+		}, calls)
+
+		require.NotEmpty(t, saved)
+		assert.Equal(t, model.AverageSimple, saved[0].Type)
+		assert.True(t, saved[0].Value.Valid)
+		assert.Equal(t, "96", saved[0].Value.Decimal.String()) // média de 92, 94, 96, 98 e 100
+	})
+
+	t.Run("deve parar na data de listagem", func(t *testing.T) {
+		var calls []call
+		var saved []model.MMS
+		repo := &mock.MockMMSRepository{SaveBatchFunc: func(ctx context.Context, mms []model.MMS) error {
+			saved = mms
+			return nil
+		}}
+
+		svc := service.NewMMSService(repo, newAPI(&calls), logger.NewLogger("[TEST] "), service.WithPeriods(10))
+		require.NoError(t, svc.CalculateAndSaveMMSForRange(ctx, "BRLBTC", model.Resolution1d, from, from))
+
+		// Só há quatro candles desde a listagem; a busca para no primeiro trecho sem candles
+		require.Len(t, calls, 2)
+		assert.True(t, calls[1].to.Before(listed))
+		require.NotEmpty(t, saved)
+		assert.False(t, saved[0].Value.Valid)
+	})
+}
+
+// alertRecorder registra os alertas enviados pelo serviço
+type alertRecorder struct {
+	alerts []string
+}
+
+func (r *alertRecorder) SendAlert(alertType string, message string) {
+	r.alerts = append(r.alerts, alertType+": "+message)
+}
+
+func TestCalculateAndSaveMMSForRange_CandleValidation(t *testing.T) {
+	t.Parallel() // Paralelizar teste
+	ctx := context.Background()
+	from := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 2)
+
+	// O provedor repete o candle de 'from' e não tem o candle do dia seguinte
+	api := &mock.MockCandleAPI{
+		GetCandlesFunc: func(ctx context.Context, pair string, resolution model.Resolution, historicalFrom, to time.Time) ([]model.Candle, error) {
+			var candles []model.Candle
+			for d := historicalFrom; !d.After(to); d = d.AddDate(0, 0, 1) {
+				if d.Equal(from.AddDate(0, 0, 1)) {
+					continue
+				}
+				candles = append(candles, flatCandle(pair, resolution, d, 100))
+			}
+			return append(candles, flatCandle(pair, resolution, from, 100)), nil
+		},
+	}
+
+	tests := []struct {
+		policy    quality.Policy
+		wantErr   bool
+		wantSaved int // MMS e MME de uma janela por dia salvo
+	}{
+		{policy: quality.PolicyReject, wantErr: true},
+		{policy: quality.PolicyDrop, wantSaved: 4},
+		{policy: quality.PolicyForwardFill, wantSaved: 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			var saved []model.MMS
+			repo := &mock.MockMMSRepository{
+				SaveBatchFunc: func(ctx context.Context, mms []model.MMS) error {
+					saved = mms
+					return nil
+				},
+			}
+			alerts := &alertRecorder{}
+
+			svc := service.NewMMSService(repo, api, logger.NewLogger("[TEST] "),
+				service.WithPeriods(2),
+				service.WithValidationPolicy(tt.policy),
+				service.WithAlertMonitor(alerts),
+			)
+
+			err := svc.CalculateAndSaveMMSForRange(ctx, "BRLBTC", model.Resolution1d, from, to)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, quality.ErrInvalidCandles)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Len(t, saved, tt.wantSaved)
+			assert.Equal(t, []string{
+				"dados_invalidos: Problemas de qualidade nos candles de BRLBTC (1d), política " + string(tt.policy) +
+					": 1 candle(s) duplicados: 2025-01-10; 1 candle(s) ausentes: 2025-01-11",
+			}, alerts.alerts)
+		})
+	}
+}
+
+// rangeIndicator é um indicador de teste que retorna a amplitude (High - Low) de cada candle
+type rangeIndicator struct{}
+
+func (rangeIndicator) Name() string  { return "range" }
+func (rangeIndicator) Lookback() int { return 1 }
+func (rangeIndicator) Compute(candles []model.Candle) []model.IndicatorValue {
+	values := make([]model.IndicatorValue, len(candles))
+	for i, c := range candles {
+		values[i] = indicator.NewValue("range", c, map[string]decimal.Decimal{"range": c.High.Sub(c.Low)})
+	}
+	return values
+}
+
+func TestCalculateAndSaveMMSForRange_RegisteredIndicators(t *testing.T) {
+	t.Parallel() // Paralelizar teste
+	ctx := context.Background()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 4)
+
+	repo := &mock.MockMMSRepository{}
+	indicatorRepo := &mock.MockIndicatorRepository{}
+	api := &mock.MockCandleAPI{}
+	api.GetCandlesFunc = func(ctx context.Context, pair string, resolution model.Resolution, historicalFrom, to time.Time) ([]model.Candle, error) {
+		var candles []model.Candle
+		for d := historicalFrom; !d.After(to); d = d.AddDate(0, 0, 1) {
+			candles = append(candles, model.Candle{Pair: pair, Timestamp: d, Open: decimal.NewFromFloat(105), High: decimal.NewFromFloat(110), Low: decimal.NewFromFloat(100), Close: decimal.NewFromFloat(105)})
+		}
+		return candles, nil
+	}
+
+	var saved []model.IndicatorValue
+	indicatorRepo.SaveBatchFunc = func(ctx context.Context, values []model.IndicatorValue) error {
+		saved = values
+		return nil
+	}
+
+	svc := service.NewMMSService(repo, api, logger.NewLogger("[TEST] "),
+		service.WithPeriods(2),
+		service.WithIndicators(rangeIndicator{}),
+		service.WithIndicatorRepository(indicatorRepo),
+	)
+	assert.Equal(t, []string{"ema2", "range", "sma2"}, svc.Indicators())
+
+	err := svc.CalculateAndSaveMMSForRange(ctx, "BRLBTC", model.Resolution1d, from, to)
+	assert.NoError(t, err)
+
+	// Apenas datas dentro do intervalo solicitado são persistidas
+	assert.Len(t, saved, 5)
+	assert.Equal(t, from, saved[0].Timestamp)
+	assert.Equal(t, "range", saved[0].Indicator)
+	assert.Equal(t, "10", saved[0].Values["range"].Decimal.String())
+}
+
+func TestCalculateAndSaveMMSForRange_CumulativeIndicator(t *testing.T) {
+	t.Parallel() // Paralelizar teste
+	ctx := context.Background()
+	from := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 2)
+
+	// Fechamentos sempre em alta com volume 1: o OBV sobe 1 a cada candle
+	api := &mock.MockCandleAPI{}
+	api.GetCandlesFunc = func(ctx context.Context, pair string, resolution model.Resolution, historicalFrom, to time.Time) ([]model.Candle, error) {
+		var candles []model.Candle
+		for d := historicalFrom; !d.After(to); d = d.AddDate(0, 0, 1) {
+			price := decimal.NewFromInt(100 + int64(d.Day()))
+			candles = append(candles, model.Candle{Pair: pair, Timestamp: d, Open: price, High: price, Low: price, Close: price, Volume: decimal.NewFromInt(1)})
+		}
+		return candles, nil
+	}
+
+	newService := func(stored []model.IndicatorValue) (service.MMSService, *[]model.IndicatorValue) {
+		indicatorRepo := &mock.MockIndicatorRepository{}
+		indicatorRepo.FindByPairAndRangeFunc = func(ctx context.Context, pair string, resolution model.Resolution, name string, rangeFrom, rangeTo time.Time) ([]model.IndicatorValue, error) {
+			assert.Equal(t, "obv", name)
+			assert.Equal(t, from.AddDate(0, 0, -1), rangeTo)
+			return stored, nil
+		}
+		var saved []model.IndicatorValue
+		indicatorRepo.SaveBatchFunc = func(ctx context.Context, values []model.IndicatorValue) error {
+			saved = values
+			return nil
+		}
+
+		svc := service.NewMMSService(&mock.MockMMSRepository{}, api, logger.NewLogger("[TEST] "),
+			service.WithPeriods(3),
+			service.WithIndicators(obv.New()),
+			service.WithIndicatorRepository(indicatorRepo),
+		)
+		return svc, &saved
+	}
+
+	t.Run("deve continuar o último valor persistido antes do intervalo", func(t *testing.T) {
+		svc, saved := newService([]model.IndicatorValue{
+			{Pair: "BRLBTC", Resolution: model.Resolution1d, Indicator: "obv", Timestamp: from.AddDate(0, 0, -1), Values: map[string]decimal.NullDecimal{indicator.SeriesValue: decimal.NewNullDecimal(decimal.NewFromInt(500))}},
+			{Pair: "BRLBTC", Resolution: model.Resolution1d, Indicator: "obv", Timestamp: from.AddDate(0, 0, -2), Values: map[string]decimal.NullDecimal{indicator.SeriesValue: decimal.NewNullDecimal(decimal.NewFromInt(499))}},
+		})
+
+		require.NoError(t, svc.CalculateAndSaveMMSForRange(ctx, "BRLBTC", model.Resolution1d, from, to))
+
+		require.Len(t, *saved, 3)
+		for i, v := range *saved {
+			assert.Equal(t, from.AddDate(0, 0, i), v.Timestamp)
+			assert.Equal(t, strconv.Itoa(501+i), v.Values[indicator.SeriesValue].Decimal.String())
+		}
+	})
+
+	t.Run("sem valor persistido deve acumular desde o primeiro candle carregado", func(t *testing.T) {
+		svc, saved := newService(nil)
+
+		require.NoError(t, svc.CalculateAndSaveMMSForRange(ctx, "BRLBTC", model.Resolution1d, from, to))
+
+		require.Len(t, *saved, 3)
+		first := (*saved)[0].Values[indicator.SeriesValue].Decimal
+		assert.True(t, first.IsPositive())
+		assert.Equal(t, first.Add(decimal.NewFromInt(2)).String(), (*saved)[2].Values[indicator.SeriesValue].Decimal.String())
+	})
+}
+
+func TestGetIndicatorByPairAndRange(t *testing.T) {
+	t.Parallel() // Paralelizar teste
+	ctx := context.Background()
+	now := time.Now()
+
+	repo := &mock.MockMMSRepository{}
+	repo.FindByPairAndRangeFunc = func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time, period int, avgType model.AverageType) ([]model.MMS, error) {
+		return []model.MMS{
+			{Pair: pair, Timestamp: now, Type: avgType, Period: period, Value: decimal.NewNullDecimal(decimal.RequireFromString("42.12345678"))},
+			{Pair: pair, Timestamp: now.AddDate(0, 0, -1), Type: avgType, Period: period}, // Histórico ainda não cobre a janela
+		}, nil
+	}
+
+	indicatorRepo := &mock.MockIndicatorRepository{}
+	indicatorRepo.FindByPairAndRangeFunc = func(ctx context.Context, pair string, resolution model.Resolution, name string, from, to time.Time) ([]model.IndicatorValue, error) {
+		return []model.IndicatorValue{{Pair: pair, Indicator: name, Timestamp: now, Values: map[string]decimal.NullDecimal{"range": decimal.NewNullDecimal(decimal.NewFromInt(1))}}}, nil
+	}
+
+	svc := service.NewMMSService(repo, &mock.MockCandleAPI{}, logger.NewLogger("[TEST] "),
+		service.WithIndicators(rangeIndicator{}),
+		service.WithIndicatorRepository(indicatorRepo),
+	)
+
+	t.Run("médias móveis devem ser lidas da tabela mms", func(t *testing.T) {
+		result, err := svc.GetIndicatorByPairAndRange(ctx, "BRLBTC", model.Resolution1d, "ema50", now.AddDate(0, 0, -1), now)
+		assert.NoError(t, err)
+		require.Len(t, result, 2)
+		assert.Equal(t, "ema50", result[0].Indicator)
+		// O valor decimal da tabela mms é repassado sem conversão para float64
+		assert.Equal(t, "42.12345678", result[0].Values[indicator.SeriesValue].Decimal.String())
+
+		// Dias sem histórico suficiente são retornados com valor nulo, como na rota /mms
+		value, ok := result[1].Values[indicator.SeriesValue]
+		assert.True(t, ok)
+		assert.False(t, value.Valid)
+	})
+
+	t.Run("demais indicadores devem ser lidos do repositório genérico", func(t *testing.T) {
+		result, err := svc.GetIndicatorByPairAndRange(ctx, "BRLBTC", model.Resolution1d, "range", now.AddDate(0, 0, -1), now)
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, "1", result[0].Values["range"].Decimal.String())
+	})
+
+	t.Run("deve retornar erro para indicador desconhecido", func(t *testing.T) {
+		_, err := svc.GetIndicatorByPairAndRange(ctx, "BRLBTC", model.Resolution1d, "rsi14", now.AddDate(0, 0, -1), now)
+		assert.Error(t, err)
+	})
+}
+
+func TestGetBollingerBands(t *testing.T) {
+	t.Parallel() // Paralelizar teste
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Hour)
+	earlier := now.Add(-time.Hour)
+
+	indicatorRepo := &mock.MockIndicatorRepository{}
+	indicatorRepo.FindByPairAndRangeFunc = func(ctx context.Context, pair string, resolution model.Resolution, name string, from, to time.Time) ([]model.IndicatorValue, error) {
+		assert.Equal(t, "bollinger20", name)
+		assert.Equal(t, model.Resolution1h, resolution)
+		stddev := map[string]decimal.NullDecimal{"stddev": decimal.NewNullDecimal(decimal.NewFromInt(5))}
+		return []model.IndicatorValue{
+			{Pair: pair, Resolution: resolution, Indicator: name, Timestamp: earlier, Values: stddev},
+			{Pair: pair, Resolution: resolution, Indicator: name, Timestamp: now, Values: stddev},
+		}, nil
+	}
+
+	// A banda do meio vem da sma20 da tabela mms, mesmo com a janela 20 fora das janelas configuradas
+	mmsRepo := &mock.MockMMSRepository{}
+	mmsRepo.FindByPairAndRangeFunc = func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time, period int, avgType model.AverageType) ([]model.MMS, error) {
+		assert.Equal(t, 20, period)
+		assert.Equal(t, model.AverageSimple, avgType)
+		return []model.MMS{
+			{Pair: pair, Resolution: resolution, Timestamp: earlier, Type: avgType, Period: period},
+			{Pair: pair, Resolution: resolution, Timestamp: now, Type: avgType, Period: period, Value: decimal.NewNullDecimal(decimal.NewFromInt(100))},
+		}, nil
+	}
+
+	svc := service.NewMMSService(mmsRepo, &mock.MockCandleAPI{}, logger.NewLogger("[TEST] "),
+		service.WithPeriods(50),
+		service.WithResolutions(model.Resolution1h),
+		service.WithIndicatorSet(service.NewIndicatorSet(indicator.Config{})),
+		service.WithIndicatorRepository(indicatorRepo),
+	)
+
+	bands, err := svc.GetBollingerBands(ctx, "BRLBTC", model.Resolution1h, now.Add(-2*time.Hour), now, 3)
+	assert.NoError(t, err)
+	require.Len(t, bands, 1, "candles sem sma não têm banda")
+	assert.True(t, bands[0].Timestamp.Equal(now))
+	assert.Equal(t, "100", bands[0].Middle.String())
+	assert.Equal(t, "115", bands[0].Upper.String())
+	assert.Equal(t, "85", bands[0].Lower.String())
+	assert.Equal(t, "0.3", bands[0].Width.String())
+
+	_, err = svc.GetBollingerBands(ctx, "BRLBTC", model.Resolution1h, now.Add(-2*time.Hour), now, 0)
+	assert.Error(t, err, "k deve ser positivo")
+
+	_, err = svc.GetBollingerBands(ctx, "BRLBTC", model.Resolution1d, now.Add(-2*time.Hour), now, 3)
+	assert.Error(t, err, "resolução fora das configuradas")
+}
+
 func TestCheckDataCompleteness(t *testing.T) {
 	t.Parallel() // Paralelizar teste
 	ctx := context.Background()
@@ -201,7 +981,7 @@ func TestCheckDataCompleteness(t *testing.T) {
 			name: "deve retornar completude com sucesso",
 			pair: "BRLBTC",
 			setupMock: func(repo *mock.MockMMSRepository) {
-				repo.CheckDataCompletenessFunc = func(ctx context.Context, pair string, from, to time.Time) (bool, []time.Time, error) {
+				repo.CheckDataCompletenessFunc = func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) (bool, []time.Time, error) {
 					return true, nil, nil
 				}
 			},
@@ -211,7 +991,7 @@ func TestCheckDataCompleteness(t *testing.T) {
 			name: "deve retornar erro para par inválido",
 			pair: "INVALID",
 			setupMock: func(repo *mock.MockMMSRepository) {
-				repo.CheckDataCompletenessFunc = func(ctx context.Context, pair string, from, to time.Time) (bool, []time.Time, error) {
+				repo.CheckDataCompletenessFunc = func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) (bool, []time.Time, error) {
 					return false, nil, errors.New("par inválido")
 				}
 			},
@@ -231,7 +1011,7 @@ func TestCheckDataCompleteness(t *testing.T) {
 
 			svc := service.NewMMSService(repo, candleAPI, log)
 
-			isComplete, missingDates, err := svc.CheckDataCompleteness(ctx, tt.pair)
+			isComplete, missingDates, err := svc.CheckDataCompleteness(ctx, tt.pair, model.Resolution1d)
 			if tt.wantErr {
 				assert.Error(t, err)
 				assert.False(t, isComplete)
@@ -261,14 +1041,14 @@ func TestAlertOnAPIError(t *testing.T) {
 	expectedError := errors.New("erro de conexão com a API")
 
 	// Configure API to return error
-	api.GetCandlesFunc = func(ctx context.Context, pair string, from, to time.Time) ([]model.Candle, error) {
+	api.GetCandlesFunc = func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) ([]model.Candle, error) {
 		return nil, expectedError
 	}
 
 	svc := service.NewMMSService(repo, api, log)
 
 	// Test error case
-	err := svc.CalculateAndSaveMMSForRange(ctx, "BRLBTC", now.Add(-24*time.Hour), now)
+	err := svc.CalculateAndSaveMMSForRange(ctx, "BRLBTC", model.Resolution1d, now.Add(-24*time.Hour), now)
 	assert.Error(t, err)
 	assert.Equal(t, expectedError, err)
 }
@@ -0,0 +1,116 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"mms_api/internal/adapter/out/mock"
+	"mms_api/internal/application/service"
+	"mms_api/internal/domain/model"
+	"mms_api/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPairRepository() *mock.MockPairRepository {
+	return &mock.MockPairRepository{
+		Pairs: []model.RegisteredPair{
+			{Symbol: "BRLBTC", Enabled: true},
+			{Symbol: "BRLETH", Enabled: true},
+			{Symbol: "BRLXRP", Enabled: false},
+		},
+	}
+}
+
+func TestPairService_EnabledPairs(t *testing.T) {
+	ctx := context.Background()
+	pairService := service.NewPairService(newPairRepository(), logger.NewLogger("[TEST] "))
+
+	pairs, err := pairService.EnabledPairs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BRLBTC", "BRLETH"}, pairs)
+
+	enabled, err := pairService.IsEnabled(ctx, "BRLXRP")
+	require.NoError(t, err)
+	assert.False(t, enabled, "par desabilitado não deve ser aceito")
+
+	enabled, err = pairService.IsEnabled(ctx, "BRLSOL")
+	require.NoError(t, err)
+	assert.False(t, enabled, "par não cadastrado não deve ser aceito")
+}
+
+func TestPairService_AddPair(t *testing.T) {
+	ctx := context.Background()
+	repo := newPairRepository()
+	pairService := service.NewPairService(repo, logger.NewLogger("[TEST] "))
+
+	pair, err := pairService.AddPair(ctx, " brlsol ", true)
+	require.NoError(t, err)
+	assert.Equal(t, "BRLSOL", pair.Symbol)
+	assert.True(t, pair.Enabled)
+
+	_, err = pairService.AddPair(ctx, "BRLSOL", true)
+	assert.ErrorIs(t, err, service.ErrPairAlreadyExists)
+
+	pair, err = pairService.AddPair(ctx, "MATIC-USDT", false)
+	require.NoError(t, err)
+	assert.Equal(t, "USDTMATIC", pair.Symbol)
+	assert.Equal(t, model.Pair{Base: "MATIC", Quote: "USDT"}, pair.Pair)
+
+	_, err = pairService.AddPair(ctx, "BTC", true)
+	assert.ErrorIs(t, err, service.ErrInvalidPairSymbol)
+
+	// TUSD cotado em USD teria o símbolo USDTUSD, lido de volta como USD cotado em USDT
+	_, err = pairService.AddPair(ctx, "TUSD-USD", true)
+	assert.ErrorIs(t, err, service.ErrInvalidPairSymbol)
+	registered, err := repo.FindBySymbol(ctx, "USDTUSD")
+	require.NoError(t, err)
+	assert.Nil(t, registered, "par ambíguo não deve ser cadastrado")
+}
+
+func TestPairService_SetPairEnabled(t *testing.T) {
+	ctx := context.Background()
+	pairService := service.NewPairService(newPairRepository(), logger.NewLogger("[TEST] "))
+
+	pair, err := pairService.SetPairEnabled(ctx, "BRLXRP", true)
+	require.NoError(t, err)
+	assert.True(t, pair.Enabled)
+
+	enabled, err := pairService.IsEnabled(ctx, "BRLXRP")
+	require.NoError(t, err)
+	assert.True(t, enabled)
+
+	_, err = pairService.SetPairEnabled(ctx, "BRLSOL", true)
+	assert.ErrorIs(t, err, service.ErrPairNotFound)
+}
+
+func TestMMSService_PairRegistry(t *testing.T) {
+	ctx := context.Background()
+	l := logger.NewLogger("[TEST] ")
+	pairService := service.NewPairService(newPairRepository(), l)
+
+	repo := &mock.MockMMSRepository{}
+	mmsService := service.NewMMSService(repo, &mock.MockCandleAPI{}, l, service.WithPairService(pairService))
+
+	from := time.Now().AddDate(0, 0, -10)
+	to := time.Now()
+
+	_, err := mmsService.GetMMSByPairAndRange(ctx, "BRLXRP", model.Resolution1d, from, to, model.Period20, model.AverageSimple)
+	assert.EqualError(t, err, "par inválido", "par desabilitado deve ser rejeitado")
+
+	_, err = mmsService.GetMMSByPairAndRange(ctx, "BRLETH", model.Resolution1d, from, to, model.Period20, model.AverageSimple)
+	assert.NoError(t, err)
+
+	// Erros do registro são propagados
+	failing := &mock.MockPairRepository{
+		FindBySymbolFunc: func(ctx context.Context, symbol string) (*model.RegisteredPair, error) {
+			return nil, errors.New("conexão perdida")
+		},
+	}
+	mmsService = service.NewMMSService(repo, &mock.MockCandleAPI{}, l, service.WithPairService(service.NewPairService(failing, l)))
+	_, err = mmsService.GetMMSByPairAndRange(ctx, "BRLBTC", model.Resolution1d, from, to, model.Period20, model.AverageSimple)
+	assert.EqualError(t, err, "conexão perdida")
+}
@@ -0,0 +1,104 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"mms_api/internal/adapter/out/mock"
+	"mms_api/internal/application/port/out"
+	"mms_api/internal/application/service"
+	"mms_api/internal/domain/model"
+	"mms_api/internal/domain/reconciliation"
+	"mms_api/pkg/logger"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// closesProvider cria um provedor que responde com candles diários a partir de from com os fechamentos
+// informados, incluindo um candle anterior ao intervalo pedido
+func closesProvider(name string, err error, closes ...int64) out.CandleProvider {
+	return out.CandleProvider{Name: name, API: &mock.MockCandleAPI{
+		GetCandlesFunc: func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) ([]model.Candle, error) {
+			if err != nil {
+				return nil, err
+			}
+			candles := []model.Candle{{Pair: pair, Timestamp: from.AddDate(0, 0, -1), Close: decimal.NewFromInt(1)}}
+			for i, c := range closes {
+				candles = append(candles, model.Candle{
+					Pair: pair, Resolution: resolution, Timestamp: from.AddDate(0, 0, i),
+					Close: decimal.NewFromInt(c), Volume: decimal.NewFromInt(10),
+				})
+			}
+			return candles, nil
+		},
+	}}
+}
+
+func TestReconciliationService_Reconcile(t *testing.T) {
+	ctx := context.Background()
+	l := logger.NewLogger("[TEST] ")
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 2)
+	tolerance := reconciliation.Tolerance{Close: decimal.NewFromInt(1), Volume: decimal.NewFromInt(50)}
+
+	t.Run("deve gravar e alertar as divergências", func(t *testing.T) {
+		var saved []model.Divergence
+		repo := &mock.MockDivergenceRepository{SaveBatchFunc: func(ctx context.Context, divergences []model.Divergence) error {
+			saved = append(saved, divergences...)
+			return nil
+		}}
+		var alerts []string
+		monitor := &mock.MockAlertMonitor{SendAlertFunc: func(alertType string, message string) {
+			alerts = append(alerts, alertType+": "+message)
+		}}
+
+		svc := service.NewReconciliationService(
+			closesProvider("mercadobitcoin", nil, 100, 100, 100),
+			closesProvider("binance", nil, 100, 105, 100),
+			repo, tolerance, l, monitor)
+
+		report, err := svc.Reconcile(ctx, "BRLBTC", model.Resolution1d, from, to)
+		require.NoError(t, err)
+		assert.Equal(t, 3, report.Compared, "o candle anterior ao intervalo não deve ser comparado")
+		require.Len(t, saved, 1)
+		assert.Equal(t, from.AddDate(0, 0, 1), saved[0].Timestamp)
+		assert.Equal(t, model.DivergenceClose, saved[0].Field)
+		assert.Equal(t, []string{
+			"divergencia_provedores: Divergências entre mercadobitcoin e binance nos candles de BRLBTC (1d): " +
+				"1 candle(s) com fechamento divergente (até 5%): 2025-01-02",
+		}, alerts)
+	})
+
+	t.Run("provedores conciliados não geram gravação nem alerta", func(t *testing.T) {
+		repo := &mock.MockDivergenceRepository{SaveBatchFunc: func(ctx context.Context, divergences []model.Divergence) error {
+			t.Fatal("nenhuma divergência deve ser gravada")
+			return nil
+		}}
+		monitor := &mock.MockAlertMonitor{SendAlertFunc: func(alertType string, message string) {
+			t.Fatal("nenhum alerta deve ser enviado")
+		}}
+
+		svc := service.NewReconciliationService(
+			closesProvider("mercadobitcoin", nil, 100, 100, 100),
+			closesProvider("binance", nil, 100, 100, 100),
+			repo, tolerance, l, monitor)
+
+		report, err := svc.Reconcile(ctx, "BRLBTC", model.Resolution1d, from, to)
+		require.NoError(t, err)
+		assert.True(t, report.Clean())
+	})
+
+	t.Run("deve falhar quando um dos provedores falha", func(t *testing.T) {
+		svc := service.NewReconciliationService(
+			closesProvider("mercadobitcoin", nil, 100, 100, 100),
+			closesProvider("binance", errors.New("timeout"), 0),
+			nil, tolerance, l, nil)
+
+		_, err := svc.Reconcile(ctx, "BRLBTC", model.Resolution1d, from, to)
+		assert.ErrorContains(t, err, "binance")
+	})
+}
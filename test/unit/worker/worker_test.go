@@ -9,51 +9,79 @@ import (
 	"mms_api/cmd/worker/bootstrap"
 	"mms_api/internal/application/service"
 	"mms_api/internal/domain/model"
+	"mms_api/internal/domain/reconciliation"
 	"mms_api/pkg/logger"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 )
 
 // Mock interfaces
 type mockMMSRepository struct {
-	getLastTimestamp       func(ctx context.Context, pair string) (time.Time, error)
+	getLastTimestamp       func(ctx context.Context, pair string, resolution model.Resolution) (time.Time, error)
 	saveBatch              func(ctx context.Context, mms []model.MMS) error
-	checkDataCompleteness  func(ctx context.Context, pair string, from, to time.Time) (bool, []time.Time, error)
-	findByPairAndTimeRange func(ctx context.Context, pair string, from, to time.Time, period int) ([]model.MMS, error)
-	getMMSByPair           func(ctx context.Context, pair string, timeframe string) ([]model.MMS, error)
+	checkDataCompleteness  func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) (bool, []time.Time, error)
+	findByPairAndTimeRange func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time, period int, avgType model.AverageType) ([]model.MMS, error)
+	getMMSByPair           func(ctx context.Context, pair string, resolution model.Resolution, timeframe string) ([]model.MMS, error)
 	saveMMS                func(ctx context.Context, mms model.MMS) error
 }
 
-func (m *mockMMSRepository) GetLastTimestamp(ctx context.Context, pair string) (time.Time, error) {
-	return m.getLastTimestamp(ctx, pair)
+func (m *mockMMSRepository) GetLastTimestamp(ctx context.Context, pair string, resolution model.Resolution) (time.Time, error) {
+	return m.getLastTimestamp(ctx, pair, resolution)
 }
 
 func (m *mockMMSRepository) SaveBatch(ctx context.Context, mms []model.MMS) error {
 	return m.saveBatch(ctx, mms)
 }
 
-func (m *mockMMSRepository) CheckDataCompleteness(ctx context.Context, pair string, from, to time.Time) (bool, []time.Time, error) {
-	return m.checkDataCompleteness(ctx, pair, from, to)
+func (m *mockMMSRepository) CheckDataCompleteness(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) (bool, []time.Time, error) {
+	return m.checkDataCompleteness(ctx, pair, resolution, from, to)
 }
 
-func (m *mockMMSRepository) FindByPairAndTimeRange(ctx context.Context, pair string, from, to time.Time, period int) ([]model.MMS, error) {
-	return m.findByPairAndTimeRange(ctx, pair, from, to, period)
+func (m *mockMMSRepository) FindByPairAndTimeRange(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time, period int, avgType model.AverageType) ([]model.MMS, error) {
+	return m.findByPairAndTimeRange(ctx, pair, resolution, from, to, period, avgType)
 }
 
-func (m *mockMMSRepository) GetMMSByPair(ctx context.Context, pair string, timeframe string) ([]model.MMS, error) {
-	return m.getMMSByPair(ctx, pair, timeframe)
+func (m *mockMMSRepository) GetMMSByPair(ctx context.Context, pair string, resolution model.Resolution, timeframe string) ([]model.MMS, error) {
+	return m.getMMSByPair(ctx, pair, resolution, timeframe)
 }
 
 func (m *mockMMSRepository) SaveMMS(ctx context.Context, mms model.MMS) error {
 	return m.saveMMS(ctx, mms)
 }
 
+type mockPairRepository struct {
+	pairs []model.RegisteredPair
+}
+
+func (m *mockPairRepository) FindAll(ctx context.Context) ([]model.RegisteredPair, error) {
+	return m.pairs, nil
+}
+
+func (m *mockPairRepository) FindBySymbol(ctx context.Context, symbol string) (*model.RegisteredPair, error) {
+	for _, p := range m.pairs {
+		if p.Symbol == symbol {
+			return &p, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockPairRepository) Create(ctx context.Context, pair model.RegisteredPair) error {
+	m.pairs = append(m.pairs, pair)
+	return nil
+}
+
+func (m *mockPairRepository) SetEnabled(ctx context.Context, symbol string, enabled bool) error {
+	return nil
+}
+
 type mockCandleAPI struct {
-	getCandles func(ctx context.Context, pair string, from, to time.Time) ([]model.Candle, error)
+	getCandles func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) ([]model.Candle, error)
 }
 
-func (m *mockCandleAPI) GetCandles(ctx context.Context, pair string, from, to time.Time) ([]model.Candle, error) {
-	return m.getCandles(ctx, pair, from, to)
+func (m *mockCandleAPI) GetCandles(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) ([]model.Candle, error) {
+	return m.getCandles(ctx, pair, resolution, from, to)
 }
 
 // mockAlertMonitor implementa a interface monitoring.AlertMonitor
@@ -86,11 +114,11 @@ func TestWorker_Run(t *testing.T) {
 			candles = append(candles, model.Candle{
 				Pair:      pair,
 				Timestamp: d,
-				Open:      basePrice,
-				High:      basePrice * 1.01,
-				Low:       basePrice * 0.99,
-				Close:     basePrice + float64(len(candles))*10, // Preço crescente para simular tendência
-				Volume:    100.0,
+				Open:      decimal.NewFromFloat(basePrice),
+				High:      decimal.NewFromFloat(basePrice * 1.01),
+				Low:       decimal.NewFromFloat(basePrice * 0.99),
+				Close:     decimal.NewFromFloat(basePrice + float64(len(candles))*10), // Preço crescente para simular tendência
+				Volume:    decimal.NewFromFloat(100.0),
 			})
 		}
 		return candles
@@ -98,20 +126,12 @@ func TestWorker_Run(t *testing.T) {
 
 	// Dados de exemplo para MMS
 	sampleMMS := []model.MMS{
-		{
-			Pair:      "BRLBTC",
-			Timestamp: yesterday,
-			MMS20:     150000.0,
-			MMS50:     145000.0,
-			MMS200:    140000.0,
-		},
-		{
-			Pair:      "BRLETH",
-			Timestamp: yesterday,
-			MMS20:     8000.0,
-			MMS50:     7500.0,
-			MMS200:    7000.0,
-		},
+		{Pair: "BRLBTC", Timestamp: yesterday, Type: model.AverageSimple, Period: model.Period20, Value: decimal.NewNullDecimal(decimal.NewFromFloat(150000.0))},
+		{Pair: "BRLBTC", Timestamp: yesterday, Type: model.AverageSimple, Period: model.Period50, Value: decimal.NewNullDecimal(decimal.NewFromFloat(145000.0))},
+		{Pair: "BRLBTC", Timestamp: yesterday, Type: model.AverageSimple, Period: model.Period200, Value: decimal.NewNullDecimal(decimal.NewFromFloat(140000.0))},
+		{Pair: "BRLETH", Timestamp: yesterday, Type: model.AverageSimple, Period: model.Period20, Value: decimal.NewNullDecimal(decimal.NewFromFloat(8000.0))},
+		{Pair: "BRLETH", Timestamp: yesterday, Type: model.AverageSimple, Period: model.Period50, Value: decimal.NewNullDecimal(decimal.NewFromFloat(7500.0))},
+		{Pair: "BRLETH", Timestamp: yesterday, Type: model.AverageSimple, Period: model.Period200, Value: decimal.NewNullDecimal(decimal.NewFromFloat(7000.0))},
 	}
 
 	tests := []struct {
@@ -124,12 +144,12 @@ func TestWorker_Run(t *testing.T) {
 			name: "processamento bem sucedido sem dados anteriores",
 			setupMocks: func(repo *mockMMSRepository, api *mockCandleAPI, monitor *mockAlertMonitor) {
 				// Mock GetLastTimestamp retornando zero (sem dados)
-				repo.getLastTimestamp = func(ctx context.Context, pair string) (time.Time, error) {
+				repo.getLastTimestamp = func(ctx context.Context, pair string, resolution model.Resolution) (time.Time, error) {
 					return time.Time{}, nil
 				}
 
 				// Mock GetCandles retornando dados históricos suficientes
-				api.getCandles = func(ctx context.Context, pair string, from, to time.Time) ([]model.Candle, error) {
+				api.getCandles = func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) ([]model.Candle, error) {
 					// Gerar 250 dias de dados históricos
 					historicalFrom := from.AddDate(0, 0, -250)
 					return generateHistoricalCandles(pair, historicalFrom, to), nil
@@ -141,12 +161,12 @@ func TestWorker_Run(t *testing.T) {
 				}
 
 				// Mock CheckDataCompleteness retornando sucesso
-				repo.checkDataCompleteness = func(ctx context.Context, pair string, from, to time.Time) (bool, []time.Time, error) {
+				repo.checkDataCompleteness = func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) (bool, []time.Time, error) {
 					return true, nil, nil
 				}
 
 				// Mock FindByPairAndTimeRange retornando dados históricos suficientes
-				repo.findByPairAndTimeRange = func(ctx context.Context, pair string, from, to time.Time, period int) ([]model.MMS, error) {
+				repo.findByPairAndTimeRange = func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time, period int, avgType model.AverageType) ([]model.MMS, error) {
 					var result []model.MMS
 					for _, mms := range sampleMMS {
 						if mms.Pair == pair {
@@ -157,7 +177,7 @@ func TestWorker_Run(t *testing.T) {
 				}
 
 				// Mock GetMMSByPair
-				repo.getMMSByPair = func(ctx context.Context, pair string, timeframe string) ([]model.MMS, error) {
+				repo.getMMSByPair = func(ctx context.Context, pair string, resolution model.Resolution, timeframe string) ([]model.MMS, error) {
 					var result []model.MMS
 					for _, mms := range sampleMMS {
 						if mms.Pair == pair {
@@ -178,12 +198,12 @@ func TestWorker_Run(t *testing.T) {
 			name: "erro ao obter candles deve gerar retry e alerta",
 			setupMocks: func(repo *mockMMSRepository, api *mockCandleAPI, monitor *mockAlertMonitor) {
 				// Mock GetLastTimestamp
-				repo.getLastTimestamp = func(ctx context.Context, pair string) (time.Time, error) {
+				repo.getLastTimestamp = func(ctx context.Context, pair string, resolution model.Resolution) (time.Time, error) {
 					return lastYear, nil
 				}
 
 				// Mock GetCandles sempre retornando erro
-				api.getCandles = func(ctx context.Context, pair string, from, to time.Time) ([]model.Candle, error) {
+				api.getCandles = func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) ([]model.Candle, error) {
 					return nil, errors.New("erro de conexão")
 				}
 
@@ -193,12 +213,12 @@ func TestWorker_Run(t *testing.T) {
 				}
 
 				// Mock CheckDataCompleteness
-				repo.checkDataCompleteness = func(ctx context.Context, pair string, from, to time.Time) (bool, []time.Time, error) {
+				repo.checkDataCompleteness = func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) (bool, []time.Time, error) {
 					return true, nil, nil
 				}
 
 				// Mock FindByPairAndTimeRange retornando dados históricos suficientes
-				repo.findByPairAndTimeRange = func(ctx context.Context, pair string, from, to time.Time, period int) ([]model.MMS, error) {
+				repo.findByPairAndTimeRange = func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time, period int, avgType model.AverageType) ([]model.MMS, error) {
 					var result []model.MMS
 					for _, mms := range sampleMMS {
 						if mms.Pair == pair {
@@ -209,7 +229,7 @@ func TestWorker_Run(t *testing.T) {
 				}
 
 				// Mock GetMMSByPair
-				repo.getMMSByPair = func(ctx context.Context, pair string, timeframe string) ([]model.MMS, error) {
+				repo.getMMSByPair = func(ctx context.Context, pair string, resolution model.Resolution, timeframe string) ([]model.MMS, error) {
 					var result []model.MMS
 					for _, mms := range sampleMMS {
 						if mms.Pair == pair {
@@ -238,12 +258,12 @@ func TestWorker_Run(t *testing.T) {
 			name: "dados incompletos devem gerar alerta",
 			setupMocks: func(repo *mockMMSRepository, api *mockCandleAPI, monitor *mockAlertMonitor) {
 				// Mock GetLastTimestamp
-				repo.getLastTimestamp = func(ctx context.Context, pair string) (time.Time, error) {
+				repo.getLastTimestamp = func(ctx context.Context, pair string, resolution model.Resolution) (time.Time, error) {
 					return lastYear, nil
 				}
 
 				// Mock GetCandles retornando dados insuficientes
-				api.getCandles = func(ctx context.Context, pair string, from, to time.Time) ([]model.Candle, error) {
+				api.getCandles = func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) ([]model.Candle, error) {
 					// Gerar apenas 150 dias de dados (insuficiente para MMS200)
 					historicalFrom := from.AddDate(0, 0, -150)
 					return generateHistoricalCandles(pair, historicalFrom, to), nil
@@ -255,12 +275,12 @@ func TestWorker_Run(t *testing.T) {
 				}
 
 				// Mock CheckDataCompleteness retornando dados incompletos
-				repo.checkDataCompleteness = func(ctx context.Context, pair string, from, to time.Time) (bool, []time.Time, error) {
+				repo.checkDataCompleteness = func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) (bool, []time.Time, error) {
 					return false, []time.Time{yesterday.Add(-48 * time.Hour)}, nil
 				}
 
 				// Mock FindByPairAndTimeRange retornando dados históricos suficientes
-				repo.findByPairAndTimeRange = func(ctx context.Context, pair string, from, to time.Time, period int) ([]model.MMS, error) {
+				repo.findByPairAndTimeRange = func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time, period int, avgType model.AverageType) ([]model.MMS, error) {
 					var result []model.MMS
 					for _, mms := range sampleMMS {
 						if mms.Pair == pair {
@@ -271,7 +291,7 @@ func TestWorker_Run(t *testing.T) {
 				}
 
 				// Mock GetMMSByPair
-				repo.getMMSByPair = func(ctx context.Context, pair string, timeframe string) ([]model.MMS, error) {
+				repo.getMMSByPair = func(ctx context.Context, pair string, resolution model.Resolution, timeframe string) ([]model.MMS, error) {
 					var result []model.MMS
 					for _, mms := range sampleMMS {
 						if mms.Pair == pair {
@@ -311,11 +331,15 @@ func TestWorker_Run(t *testing.T) {
 			// Criar logger
 			l := logger.NewLogger("[TEST] ")
 
-			// Criar serviço com os mocks
-			mmsService := service.NewMMSService(mockRepo, mockAPI, l)
+			// Criar serviços com os mocks
+			pairService := service.NewPairService(&mockPairRepository{pairs: []model.RegisteredPair{
+				{Symbol: "BRLBTC", Enabled: true},
+				{Symbol: "BRLETH", Enabled: true},
+			}}, l)
+			mmsService := service.NewMMSService(mockRepo, mockAPI, l, service.WithPairService(pairService))
 
 			// Criar worker com os mocks
-			worker := bootstrap.NewWorkerWithDeps(mmsService, mockRepo, mockMonitor, l)
+			worker := bootstrap.NewWorkerWithDeps(mmsService, pairService, mockRepo, mockMonitor, l)
 
 			// Configurar intervalo de retry menor para os testes
 			worker.SetRetryInterval(100 * time.Millisecond)
@@ -344,3 +368,172 @@ func TestWorker_Run(t *testing.T) {
 		})
 	}
 }
+
+func TestWorker_Run_OnlyEnabledPairs(t *testing.T) {
+	l := logger.NewLogger("[TEST] ")
+
+	var processed []string
+	repo := &mockMMSRepository{
+		getLastTimestamp: func(ctx context.Context, pair string, resolution model.Resolution) (time.Time, error) {
+			processed = append(processed, pair)
+			return time.Now(), nil // Dados já atualizados
+		},
+		checkDataCompleteness: func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) (bool, []time.Time, error) {
+			return true, nil, nil
+		},
+	}
+
+	pairService := service.NewPairService(&mockPairRepository{pairs: []model.RegisteredPair{
+		{Symbol: "BRLBTC", Enabled: true},
+		{Symbol: "BRLETH", Enabled: false},
+		{Symbol: "BRLSOL", Enabled: true},
+	}}, l)
+	mmsService := service.NewMMSService(repo, &mockCandleAPI{}, l, service.WithPairService(pairService))
+
+	worker := bootstrap.NewWorkerWithDeps(mmsService, pairService, repo, &mockAlertMonitor{}, l)
+	assert.NoError(t, worker.Run())
+	assert.Equal(t, []string{"BRLBTC", "BRLSOL"}, processed)
+}
+
+func TestWorker_Run_ConfiguredResolutions(t *testing.T) {
+	l := logger.NewLogger("[TEST] ")
+
+	var processed []string
+	repo := &mockMMSRepository{
+		getLastTimestamp: func(ctx context.Context, pair string, resolution model.Resolution) (time.Time, error) {
+			processed = append(processed, pair+"/"+string(resolution))
+			return time.Now(), nil // Dados já atualizados
+		},
+		checkDataCompleteness: func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) (bool, []time.Time, error) {
+			return true, nil, nil
+		},
+	}
+
+	pairService := service.NewPairService(&mockPairRepository{pairs: []model.RegisteredPair{
+		{Symbol: "BRLBTC", Enabled: true},
+	}}, l)
+	mmsService := service.NewMMSService(repo, &mockCandleAPI{}, l,
+		service.WithPairService(pairService),
+		service.WithResolutions(model.Resolution1d, model.Resolution4h),
+	)
+
+	worker := bootstrap.NewWorkerWithDeps(mmsService, pairService, repo, &mockAlertMonitor{}, l)
+	assert.NoError(t, worker.Run())
+	assert.Equal(t, []string{"BRLBTC/4h", "BRLBTC/1d"}, processed)
+}
+
+func TestWorker_Run_BackfillGaps(t *testing.T) {
+	l := logger.NewLogger("[TEST] ")
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC) }
+
+	// Lacunas: dias 10 a 12 (contíguos), dia 15 (sem candle na exchange) e dia 20 (acima do limite)
+	missing := []time.Time{day(11), day(10), day(12), day(15), day(20)}
+	noCandle := day(15)
+
+	saved := make(map[int64]bool)
+	repo := &mockMMSRepository{
+		getLastTimestamp: func(ctx context.Context, pair string, resolution model.Resolution) (time.Time, error) {
+			return time.Now(), nil // Dados já atualizados
+		},
+		saveBatch: func(ctx context.Context, mms []model.MMS) error {
+			for _, m := range mms {
+				saved[m.Timestamp.Unix()] = true
+			}
+			return nil
+		},
+		checkDataCompleteness: func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) (bool, []time.Time, error) {
+			var remaining []time.Time
+			for _, ts := range missing {
+				if !saved[ts.Unix()] {
+					remaining = append(remaining, ts)
+				}
+			}
+			return len(remaining) == 0, remaining, nil
+		},
+	}
+
+	var requested []string
+	api := &mockCandleAPI{
+		getCandles: func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) ([]model.Candle, error) {
+			requested = append(requested, from.Format("02")+"-"+to.Format("02"))
+			var candles []model.Candle
+			for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+				if d.Equal(noCandle) {
+					continue
+				}
+				price := decimal.NewFromFloat(100)
+				candles = append(candles, model.Candle{Pair: pair, Timestamp: d, Open: price, High: price, Low: price, Close: price})
+			}
+			return candles, nil
+		},
+	}
+
+	var alerts []string
+	monitor := &mockAlertMonitor{sendAlert: func(alertType string, message string) {
+		alerts = append(alerts, alertType+": "+message)
+	}}
+
+	pairService := service.NewPairService(&mockPairRepository{pairs: []model.RegisteredPair{
+		{Symbol: "BRLBTC", Enabled: true},
+	}}, l)
+	mmsService := service.NewMMSService(repo, api, l, service.WithPairService(pairService), service.WithPeriods(2))
+
+	worker := bootstrap.NewWorkerWithDeps(mmsService, pairService, repo, monitor, l)
+	worker.SetBackfillLimit(2)
+	assert.NoError(t, worker.Run())
+
+	// Cada intervalo contíguo é recalculado uma vez, com o histórico da maior janela
+	assert.Equal(t, []string{"08-12", "13-15"}, requested)
+	assert.True(t, saved[day(10).Unix()] && saved[day(11).Unix()] && saved[day(12).Unix()])
+
+	assert.Equal(t, []string{
+		"dados_incompletos: Dados incompletos para BRLBTC (1d). Lacunas não preenchidas: 2025-03-15. " +
+			"1 lacuna(s) adiada(s) para a próxima execução: 2025-03-20",
+	}, alerts)
+}
+
+// recordingReconciler registra os intervalos reconciliados pelo worker
+type recordingReconciler struct {
+	ranges []model.TimeRange
+	err    error
+}
+
+func (r *recordingReconciler) Reconcile(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) (reconciliation.Report, error) {
+	r.ranges = append(r.ranges, model.TimeRange{From: from, To: to})
+	return reconciliation.Report{Pair: pair, Resolution: resolution}, r.err
+}
+
+func TestWorker_Run_Reconciliation(t *testing.T) {
+	l := logger.NewLogger("[TEST] ")
+	repo := &mockMMSRepository{
+		getLastTimestamp: func(ctx context.Context, pair string, resolution model.Resolution) (time.Time, error) {
+			return time.Now(), nil // Dados já atualizados
+		},
+		checkDataCompleteness: func(ctx context.Context, pair string, resolution model.Resolution, from, to time.Time) (bool, []time.Time, error) {
+			return true, nil, nil
+		},
+	}
+	pairService := service.NewPairService(&mockPairRepository{pairs: []model.RegisteredPair{
+		{Symbol: "BRLBTC", Enabled: true},
+	}}, l)
+	mmsService := service.NewMMSService(repo, &mockCandleAPI{}, l, service.WithPairService(pairService))
+
+	t.Run("reconcilia os últimos candles completos", func(t *testing.T) {
+		reconciler := &recordingReconciler{}
+		worker := bootstrap.NewWorkerWithDeps(mmsService, pairService, repo, &mockAlertMonitor{}, l)
+		worker.SetReconciliation(reconciler, 7)
+		assert.NoError(t, worker.Run())
+
+		lastComplete := model.Resolution1d.Truncate(time.Now()).AddDate(0, 0, -1)
+		assert.Equal(t, []model.TimeRange{{From: lastComplete.AddDate(0, 0, -6), To: lastComplete}}, reconciler.ranges)
+	})
+
+	t.Run("falha na reconciliação não interrompe a execução", func(t *testing.T) {
+		reconciler := &recordingReconciler{err: errors.New("timeout")}
+		worker := bootstrap.NewWorkerWithDeps(mmsService, pairService, repo, &mockAlertMonitor{}, l)
+		worker.SetReconciliation(reconciler, 1)
+		assert.NoError(t, worker.Run())
+		assert.Len(t, reconciler.ranges, 1)
+		assert.Equal(t, reconciler.ranges[0].From, reconciler.ranges[0].To)
+	})
+}